@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// listFlag collects a flag given several times.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// generateSlots runs the generate-slots command, which creates sales slots
+// from a daily pattern:
+//
+//	timeseats generate-slots -from 2026-11-03 -to 2026-11-04 -open 10:00 -close 16:00 \
+//		-length 30m -break 12:00-13:00 -product <product ID>=20 -dry-run
+//
+// A product may be given as <product ID>=<quantity>@<price> to set a price
+// for the slots.
+func generateSlots(ctx context.Context, service services.SalesSlotService, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("generate-slots", flag.ContinueOnError)
+	booth := fs.String("booth", "", "booth the slots sell at")
+	from := fs.String("from", "", "first day (YYYY-MM-DD)")
+	to := fs.String("to", "", "last day (YYYY-MM-DD), defaults to -from")
+	open := fs.String("open", "", "daily opening time (HH:MM)")
+	closing := fs.String("close", "", "daily closing time (HH:MM)")
+	length := fs.Duration("length", 30*time.Minute, "slot length")
+	zone := fs.String("tz", "Asia/Tokyo", "time zone of the days and times")
+	dryRun := fs.Bool("dry-run", false, "only show the slots")
+	var breaks, products listFlag
+	fs.Var(&breaks, "break", "break without slots (HH:MM-HH:MM), repeatable")
+	fs.Var(&products, "product", "product and quantity for each slot (ID=QUANTITY[@PRICE]), repeatable")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *to == "" {
+		*to = *from
+	}
+
+	location, err := time.LoadLocation(*zone)
+	if err != nil {
+		return fmt.Errorf("invalid -tz: %w", err)
+	}
+	firstDay, err := time.Parse(time.DateOnly, *from)
+	if err != nil {
+		return fmt.Errorf("invalid -from: %q", *from)
+	}
+	lastDay, err := time.Parse(time.DateOnly, *to)
+	if err != nil {
+		return fmt.Errorf("invalid -to: %q", *to)
+	}
+	opensAt, ok := services.ParseClock(*open)
+	if !ok {
+		return fmt.Errorf("invalid -open: %q", *open)
+	}
+	closesAt, ok := services.ParseClock(*closing)
+	if !ok {
+		return fmt.Errorf("invalid -close: %q", *closing)
+	}
+
+	pattern := services.SlotPattern{
+		Booth:      *booth,
+		FirstDay:   firstDay,
+		LastDay:    lastDay,
+		OpensAt:    opensAt,
+		ClosesAt:   closesAt,
+		SlotLength: *length,
+		Location:   location,
+	}
+	for _, b := range breaks {
+		start, end, _ := strings.Cut(b, "-")
+		startAt, ok1 := services.ParseClock(start)
+		endAt, ok2 := services.ParseClock(end)
+		if !ok1 || !ok2 {
+			return fmt.Errorf("invalid -break: %q", b)
+		}
+		pattern.Breaks = append(pattern.Breaks, services.SlotBreak{Start: startAt, End: endAt})
+	}
+	for _, p := range products {
+		product, err := parseTemplateProduct(p)
+		if err != nil {
+			return err
+		}
+		pattern.Products = append(pattern.Products, product)
+	}
+
+	generated, err := service.GenerateSalesSlots(ctx, pattern, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range generated {
+		state := "exists"
+		if g.Created {
+			state = "new"
+		}
+		fmt.Fprintf(out, "%s-%s %s, %d products added",
+			g.Slot.StartTime.In(location).Format("2006-01-02 15:04"),
+			g.Slot.EndTime.In(location).Format("15:04"),
+			state, len(g.AddedProducts))
+		for _, c := range g.Conflicts {
+			fmt.Fprintf(out, ", overlaps %s (%s-%s)", c.ID,
+				c.StartTime.In(location).Format("2006-01-02 15:04"),
+				c.EndTime.In(location).Format("15:04"))
+		}
+		fmt.Fprintln(out)
+	}
+	if *dryRun {
+		fmt.Fprintf(out, "%d slots previewed; nothing was written\n", len(generated))
+	}
+	return nil
+}
+
+func parseTemplateProduct(s string) (services.SlotTemplateProduct, error) {
+	id, rest, ok := strings.Cut(s, "=")
+	if !ok || id == "" {
+		return services.SlotTemplateProduct{}, fmt.Errorf("invalid -product: %q", s)
+	}
+	quantity, price, hasPrice := strings.Cut(rest, "@")
+
+	product := services.SlotTemplateProduct{ProductID: types.ID(id)}
+	var err error
+	if product.InitialQuantity, err = strconv.Atoi(quantity); err != nil {
+		return services.SlotTemplateProduct{}, fmt.Errorf("invalid -product quantity: %q", s)
+	}
+	if hasPrice {
+		p, err := strconv.Atoi(price)
+		if err != nil {
+			return services.SlotTemplateProduct{}, fmt.Errorf("invalid -product price: %q", s)
+		}
+		product.Price = &p
+	}
+	return product, nil
+}
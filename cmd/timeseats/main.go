@@ -1,14 +1,28 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
 	"errors"
 	"log"
 	"os"
+	"strconv"
+	"time"
+	// Embedded so that slot generation can load time zones on hosts
+	// without a zoneinfo database.
+	_ "time/tzdata"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/api"
+	domainevents "github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/infrastructure/database"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/infrastructure/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/infrastructure/printing"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/infrastructure/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/infrastructure/scheduler"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/infrastructure/storage"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -34,19 +48,163 @@ func main() {
 	db := database.GetDB()
 
 	productRepo := repositories.NewProductRepository(db)
+	categoryRepo := repositories.NewCategoryRepository(db)
+	productOptionGroupRepo := repositories.NewProductOptionGroupRepository(db)
 	salesSlotRepo := repositories.NewSalesSlotRepository(db)
 	productInventoryRepo := repositories.NewProductInventoryRepository(db)
 	orderRepo := repositories.NewOrderRepository(db)
 	orderTicketRepo := repositories.NewOrderTicketRepository(db)
+	promotionRepo := repositories.NewPromotionRepository(db)
+	productPriceRepo := repositories.NewProductPriceRepository(db)
+	ingredientRepo := repositories.NewIngredientRepository(db)
+
+	receiptTitle := os.Getenv("RECEIPT_TITLE")
+	if receiptTitle == "" {
+		receiptTitle = "TimesEats"
+	}
+
+	var printer receipt.Printer
+	if addr := os.Getenv("PRINTER_ADDR"); addr != "" {
+		queue := printing.NewQueue(printing.NewTCPPrinter(addr), 64)
+		defer queue.Close()
+		printer = queue
+	} else if dir := os.Getenv("PRINTER_OUTPUT_DIR"); dir != "" {
+		queue := printing.NewQueue(printing.NewFilePrinter(dir), 64)
+		defer queue.Close()
+		printer = queue
+	}
+
+	imageDir := os.Getenv("IMAGE_DIR")
+	if imageDir == "" {
+		imageDir = "./data/images"
+	}
+
+	signingSecret := []byte(os.Getenv("TICKET_SIGNING_SECRET"))
+	if len(signingSecret) == 0 {
+		log.Println("TICKET_SIGNING_SECRET is not set; ticket QR codes will be invalidated on restart")
+		signingSecret = make([]byte, 32)
+		if _, err := rand.Read(signingSecret); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	taxPolicy := services.DefaultTaxPolicy()
+	if mode := os.Getenv("TAX_MODE"); mode != "" {
+		parsed, ok := types.ParseTaxMode(mode)
+		if !ok {
+			log.Fatalf("invalid TAX_MODE: %s", mode)
+		}
+		taxPolicy.Mode = parsed
+	}
+	if rounding := os.Getenv("TAX_ROUNDING"); rounding != "" {
+		parsed, ok := types.ParseTaxRounding(rounding)
+		if !ok {
+			log.Fatalf("invalid TAX_ROUNDING: %s", rounding)
+		}
+		taxPolicy.Rounding = parsed
+	}
+
+	slotSchedule := services.SlotSchedule{Overlap: types.REJECT_OVERLAP}
+	if d := os.Getenv("SLOT_OPEN_BEFORE"); d != "" {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			log.Fatalf("invalid SLOT_OPEN_BEFORE: %s", d)
+		}
+		slotSchedule.OpenBefore = parsed
+	}
+	if d := os.Getenv("SLOT_CLOSE_AFTER"); d != "" {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			log.Fatalf("invalid SLOT_CLOSE_AFTER: %s", d)
+		}
+		slotSchedule.CloseAfter = parsed
+	}
+	if policy := os.Getenv("SLOT_OVERLAP_POLICY"); policy != "" {
+		parsed, ok := types.ParseSlotOverlapPolicy(policy)
+		if !ok {
+			log.Fatalf("invalid SLOT_OVERLAP_POLICY: %s", policy)
+		}
+		slotSchedule.Overlap = parsed
+	}
+
+	waitPolicy := services.DefaultWaitPolicy()
+	if lanes := os.Getenv("KITCHEN_LANES"); lanes != "" {
+		parsed, err := strconv.Atoi(lanes)
+		if err != nil || parsed < 1 {
+			log.Fatalf("invalid KITCHEN_LANES: %s", lanes)
+		}
+		waitPolicy.Lanes = parsed
+	}
+	if d := os.Getenv("DEFAULT_PREP_TIME"); d != "" {
+		parsed, err := time.ParseDuration(d)
+		if err != nil {
+			log.Fatalf("invalid DEFAULT_PREP_TIME: %s", d)
+		}
+		waitPolicy.DefaultPrepTime = parsed
+	}
+
+	eventBus := events.NewBus()
+	eventBus.Subscribe(func(ctx context.Context, event domainevents.Event) {
+		log.Printf("event %s %s", event.Type, event.EntityID)
+	})
+	if url := os.Getenv("STOCK_ALERT_WEBHOOK_URL"); url != "" {
+		eventBus.Subscribe(events.NewStockAlertWebhook(url))
+	}
 
 	serviceFactory := services.NewServiceFactory(
 		productRepo,
+		categoryRepo,
+		productOptionGroupRepo,
 		salesSlotRepo,
 		productInventoryRepo,
 		orderRepo,
 		orderTicketRepo,
+		promotionRepo,
+		productPriceRepo,
+		ingredientRepo,
+		printing.NewRenderer(receiptTitle),
+		printer,
+		services.NewTicketSigner(signingSecret),
+		storage.NewLocalImageStorage(imageDir),
+		taxPolicy,
+		slotSchedule,
+		waitPolicy,
+		eventBus,
+		eventBus,
 	)
 
+	if len(os.Args) > 1 && os.Args[1] == "generate-slots" {
+		if err := generateSlots(context.Background(), serviceFactory.SalesSlotService(), os.Args[2:], os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if os.Getenv("SLOT_ROLLOVER") == "true" {
+		slotService := serviceFactory.SalesSlotService()
+		eventBus.Subscribe(func(ctx context.Context, event domainevents.Event) {
+			if event.Type != domainevents.SlotClosed {
+				return
+			}
+			// Bus handlers run in the publisher's goroutine.
+			go func() {
+				transfers, err := slotService.RollOverStock(context.Background(), event.EntityID)
+				if err != nil {
+					log.Printf("roll over stock of sales slot %s: %v", event.EntityID, err)
+					return
+				}
+				for _, t := range transfers {
+					log.Printf("rolled over %d of product %s from sales slot %s to %s", t.Quantity, t.From.ProductID, t.From.SalesSlotID, t.To.SalesSlotID)
+				}
+			}()
+		})
+	}
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go scheduler.Run(jobCtx, "apply scheduled prices", time.Minute, serviceFactory.ProductService().ApplyScheduledPrices)
+	go scheduler.Run(jobCtx, "open and close sales slots", 15*time.Second, serviceFactory.SalesSlotService().ApplySchedule)
+
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/url"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CategoryHandler struct {
+	categoryService services.CategoryService
+}
+
+func NewCategoryHandler(categoryService services.CategoryService) *CategoryHandler {
+	return &CategoryHandler{categoryService: categoryService}
+}
+
+// @Summary Create a new category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param category body CreateCategoryRequest true "Category information"
+// @Success 201 {object} CategoryResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Router /categories [post]
+func (h *CategoryHandler) Create(c *fiber.Ctx) error {
+	var req CreateCategoryRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	category, err := h.categoryService.CreateCategory(c.Context(), req.Name, req.DisplayOrder, req.Station)
+	if err != nil {
+		if err == services.ErrInvalidCategory {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewCategoryResponse(category))
+}
+
+// @Summary Get all categories in display order
+// @Tags categories
+// @Produce json
+// @Success 200 {array} CategoryResponse
+// @Router /categories [get]
+func (h *CategoryHandler) GetAll(c *fiber.Ctx) error {
+	categories, err := h.categoryService.GetAllCategories(c.Context())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewCategoryResponseList(categories))
+}
+
+// @Summary Get a category by ID
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} CategoryResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /categories/{id} [get]
+func (h *CategoryHandler) GetByID(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	category, err := h.categoryService.GetCategory(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Category not found")
+	}
+
+	return c.JSON(NewCategoryResponse(category))
+}
+
+// @Summary Update a category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param category body UpdateCategoryRequest true "Category information"
+// @Success 200 {object} CategoryResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) Update(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req UpdateCategoryRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	category, err := h.categoryService.UpdateCategory(c.Context(), types.ID(id), req.Name, req.DisplayOrder, req.Station)
+	if err != nil {
+		if err == services.ErrInvalidCategory {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Category not found")
+	}
+
+	return c.JSON(NewCategoryResponse(category))
+}
+
+// @Summary Delete a category
+// @Description Products in the category are kept and become uncategorized.
+// @Tags categories
+// @Param id path string true "Category ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} ErrorResponse
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) Delete(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	if err := h.categoryService.DeleteCategory(c.Context(), types.ID(id)); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Category not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockCategoryService struct {
+	categories map[types.ID]*models.Category
+}
+
+func newMockCategoryService() *mockCategoryService {
+	return &mockCategoryService{
+		categories: make(map[types.ID]*models.Category),
+	}
+}
+
+func (s *mockCategoryService) CreateCategory(ctx context.Context, name string, displayOrder int, station string) (*models.Category, error) {
+	category := &models.Category{
+		ID:           types.ID("test-id-" + name),
+		Name:         name,
+		DisplayOrder: displayOrder,
+		Station:      station,
+	}
+	s.categories[category.ID] = category
+	return category, nil
+}
+
+func (s *mockCategoryService) GetCategory(ctx context.Context, id types.ID) (*models.Category, error) {
+	if category, exists := s.categories[id]; exists {
+		return category, nil
+	}
+	return nil, &services.ServiceError{Message: "Category not found"}
+}
+
+func (s *mockCategoryService) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	for _, c := range s.categories {
+		categories = append(categories, *c)
+	}
+	return categories, nil
+}
+
+func (s *mockCategoryService) UpdateCategory(ctx context.Context, id types.ID, name string, displayOrder int, station string) (*models.Category, error) {
+	if category, exists := s.categories[id]; exists {
+		category.Name = name
+		category.DisplayOrder = displayOrder
+		category.Station = station
+		return category, nil
+	}
+	return nil, &services.ServiceError{Message: "Category not found"}
+}
+
+func (s *mockCategoryService) DeleteCategory(ctx context.Context, id types.ID) error {
+	if _, exists := s.categories[id]; !exists {
+		return &services.ServiceError{Message: "Category not found"}
+	}
+	delete(s.categories, id)
+	return nil
+}
+
+func TestCategoryHandler_Create(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockCategoryService()
+	handler := NewCategoryHandler(mockService)
+
+	app.Post("/categories", handler.Create)
+
+	reqBody := CreateCategoryRequest{
+		Name:         "Food",
+		DisplayOrder: 1,
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/categories", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusCreated, resp.StatusCode)
+	}
+
+	var response CategoryResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+
+	if response.Name != "Food" {
+		t.Errorf("Expected category name Food, got %s", response.Name)
+	}
+}
+
+func TestCategoryHandler_GetByID_NotFound(t *testing.T) {
+	app := fiber.New()
+	handler := NewCategoryHandler(newMockCategoryService())
+
+	app.Get("/categories/:id", handler.GetByID)
+
+	req := httptest.NewRequest("GET", "/categories/unknown", nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+	}
+}
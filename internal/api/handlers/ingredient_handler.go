@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"net/url"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type IngredientHandler struct {
+	ingredientService services.IngredientService
+}
+
+func NewIngredientHandler(ingredientService services.IngredientService) *IngredientHandler {
+	return &IngredientHandler{ingredientService: ingredientService}
+}
+
+// @Summary Create a new ingredient
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param ingredient body IngredientRequest true "Ingredient information"
+// @Success 201 {object} IngredientResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Router /ingredients [post]
+func (h *IngredientHandler) Create(c *fiber.Ctx) error {
+	var req IngredientRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	ingredient, err := h.ingredientService.CreateIngredient(c.Context(), req.Name, req.Unit)
+	if err != nil {
+		if err == services.ErrInvalidIngredient {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewIngredientResponse(ingredient))
+}
+
+// @Summary Get all ingredients
+// @Tags ingredients
+// @Produce json
+// @Success 200 {array} IngredientResponse
+// @Router /ingredients [get]
+func (h *IngredientHandler) GetAll(c *fiber.Ctx) error {
+	ingredients, err := h.ingredientService.GetAllIngredients(c.Context())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewIngredientResponseList(ingredients))
+}
+
+// @Summary Get an ingredient by ID
+// @Tags ingredients
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Success 200 {object} IngredientResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /ingredients/{id} [get]
+func (h *IngredientHandler) GetByID(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	ingredient, err := h.ingredientService.GetIngredient(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Ingredient not found")
+	}
+
+	return c.JSON(NewIngredientResponse(ingredient))
+}
+
+// @Summary Update an ingredient
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Param ingredient body IngredientRequest true "Ingredient information"
+// @Success 200 {object} IngredientResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /ingredients/{id} [put]
+func (h *IngredientHandler) Update(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req IngredientRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	ingredient, err := h.ingredientService.UpdateIngredient(c.Context(), types.ID(id), req.Name, req.Unit)
+	if err != nil {
+		if err == services.ErrInvalidIngredient {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Ingredient not found")
+	}
+
+	return c.JSON(NewIngredientResponse(ingredient))
+}
+
+// @Summary Delete an ingredient
+// @Description The ingredient is removed from recipes and its stock is deleted.
+// @Tags ingredients
+// @Param id path string true "Ingredient ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} ErrorResponse
+// @Router /ingredients/{id} [delete]
+func (h *IngredientHandler) Delete(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	if err := h.ingredientService.DeleteIngredient(c.Context(), types.ID(id)); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Ingredient not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Get the ingredient stock of a booth
+// @Tags ingredients
+// @Produce json
+// @Param booth query string false "Booth"
+// @Success 200 {array} IngredientStockResponse
+// @Router /ingredients/stock [get]
+func (h *IngredientHandler) GetStock(c *fiber.Ctx) error {
+	stock, err := h.ingredientService.GetStock(c.Context(), c.Query("booth"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewIngredientStockResponseList(stock))
+}
+
+// @Summary Set the amount of an ingredient a booth has on hand
+// @Description Confirmed orders take the ingredients of their products' recipes from the stock of their slot's booth.
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID"
+// @Param stock body SetIngredientStockRequest true "Ingredient stock"
+// @Success 200 {array} IngredientStockResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /ingredients/{id}/stock [put]
+func (h *IngredientHandler) SetStock(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req SetIngredientStockRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	stock, err := h.ingredientService.SetStock(c.Context(), req.Booth, types.ID(id), req.Quantity)
+	if err != nil {
+		if err == services.ErrInvalidIngredientStock {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Ingredient not found")
+	}
+
+	return c.JSON(NewIngredientStockResponseList(stock))
+}
+
+// @Summary Get the recipe of a product
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {array} RecipeItemResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /products/{id}/recipe [get]
+func (h *IngredientHandler) GetRecipe(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	items, err := h.ingredientService.GetRecipe(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Product not found")
+	}
+
+	return c.JSON(NewRecipeResponse(items))
+}
+
+// @Summary Replace the recipe of a product
+// @Description Set menus have no recipe of their own; the recipes of their components are used.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param recipe body SetRecipeRequest true "Recipe"
+// @Success 200 {array} RecipeItemResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /products/{id}/recipe [put]
+func (h *IngredientHandler) SetRecipe(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req SetRecipeRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	inputs := make([]services.RecipeItemInput, len(req.Items))
+	for i, item := range req.Items {
+		inputs[i] = services.RecipeItemInput{IngredientID: types.ID(item.IngredientID), Quantity: item.Quantity}
+	}
+	items, err := h.ingredientService.SetRecipe(c.Context(), types.ID(id), inputs)
+	if err != nil {
+		if err == services.ErrInvalidRecipe {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Product or ingredient not found")
+	}
+
+	return c.JSON(NewRecipeResponse(items))
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockIngredientService struct {
+	ingredients map[types.ID]*models.Ingredient
+	recipes     map[types.ID][]models.RecipeItem
+	stock       []models.IngredientStock
+}
+
+func newMockIngredientService() *mockIngredientService {
+	return &mockIngredientService{
+		ingredients: make(map[types.ID]*models.Ingredient),
+		recipes:     make(map[types.ID][]models.RecipeItem),
+	}
+}
+
+func (s *mockIngredientService) CreateIngredient(ctx context.Context, name, unit string) (*models.Ingredient, error) {
+	ingredient := &models.Ingredient{ID: types.ID("ingredient-" + name), Name: name, Unit: unit}
+	s.ingredients[ingredient.ID] = ingredient
+	return ingredient, nil
+}
+
+func (s *mockIngredientService) GetIngredient(ctx context.Context, id types.ID) (*models.Ingredient, error) {
+	if ingredient, exists := s.ingredients[id]; exists {
+		return ingredient, nil
+	}
+	return nil, &services.ServiceError{Message: "Ingredient not found"}
+}
+
+func (s *mockIngredientService) GetAllIngredients(ctx context.Context) ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+	for _, ingredient := range s.ingredients {
+		ingredients = append(ingredients, *ingredient)
+	}
+	return ingredients, nil
+}
+
+func (s *mockIngredientService) UpdateIngredient(ctx context.Context, id types.ID, name, unit string) (*models.Ingredient, error) {
+	ingredient, err := s.GetIngredient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ingredient.Name, ingredient.Unit = name, unit
+	return ingredient, nil
+}
+
+func (s *mockIngredientService) DeleteIngredient(ctx context.Context, id types.ID) error {
+	if _, exists := s.ingredients[id]; !exists {
+		return &services.ServiceError{Message: "Ingredient not found"}
+	}
+	delete(s.ingredients, id)
+	return nil
+}
+
+func (s *mockIngredientService) GetRecipe(ctx context.Context, productID types.ID) ([]models.RecipeItem, error) {
+	return s.recipes[productID], nil
+}
+
+func (s *mockIngredientService) SetRecipe(ctx context.Context, productID types.ID, inputs []services.RecipeItemInput) ([]models.RecipeItem, error) {
+	items := make([]models.RecipeItem, len(inputs))
+	for i, input := range inputs {
+		if input.Quantity <= 0 {
+			return nil, services.ErrInvalidRecipe
+		}
+		ingredient, exists := s.ingredients[input.IngredientID]
+		if !exists {
+			return nil, &services.ServiceError{Message: "Ingredient not found"}
+		}
+		items[i] = models.RecipeItem{ProductID: productID, IngredientID: input.IngredientID, Quantity: input.Quantity, Ingredient: ingredient}
+	}
+	s.recipes[productID] = items
+	return items, nil
+}
+
+func (s *mockIngredientService) GetStock(ctx context.Context, booth string) ([]models.IngredientStock, error) {
+	var stock []models.IngredientStock
+	for _, st := range s.stock {
+		if st.Booth == booth {
+			stock = append(stock, st)
+		}
+	}
+	return stock, nil
+}
+
+func (s *mockIngredientService) SetStock(ctx context.Context, booth string, ingredientID types.ID, quantity float64) ([]models.IngredientStock, error) {
+	if quantity < 0 {
+		return nil, services.ErrInvalidIngredientStock
+	}
+	ingredient, exists := s.ingredients[ingredientID]
+	if !exists {
+		return nil, &services.ServiceError{Message: "Ingredient not found"}
+	}
+	s.stock = append(s.stock, models.IngredientStock{Booth: booth, IngredientID: ingredientID, Quantity: quantity, Ingredient: ingredient})
+	return s.GetStock(ctx, booth)
+}
+
+func TestIngredientHandler_Create(t *testing.T) {
+	app := fiber.New()
+	handler := NewIngredientHandler(newMockIngredientService())
+	app.Post("/ingredients", handler.Create)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "valid", body: `{"name": "キャベツ", "unit": "g"}`, expectedStatus: fiber.StatusCreated},
+		{name: "missing unit", body: `{"name": "キャベツ"}`, expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/ingredients", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestIngredientHandler_SetRecipe(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockIngredientService()
+	handler := NewIngredientHandler(mockService)
+	app.Put("/products/:id/recipe", handler.SetRecipe)
+
+	ctx := context.Background()
+	mockService.CreateIngredient(ctx, "noodles", "玉")
+	mockService.ingredients["123e4567-e89b-12d3-a456-426614174000"] = mockService.ingredients["ingredient-noodles"]
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "valid", body: `{"items": [{"ingredientId": "123e4567-e89b-12d3-a456-426614174000", "quantity": 1.5}]}`, expectedStatus: fiber.StatusOK},
+		{name: "zero quantity", body: `{"items": [{"ingredientId": "123e4567-e89b-12d3-a456-426614174000", "quantity": 0}]}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "unknown ingredient", body: `{"items": [{"ingredientId": "123e4567-e89b-12d3-a456-426614174999", "quantity": 1}]}`, expectedStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/products/product-id/recipe", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+			var response []RecipeItemResponse
+			json.NewDecoder(resp.Body).Decode(&response)
+			if len(response) != 1 || response[0].Quantity != 1.5 || response[0].Unit != "玉" {
+				t.Errorf("Expected 1.5 玉 of noodles, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestIngredientHandler_SetStock(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockIngredientService()
+	handler := NewIngredientHandler(mockService)
+	app.Put("/ingredients/:id/stock", handler.SetStock)
+	app.Get("/ingredients/stock", handler.GetStock)
+
+	mockService.CreateIngredient(context.Background(), "cups", "個")
+
+	req := httptest.NewRequest("PUT", "/ingredients/ingredient-cups/stock", bytes.NewReader([]byte(`{"booth": "A", "quantity": -1}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ := app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	req = httptest.NewRequest("PUT", "/ingredients/ingredient-cups/stock", bytes.NewReader([]byte(`{"booth": "A", "quantity": 200}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/ingredients/stock?booth=A", nil))
+	var response []IngredientStockResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if len(response) != 1 || response[0].Quantity != 200 || response[0].Name != "cups" {
+		t.Errorf("Expected 200 cups at booth A, got %+v", response)
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+// streamHeartbeat is how often the stream writes a comment so that proxies
+// keep the connection open and closed connections are noticed.
+const streamHeartbeat = 15 * time.Second
+
+type KitchenHandler struct {
+	kitchenService services.KitchenService
+}
+
+func NewKitchenHandler(kitchenService services.KitchenService) *KitchenHandler {
+	return &KitchenHandler{kitchenService: kitchenService}
+}
+
+// kitchenError maps the errors of bumping items to a response.
+func kitchenError(err error, notFoundMessage string) error {
+	switch err {
+	case services.ErrOrderNotConfirmed, services.ErrAlreadyPrepared:
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	}
+	var notFound *repositories.ErrNotFound
+	if errors.As(err, &notFound) {
+		return fiber.NewError(fiber.StatusNotFound, notFoundMessage)
+	}
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+}
+
+// @Summary Get the kitchen queue
+// @Description Confirmed orders of the active sales slots with items still to prepare, earliest confirmed first. Items are routed to stations by their product's category.
+// @Tags kitchen
+// @Produce json
+// @Param booth query string false "Only the active slot of this booth"
+// @Param station query string false "Only items prepared at this station"
+// @Success 200 {array} KitchenOrderResponse
+// @Router /kitchen/queue [get]
+func (h *KitchenHandler) GetQueue(c *fiber.Ctx) error {
+	orders, err := h.kitchenService.GetQueue(c.Context(), c.Query("booth"), c.Query("station"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewKitchenQueueResponse(orders))
+}
+
+// @Summary Move an order item to its next preparation status
+// @Description QUEUED items become PREPARING and PREPARING items READY.
+// @Tags kitchen
+// @Produce json
+// @Param id path string true "Order item ID"
+// @Success 200 {object} KitchenItemResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /kitchen/items/{id}/bump [post]
+func (h *KitchenHandler) BumpItem(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	item, err := h.kitchenService.BumpItem(c.Context(), types.ID(id))
+	if err != nil {
+		return kitchenError(err, "Order item not found")
+	}
+
+	return c.JSON(NewKitchenItemResponse(item))
+}
+
+// @Summary Move an order to its next preparation status
+// @Description The order's least advanced items at the station move to their next status.
+// @Tags kitchen
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param station query string false "Only items prepared at this station"
+// @Success 200 {object} KitchenOrderResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /kitchen/orders/{id}/bump [post]
+func (h *KitchenHandler) BumpOrder(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	order, err := h.kitchenService.BumpOrder(c.Context(), types.ID(id), c.Query("station"))
+	if err != nil {
+		return kitchenError(err, "Order not found")
+	}
+
+	return c.JSON(NewKitchenOrderResponse(order))
+}
+
+// @Summary Stream kitchen queue updates
+// @Description Server-sent events named order.confirmed or order.prep_status_changed whose data is {"orderId": "..."}. Clients fetch the queue again when they receive one. Events are only passed between requests handled by the same server process, so the API must run as a single instance for every kitchen display to receive them.
+// @Tags kitchen
+// @Produce text/event-stream
+// @Success 200 {string} string
+// @Router /kitchen/stream [get]
+func (h *KitchenHandler) Stream(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+
+	// The request context is not usable once the handler returns, so the
+	// watch ends when writing to the client fails instead.
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := h.kitchenService.Watch(ctx)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		ticker := time.NewTicker(streamHeartbeat)
+		defer ticker.Stop()
+
+		fmt.Fprint(w, ": connected\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+		for {
+			select {
+			case event, ok := <-updates:
+				if !ok {
+					return
+				}
+				data, _ := json.Marshal(fiber.Map{"orderId": event.EntityID})
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			case <-ticker.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockKitchenService struct {
+	orders []models.Order
+}
+
+func (s *mockKitchenService) GetQueue(ctx context.Context, booth, station string) ([]models.Order, error) {
+	return s.orders, nil
+}
+
+func (s *mockKitchenService) findItem(id types.ID) (*models.Order, *models.OrderItem) {
+	for i := range s.orders {
+		for j := range s.orders[i].Items {
+			if s.orders[i].Items[j].ID == id {
+				return &s.orders[i], &s.orders[i].Items[j]
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (s *mockKitchenService) BumpItem(ctx context.Context, itemID types.ID) (*models.OrderItem, error) {
+	_, item := s.findItem(itemID)
+	if item == nil {
+		return nil, repositories.NewErrNotFound("OrderItem", itemID)
+	}
+	if item.PrepStatus == types.READY {
+		return nil, services.ErrAlreadyPrepared
+	}
+	item.PrepStatus = item.PrepStatus.Next()
+	return item, nil
+}
+
+func (s *mockKitchenService) BumpOrder(ctx context.Context, orderID types.ID, station string) (*models.Order, error) {
+	for i := range s.orders {
+		if s.orders[i].ID == orderID {
+			for j := range s.orders[i].Items {
+				s.orders[i].Items[j].PrepStatus = s.orders[i].Items[j].PrepStatus.Next()
+			}
+			return &s.orders[i], nil
+		}
+	}
+	return nil, repositories.NewErrNotFound("Order", orderID)
+}
+
+func (s *mockKitchenService) Watch(ctx context.Context) <-chan events.Event {
+	ch := make(chan events.Event)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch
+}
+
+func TestKitchenHandler_Queue(t *testing.T) {
+	app := fiber.New()
+	confirmedAt := time.Now()
+	mockService := &mockKitchenService{orders: []models.Order{{
+		ID:          "order1",
+		SalesSlotID: "slot1",
+		ConfirmedAt: &confirmedAt,
+		Ticket:      &models.OrderTicket{TicketNumber: "A-12"},
+		Items: []models.OrderItem{{
+			ID:         "item1",
+			ProductID:  "prod1",
+			Quantity:   2,
+			PrepStatus: types.QUEUED,
+			Product:    &models.Product{ID: "prod1", Name: "焼きそば", Category: &models.Category{Station: "grill"}},
+			Options:    []models.OrderItemOption{{GroupName: "麺の量", Name: "大盛り"}},
+		}},
+	}}}
+	handler := NewKitchenHandler(mockService)
+	app.Get("/kitchen/queue", handler.GetQueue)
+	app.Post("/kitchen/items/:id/bump", handler.BumpItem)
+	app.Post("/kitchen/orders/:id/bump", handler.BumpOrder)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/kitchen/queue?station=grill", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	var queue []KitchenOrderResponse
+	json.NewDecoder(resp.Body).Decode(&queue)
+	if len(queue) != 1 || queue[0].TicketNumber != "A-12" || len(queue[0].Items) != 1 {
+		t.Fatalf("Expected the order with its ticket number, got %+v", queue)
+	}
+	item := queue[0].Items[0]
+	if item.ProductName != "焼きそば" || item.Station != "grill" || item.PrepStatus != "QUEUED" || len(item.Options) != 1 {
+		t.Errorf("Expected the item details, got %+v", item)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		prepStatus     string
+	}{
+		{name: "start item", path: "/kitchen/items/item1/bump", expectedStatus: fiber.StatusOK, prepStatus: "PREPARING"},
+		{name: "finish order", path: "/kitchen/orders/order1/bump", expectedStatus: fiber.StatusOK, prepStatus: "READY"},
+		{name: "item already ready", path: "/kitchen/items/item1/bump", expectedStatus: fiber.StatusConflict},
+		{name: "unknown item", path: "/kitchen/items/missing/bump", expectedStatus: fiber.StatusNotFound},
+		{name: "unknown order", path: "/kitchen/orders/missing/bump", expectedStatus: fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("POST", tt.path, nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if mockService.orders[0].Items[0].PrepStatus.String() != tt.prepStatus && tt.prepStatus != "" {
+				t.Errorf("Expected the item to be %s, got %s", tt.prepStatus, mockService.orders[0].Items[0].PrepStatus)
+			}
+		})
+	}
+}
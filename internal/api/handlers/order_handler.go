@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/url"
 
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -17,17 +19,18 @@ func NewOrderHandler(orderService services.OrderService) *OrderHandler {
 }
 
 // @Summary Create a new order
+// @Description The response includes how long the order is expected to take to be ready once confirmed, estimated from the kitchen queue.
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Param order body CreateOrderRequest true "Order information"
 // @Success 201 {object} OrderResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Router /orders [post]
 func (h *OrderHandler) Create(c *fiber.Ctx) error {
 	var req CreateOrderRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &req); !ok {
+		return err
 	}
 
 	var items []services.OrderItemInput
@@ -35,11 +38,18 @@ func (h *OrderHandler) Create(c *fiber.Ctx) error {
 		items = append(items, services.OrderItemInput{
 			ProductID: types.ID(item.ProductID),
 			Quantity:  item.Quantity,
+			OptionIDs: toIDs(item.OptionIDs),
 		})
 	}
 
-	order, err := h.orderService.CreateOrder(c.Context(), types.ID(req.SalesSlotID), items)
+	order, err := h.orderService.CreateOrder(c.Context(), types.ID(req.SalesSlotID), req.CustomerID, items, req.CouponCodes)
 	if err != nil {
+		if err == services.ErrInvalidOptionSelection || err == services.ErrInvalidCoupon || err == services.ErrCouponUsedUp ||
+			err == services.ErrNoOrderItems || err == services.ErrInvalidQuantity || isPurchaseLimitError(err) ||
+			err == services.ErrSlotFull || err == services.ErrSlotItemsFull || err == services.ErrProductUnavailable ||
+			err == services.ErrInsufficientInventory {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -136,7 +146,7 @@ func (h *OrderHandler) Cancel(c *fiber.Ctx) error {
 // @Param id path string true "Order ID"
 // @Param items body []OrderItemCreateInput true "Order items"
 // @Success 200 {object} OrderResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /orders/{id}/items [post]
 func (h *OrderHandler) AddItems(c *fiber.Ctx) error {
@@ -145,8 +155,8 @@ func (h *OrderHandler) AddItems(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
 	}
 	var items []OrderItemCreateInput
-	if err := c.BodyParser(&items); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &items); !ok {
+		return err
 	}
 
 	var orderItems []services.OrderItemInput
@@ -154,13 +164,182 @@ func (h *OrderHandler) AddItems(c *fiber.Ctx) error {
 		orderItems = append(orderItems, services.OrderItemInput{
 			ProductID: types.ID(item.ProductID),
 			Quantity:  item.Quantity,
+			OptionIDs: toIDs(item.OptionIDs),
 		})
 	}
 
 	if err := h.orderService.AddOrderItems(c.Context(), types.ID(id), orderItems); err != nil {
+		return orderItemError(err)
+	}
+
+	order, err := h.orderService.GetOrder(c.Context(), types.ID(id))
+	if err != nil {
+		return orderItemError(err)
+	}
+	return c.JSON(NewOrderResponse(order))
+}
+
+// @Summary Get consumption tax collected per rate
+// @Description Counts only confirmed orders created in the optional time range.
+// @Tags orders
+// @Produce json
+// @Param from query string false "Start time (RFC3339)"
+// @Param to query string false "End time (RFC3339, exclusive)"
+// @Success 200 {array} TaxSummaryResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /orders/tax-report [get]
+func (h *OrderHandler) TaxReport(c *fiber.Ctx) error {
+	fromParam, toParam := c.Query("from"), c.Query("to")
+	from, err := parseOptionalTime(&fromParam)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid from time format")
+	}
+	to, err := parseOptionalTime(&toParam)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid to time format")
+	}
+
+	summaries, err := h.orderService.GetTaxReport(c.Context(), from, to)
+	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	order, _ := h.orderService.GetOrder(c.Context(), types.ID(id))
+	result := make([]TaxSummaryResponse, len(summaries))
+	for i, s := range summaries {
+		result[i] = TaxSummaryResponse{
+			Rate:        s.Rate.String(),
+			Percent:     s.Rate.Percent(),
+			Orders:      s.Orders,
+			SalesAmount: s.SalesAmount,
+			TaxAmount:   s.TaxAmount,
+			NetAmount:   s.SalesAmount - s.TaxAmount,
+		}
+	}
+	return c.JSON(result)
+}
+
+// @Summary Change the quantity of an item on a reserved order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param itemId path string true "Order Item ID"
+// @Param item body UpdateOrderItemRequest true "New quantity"
+// @Success 200 {object} OrderResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /orders/{id}/items/{itemId} [put]
+func (h *OrderHandler) UpdateItem(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	itemID, err := url.PathUnescape(c.Params("itemId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req UpdateOrderItemRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	if err := h.orderService.UpdateOrderItem(c.Context(), types.ID(id), types.ID(itemID), req.Quantity); err != nil {
+		return orderItemError(err)
+	}
+
+	order, err := h.orderService.GetOrder(c.Context(), types.ID(id))
+	if err != nil {
+		return orderItemError(err)
+	}
+	return c.JSON(NewOrderResponse(order))
+}
+
+// @Summary Remove an item from a reserved order
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param itemId path string true "Order Item ID"
+// @Success 200 {object} OrderResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /orders/{id}/items/{itemId} [delete]
+func (h *OrderHandler) RemoveItem(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	itemID, err := url.PathUnescape(c.Params("itemId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	if err := h.orderService.RemoveOrderItem(c.Context(), types.ID(id), types.ID(itemID)); err != nil {
+		return orderItemError(err)
+	}
+
+	order, err := h.orderService.GetOrder(c.Context(), types.ID(id))
+	if err != nil {
+		return orderItemError(err)
+	}
 	return c.JSON(NewOrderResponse(order))
 }
+
+// @Summary Get the item change history of an order
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {array} OrderItemChangeResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /orders/{id}/item-changes [get]
+func (h *OrderHandler) GetItemChanges(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	changes, err := h.orderService.GetOrderItemChanges(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Order not found")
+	}
+
+	result := make([]OrderItemChangeResponse, len(changes))
+	for i, ch := range changes {
+		result[i] = OrderItemChangeResponse{
+			OrderItemID: string(ch.OrderItemID),
+			ProductID:   string(ch.ProductID),
+			UnitPrice:   ch.UnitPrice,
+			OldQuantity: ch.OldQuantity,
+			NewQuantity: ch.NewQuantity,
+			CreatedAt:   ch.CreatedAt,
+		}
+	}
+	return c.JSON(result)
+}
+
+// orderItemError maps the errors of adding, changing and removing the items
+// of an order to a response.
+func orderItemError(err error) error {
+	if isPurchaseLimitError(err) {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	var notFound *repositories.ErrNotFound
+	if errors.As(err, &notFound) {
+		return fiber.NewError(fiber.StatusNotFound, notFound.Entity+" not found")
+	}
+	switch err {
+	case services.ErrInvalidQuantity, services.ErrInvalidOrderStatus, services.ErrEmptyOrder, services.ErrInsufficientInventory,
+		services.ErrSlotItemsFull, services.ErrProductUnavailable, services.ErrNoOrderItems, services.ErrInvalidOptionSelection:
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case services.ErrOrderItemNotFound:
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	default:
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+}
+
+// isPurchaseLimitError reports whether err rejects an order for going over a
+// product's purchase limits.
+func isPurchaseLimitError(err error) bool {
+	var limitErr *services.PurchaseLimitError
+	return errors.As(err, &limitErr) || err == services.ErrCustomerRequired
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -15,6 +18,8 @@ import (
 
 type mockOrderService struct {
 	orders map[types.ID]*models.Order
+	// err, when set, is returned by CreateOrder and AddOrderItems.
+	err error
 }
 
 func newMockOrderService() *mockOrderService {
@@ -23,7 +28,10 @@ func newMockOrderService() *mockOrderService {
 	}
 }
 
-func (s *mockOrderService) CreateOrder(ctx context.Context, salesSlotID types.ID, items []services.OrderItemInput) (*models.Order, error) {
+func (s *mockOrderService) CreateOrder(ctx context.Context, salesSlotID types.ID, customerID string, items []services.OrderItemInput, couponCodes []string) (*models.Order, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
 	order := &models.Order{
 		ID:          types.ID("test-id"),
 		SalesSlotID: salesSlotID,
@@ -50,7 +58,7 @@ func (s *mockOrderService) GetOrder(ctx context.Context, id types.ID) (*models.O
 	if order, exists := s.orders[id]; exists {
 		return order, nil
 	}
-	return nil, &services.ServiceError{Message: "Order not found"}
+	return nil, repositories.NewErrNotFound("Order", id)
 }
 
 func (s *mockOrderService) GetAllOrders(ctx context.Context) ([]models.Order, error) {
@@ -84,9 +92,12 @@ func (s *mockOrderService) CancelOrder(ctx context.Context, id types.ID) error {
 }
 
 func (s *mockOrderService) AddOrderItems(ctx context.Context, orderID types.ID, items []services.OrderItemInput) error {
+	if s.err != nil {
+		return s.err
+	}
 	order, exists := s.orders[orderID]
 	if !exists {
-		return &services.ServiceError{Message: "Order not found"}
+		return repositories.NewErrNotFound("Order", orderID)
 	}
 
 	for _, item := range items {
@@ -101,6 +112,53 @@ func (s *mockOrderService) AddOrderItems(ctx context.Context, orderID types.ID,
 	return nil
 }
 
+func (s *mockOrderService) GetTaxReport(ctx context.Context, from, to *time.Time) ([]repositories.TaxSummary, error) {
+	return []repositories.TaxSummary{
+		{Rate: types.REDUCED_RATE, Orders: 2, SalesAmount: 1080, TaxAmount: 80},
+		{Rate: types.STANDARD_RATE, Orders: 1, SalesAmount: 550, TaxAmount: 50},
+	}, nil
+}
+
+func (s *mockOrderService) UpdateOrderItem(ctx context.Context, orderID, itemID types.ID, quantity int) error {
+	order, exists := s.orders[orderID]
+	if !exists {
+		return repositories.NewErrNotFound("Order", orderID)
+	}
+	if quantity <= 0 {
+		return services.ErrInvalidQuantity
+	}
+	for i, item := range order.Items {
+		if item.ID == itemID {
+			order.TotalAmount += (quantity - item.Quantity) * item.Price
+			order.Items[i].Quantity = quantity
+			return nil
+		}
+	}
+	return services.ErrOrderItemNotFound
+}
+
+func (s *mockOrderService) RemoveOrderItem(ctx context.Context, orderID, itemID types.ID) error {
+	order, exists := s.orders[orderID]
+	if !exists {
+		return repositories.NewErrNotFound("Order", orderID)
+	}
+	for i, item := range order.Items {
+		if item.ID == itemID {
+			order.TotalAmount -= item.GetSubtotal()
+			order.Items = append(order.Items[:i], order.Items[i+1:]...)
+			return nil
+		}
+	}
+	return services.ErrOrderItemNotFound
+}
+
+func (s *mockOrderService) GetOrderItemChanges(ctx context.Context, orderID types.ID) ([]models.OrderItemChange, error) {
+	if _, exists := s.orders[orderID]; !exists {
+		return nil, &services.ServiceError{Message: "Order not found"}
+	}
+	return nil, nil
+}
+
 func TestOrderHandler_Create(t *testing.T) {
 	app := fiber.New()
 	mockService := newMockOrderService()
@@ -109,10 +167,10 @@ func TestOrderHandler_Create(t *testing.T) {
 	app.Post("/orders", handler.Create)
 
 	reqBody := CreateOrderRequest{
-		SalesSlotID: "test-slot-id",
+		SalesSlotID: "5b0c2d0e-8f6a-4c1e-9d3b-2a7e4f1c6b90",
 		Items: []OrderItemCreateInput{
 			{
-				ProductID: "test-product-id",
+				ProductID: "0f8a3c52-1e4b-4d7a-b6c9-8e2d5f7a1b34",
 				Quantity:  2,
 			},
 		},
@@ -137,6 +195,16 @@ func TestOrderHandler_Create(t *testing.T) {
 	if response.Status != types.RESERVED.String() {
 		t.Errorf("Expected order status %s, got %s", types.RESERVED, response.Status)
 	}
+
+	for _, serviceErr := range []error{services.ErrInsufficientInventory, services.ErrProductUnavailable} {
+		mockService.err = serviceErr
+		req = httptest.NewRequest("POST", "/orders", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ = app.Test(req)
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected status code %d for %v, got %d", fiber.StatusBadRequest, serviceErr, resp.StatusCode)
+		}
+	}
 }
 
 func TestOrderHandler_Cancel(t *testing.T) {
@@ -151,7 +219,7 @@ func TestOrderHandler_Cancel(t *testing.T) {
 			Quantity:  2,
 		},
 	}
-	order, _ := mockService.CreateOrder(ctx, types.ID("test-slot-id"), items)
+	order, _ := mockService.CreateOrder(ctx, types.ID("test-slot-id"), "", items, nil)
 
 	app.Put("/orders/:id/cancel", handler.Cancel)
 
@@ -186,13 +254,13 @@ func TestOrderHandler_AddItems(t *testing.T) {
 			Quantity:  2,
 		},
 	}
-	order, _ := mockService.CreateOrder(ctx, types.ID("test-slot-id"), items)
+	order, _ := mockService.CreateOrder(ctx, types.ID("test-slot-id"), "", items, nil)
 
 	app.Post("/orders/:id/items", handler.AddItems)
 
 	reqBody := []OrderItemCreateInput{
 		{
-			ProductID: "test-product-id-2",
+			ProductID: "a3d9e7b1-6c2f-4e8a-9b5d-1f4c7e2a8d60",
 			Quantity:  3,
 		},
 	}
@@ -216,4 +284,118 @@ func TestOrderHandler_AddItems(t *testing.T) {
 	if len(response.Items) != 2 {
 		t.Errorf("Expected 2 items, got %d", len(response.Items))
 	}
+
+	for _, serviceErr := range []error{services.ErrInsufficientInventory, services.ErrProductUnavailable, services.ErrInvalidOrderStatus} {
+		mockService.err = serviceErr
+		req = httptest.NewRequest("POST", "/orders/"+string(order.ID)+"/items", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ = app.Test(req)
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected status code %d for %v, got %d", fiber.StatusBadRequest, serviceErr, resp.StatusCode)
+		}
+	}
+
+	mockService.err = errors.New("connection refused")
+	req = httptest.NewRequest("POST", "/orders/"+string(order.ID)+"/items", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusInternalServerError, resp.StatusCode)
+	}
+}
+
+func TestOrderHandler_TaxReport(t *testing.T) {
+	app := fiber.New()
+	handler := NewOrderHandler(newMockOrderService())
+
+	app.Get("/orders/tax-report", handler.TaxReport)
+
+	req := httptest.NewRequest("GET", "/orders/tax-report?from=2026-10-01T00:00:00Z", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response []TaxSummaryResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if len(response) != 2 {
+		t.Fatalf("Expected 2 rates, got %d", len(response))
+	}
+	if response[0].Percent != 8 || response[0].NetAmount != 1000 {
+		t.Errorf("Expected 8%% with net amount 1000, got %d%% with %d", response[0].Percent, response[0].NetAmount)
+	}
+
+	req = httptest.NewRequest("GET", "/orders/tax-report?to=yesterday", nil)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestOrderHandler_UpdateAndRemoveItem(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockOrderService()
+	handler := NewOrderHandler(mockService)
+
+	mockService.orders["order1"] = &models.Order{
+		ID:     types.ID("order1"),
+		Status: types.RESERVED,
+		Items: []models.OrderItem{
+			{ID: types.ID("item1"), ProductID: types.ID("prod1"), Quantity: 1, Price: 500},
+			{ID: types.ID("item2"), ProductID: types.ID("prod2"), Quantity: 1, Price: 200},
+		},
+		TotalAmount: 700,
+	}
+
+	app.Put("/orders/:id/items/:itemId", handler.UpdateItem)
+	app.Delete("/orders/:id/items/:itemId", handler.RemoveItem)
+
+	req := httptest.NewRequest("PUT", "/orders/order1/items/item1", bytes.NewReader([]byte(`{"quantity": 3}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	var response OrderResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.TotalAmount != 1700 {
+		t.Errorf("Expected total amount 1700, got %d", response.TotalAmount)
+	}
+
+	req = httptest.NewRequest("PUT", "/orders/order1/items/item1", bytes.NewReader([]byte(`{"quantity": 0}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	req = httptest.NewRequest("DELETE", "/orders/order1/items/item2", nil)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+	response = OrderResponse{}
+	json.NewDecoder(resp.Body).Decode(&response)
+	if len(response.Items) != 1 || response.TotalAmount != 1500 {
+		t.Errorf("Expected 1 item totalling 1500, got %d items totalling %d", len(response.Items), response.TotalAmount)
+	}
+
+	req = httptest.NewRequest("DELETE", "/orders/order1/items/item2", nil)
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+	}
+
+	req = httptest.NewRequest("PUT", "/orders/missing/items/item1", bytes.NewReader([]byte(`{"quantity": 3}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+	}
 }
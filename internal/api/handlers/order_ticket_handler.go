@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/url"
 
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -22,12 +24,12 @@ func NewOrderTicketHandler(ticketService services.OrderTicketService) *OrderTick
 // @Produce json
 // @Param ticket body CreateOrderTicketRequest true "Ticket information"
 // @Success 201 {object} OrderTicketResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Router /order-tickets [post]
 func (h *OrderTicketHandler) Create(c *fiber.Ctx) error {
 	var req CreateOrderTicketRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &req); !ok {
+		return err
 	}
 
 	ticket, err := h.ticketService.CreateTicket(
@@ -40,7 +42,7 @@ func (h *OrderTicketHandler) Create(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(ticket)
+	return c.Status(fiber.StatusCreated).JSON(NewOrderTicketResponse(ticket))
 }
 
 // @Summary Get all order tickets
@@ -54,7 +56,7 @@ func (h *OrderTicketHandler) GetAll(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.JSON(tickets)
+	return c.JSON(NewOrderTicketResponseList(tickets))
 }
 
 // @Summary Get an order ticket by ID
@@ -74,7 +76,7 @@ func (h *OrderTicketHandler) GetByID(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
 	}
 
-	return c.JSON(ticket)
+	return c.JSON(NewOrderTicketResponse(ticket))
 }
 
 // @Summary Get an order ticket by ticket number
@@ -91,7 +93,7 @@ func (h *OrderTicketHandler) GetByNumber(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
 	}
 
-	return c.JSON(ticket)
+	return c.JSON(NewOrderTicketResponse(ticket))
 }
 
 // @Summary Update payment status
@@ -101,6 +103,7 @@ func (h *OrderTicketHandler) GetByNumber(c *fiber.Ctx) error {
 // @Param id path string true "Ticket ID"
 // @Param status body UpdatePaymentStatusRequest true "Payment Status"
 // @Success 200 {object} OrderTicketResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /order-tickets/{id}/payment [put]
 func (h *OrderTicketHandler) UpdatePayment(c *fiber.Ctx) error {
@@ -109,17 +112,27 @@ func (h *OrderTicketHandler) UpdatePayment(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
 	}
 	var req UpdatePaymentStatusRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &req); !ok {
+		return err
 	}
 
-	err = h.ticketService.UpdatePaymentStatus(c.Context(), types.ID(id), req.IsPaid, req.TransactionID)
+	err = h.ticketService.UpdatePaymentStatus(c.Context(), types.ID(id), req.IsPaid, req.TransactionID, req.TenderedAmount)
 	if err != nil {
+		if errors.Is(err, services.ErrInsufficientPayment) || errors.Is(err, services.ErrTenderedNotAllowed) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	ticket, _ := h.ticketService.GetTicket(c.Context(), types.ID(id))
-	return c.JSON(ticket)
+	ticket, err := h.ticketService.GetTicket(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return c.JSON(NewOrderTicketResponse(ticket))
 }
 
 // @Summary Update delivery status
@@ -141,5 +154,82 @@ func (h *OrderTicketHandler) UpdateDelivery(c *fiber.Ctx) error {
 	}
 
 	ticket, _ := h.ticketService.GetTicket(c.Context(), types.ID(id))
-	return c.JSON(ticket)
+	return c.JSON(NewOrderTicketResponse(ticket))
+}
+
+// @Summary Get the QR code of an order ticket
+// @Tags order-tickets
+// @Produce image/png
+// @Produce image/svg+xml
+// @Param id path string true "Ticket ID"
+// @Param format query string false "Image format" Enums(png, svg)
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /order-tickets/{id}/qr [get]
+func (h *OrderTicketHandler) QRCode(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	token, err := h.ticketService.GetTicketToken(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
+	}
+
+	switch c.Query("format", "png") {
+	case "png":
+		image, err := qrCodePNG(token)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		c.Set(fiber.HeaderContentType, "image/png")
+		return c.Send(image)
+	case "svg":
+		image, err := qrCodeSVG(token)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+		c.Set(fiber.HeaderContentType, "image/svg+xml")
+		return c.Send(image)
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format")
+	}
+}
+
+// @Summary Verify a scanned ticket QR code
+// @Tags order-tickets
+// @Accept json
+// @Produce json
+// @Param token body VerifyTicketRequest true "Scanned token"
+// @Success 200 {object} VerifyTicketResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /order-tickets/verify [post]
+func (h *OrderTicketHandler) Verify(c *fiber.Ctx) error {
+	var req VerifyTicketRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	ticket, err := h.ticketService.VerifyTicket(c.Context(), req.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTicketToken) {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, services.ErrAlreadyDelivered) {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(VerifyTicketResponse{
+		Ticket: NewOrderTicketResponse(ticket),
+		Order:  NewOrderResponse(ticket.Order),
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -60,13 +61,28 @@ func (s *mockOrderTicketService) GetAllTickets(ctx context.Context) ([]models.Or
 	return tickets, nil
 }
 
-func (s *mockOrderTicketService) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string) error {
-	if ticket, exists := s.tickets[id]; exists {
-		ticket.IsPaid = isPaid
-		ticket.TransactionID = transactionID
-		return nil
+// mockOrderTotal is the total of every ticket's order.
+const mockOrderTotal = 500
+
+func (s *mockOrderTicketService) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string, tenderedAmount *int) error {
+	ticket, exists := s.tickets[id]
+	if !exists {
+		return repositories.NewErrNotFound("OrderTicket", id)
 	}
-	return &services.ServiceError{Message: "Ticket not found"}
+	if tenderedAmount != nil {
+		if ticket.PaymentMethod != types.CASH {
+			return services.ErrTenderedNotAllowed
+		}
+		if *tenderedAmount < mockOrderTotal {
+			return services.ErrInsufficientPayment
+		}
+		change := *tenderedAmount - mockOrderTotal
+		ticket.ChangeAmount = &change
+	}
+	ticket.IsPaid = isPaid
+	ticket.TransactionID = transactionID
+	ticket.TenderedAmount = tenderedAmount
+	return nil
 }
 
 func (s *mockOrderTicketService) UpdateDeliveryStatus(ctx context.Context, id types.ID, isDelivered bool) error {
@@ -77,6 +93,26 @@ func (s *mockOrderTicketService) UpdateDeliveryStatus(ctx context.Context, id ty
 	return &services.ServiceError{Message: "Ticket not found"}
 }
 
+func (s *mockOrderTicketService) GetTicketToken(ctx context.Context, id types.ID) (string, error) {
+	if ticket, exists := s.tickets[id]; exists {
+		return "token-" + string(ticket.ID), nil
+	}
+	return "", &services.ServiceError{Message: "Ticket not found"}
+}
+
+func (s *mockOrderTicketService) VerifyTicket(ctx context.Context, token string) (*models.OrderTicket, error) {
+	for _, ticket := range s.tickets {
+		if token == "token-"+string(ticket.ID) {
+			if ticket.IsDelivered {
+				return nil, services.ErrAlreadyDelivered
+			}
+			ticket.Order = &models.Order{ID: ticket.OrderID, Status: types.CONFIRMED}
+			return ticket, nil
+		}
+	}
+	return nil, services.ErrInvalidTicketToken
+}
+
 func TestOrderTicketHandler_Create(t *testing.T) {
 	app := fiber.New()
 	mockService := newMockOrderTicketService()
@@ -85,7 +121,7 @@ func TestOrderTicketHandler_Create(t *testing.T) {
 	app.Post("/order-tickets", handler.Create)
 
 	reqBody := CreateOrderTicketRequest{
-		OrderID:       "test-order-id",
+		OrderID:       "c7e1a4f2-3b9d-4a6e-8f2c-5d0b9e3a7c18",
 		TicketNumber:  "TICKET123",
 		PaymentMethod: types.CASH,
 	}
@@ -111,6 +147,30 @@ func TestOrderTicketHandler_Create(t *testing.T) {
 	}
 }
 
+func TestOrderTicketHandler_GetAll(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockOrderTicketService()
+	handler := NewOrderTicketHandler(mockService)
+
+	mockService.CreateTicket(context.Background(), types.ID("test-order-id"), "TICKET123", types.CASH)
+
+	app.Get("/order-tickets", handler.GetAll)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/order-tickets", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response []OrderTicketResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if len(response) != 1 || response[0].OrderID != "test-order-id" || response[0].PaymentMethod != types.CASH.String() {
+		t.Errorf("Unexpected tickets %+v", response)
+	}
+}
+
 func TestOrderTicketHandler_UpdatePayment(t *testing.T) {
 	app := fiber.New()
 	mockService := newMockOrderTicketService()
@@ -150,6 +210,44 @@ func TestOrderTicketHandler_UpdatePayment(t *testing.T) {
 	if *response.TransactionID != transactionID {
 		t.Errorf("Expected transaction ID %s, got %s", transactionID, *response.TransactionID)
 	}
+
+	mockService.tickets["cash-id"] = &models.OrderTicket{ID: "cash-id", TicketNumber: "TICKET124", PaymentMethod: types.CASH}
+	mockService.tickets["paypay-id"] = &models.OrderTicket{ID: "paypay-id", TicketNumber: "TICKET125", PaymentMethod: types.PAYPAY}
+
+	short, enough := 300, 1000
+	tests := []struct {
+		name           string
+		id             string
+		tendered       *int
+		expectedStatus int
+	}{
+		{name: "short cash payment", id: "cash-id", tendered: &short, expectedStatus: fiber.StatusBadRequest},
+		{name: "tendered for PayPay", id: "paypay-id", tendered: &enough, expectedStatus: fiber.StatusBadRequest},
+		{name: "unknown ticket", id: "missing", tendered: &enough, expectedStatus: fiber.StatusNotFound},
+		{name: "cash payment with change", id: "cash-id", tendered: &enough, expectedStatus: fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(UpdatePaymentStatusRequest{IsPaid: true, TenderedAmount: tt.tendered})
+			req := httptest.NewRequest("PUT", "/order-tickets/"+tt.id+"/payment", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedStatus != fiber.StatusOK {
+				return
+			}
+			var response OrderTicketResponse
+			json.NewDecoder(resp.Body).Decode(&response)
+			if response.ChangeAmount == nil || *response.ChangeAmount != enough-mockOrderTotal || response.PaymentMethod != "CASH" {
+				t.Errorf("Expected the change in the ticket response, got %+v", response)
+			}
+		})
+	}
 }
 
 func TestOrderTicketHandler_UpdateDelivery(t *testing.T) {
@@ -159,7 +257,7 @@ func TestOrderTicketHandler_UpdateDelivery(t *testing.T) {
 
 	ctx := context.Background()
 	ticket, _ := mockService.CreateTicket(ctx, types.ID("test-order-id"), "TICKET123", types.CASH)
-	mockService.UpdatePaymentStatus(ctx, ticket.ID, true, nil)
+	mockService.UpdatePaymentStatus(ctx, ticket.ID, true, nil, nil)
 
 	app.Put("/order-tickets/:id/deliver", handler.UpdateDelivery)
 
@@ -181,3 +279,86 @@ func TestOrderTicketHandler_UpdateDelivery(t *testing.T) {
 		t.Error("Expected ticket to be marked as delivered")
 	}
 }
+
+func TestOrderTicketHandler_QRCode(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockOrderTicketService()
+	handler := NewOrderTicketHandler(mockService)
+
+	ctx := context.Background()
+	ticket, _ := mockService.CreateTicket(ctx, types.ID("test-order-id"), "TICKET123", types.CASH)
+
+	app.Get("/order-tickets/:id/qr", handler.QRCode)
+
+	req := httptest.NewRequest("GET", "/order-tickets/"+string(ticket.ID)+"/qr", nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	if resp.Header.Get("Content-Type") != "image/png" {
+		t.Errorf("Expected image/png, got %s", resp.Header.Get("Content-Type"))
+	}
+
+	req = httptest.NewRequest("GET", "/order-tickets/"+string(ticket.ID)+"/qr?format=svg", nil)
+	resp, _ = app.Test(req)
+
+	if resp.Header.Get("Content-Type") != "image/svg+xml" {
+		t.Errorf("Expected image/svg+xml, got %s", resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestOrderTicketHandler_Verify(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockOrderTicketService()
+	handler := NewOrderTicketHandler(mockService)
+
+	ctx := context.Background()
+	ticket, _ := mockService.CreateTicket(ctx, types.ID("test-order-id"), "TICKET123", types.CASH)
+
+	app.Post("/order-tickets/verify", handler.Verify)
+
+	body, _ := json.Marshal(VerifyTicketRequest{Token: "token-" + string(ticket.ID)})
+	req := httptest.NewRequest("POST", "/order-tickets/verify", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response VerifyTicketResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+
+	if response.Ticket.TicketNumber != "TICKET123" || response.Order.ID != "test-order-id" {
+		t.Errorf("Unexpected verify response %+v", response)
+	}
+
+	body, _ = json.Marshal(VerifyTicketRequest{Token: "forged"})
+	req = httptest.NewRequest("POST", "/order-tickets/verify", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	mockService.UpdateDeliveryStatus(ctx, ticket.ID, true)
+	body, _ = json.Marshal(VerifyTicketRequest{Token: "token-" + string(ticket.ID)})
+	req = httptest.NewRequest("POST", "/order-tickets/verify", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusConflict, resp.StatusCode)
+	}
+}
@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -22,16 +28,24 @@ func NewProductHandler(productService services.ProductService) *ProductHandler {
 // @Produce json
 // @Param product body CreateProductRequest true "Product information"
 // @Success 201 {object} ProductResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Router /products [post]
 func (h *ProductHandler) Create(c *fiber.Ctx) error {
 	var req CreateProductRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	input, err := newProductInput(req)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	product, err := h.productService.CreateProduct(c.Context(), req.Name, req.Price)
+	product, err := h.productService.CreateProduct(c.Context(), input)
 	if err != nil {
+		if err == services.ErrInvalidBundle || err == services.ErrInvalidProduct {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -41,10 +55,26 @@ func (h *ProductHandler) Create(c *fiber.Ctx) error {
 // @Summary Get all products
 // @Tags products
 // @Produce json
+// @Param categoryId query string false "Only products in this category"
+// @Param excludeAllergens query string false "Comma separated allergens to exclude (e.g. EGG,MILK)"
 // @Success 200 {array} ProductResponse
+// @Failure 400 {object} ErrorResponse
 // @Router /products [get]
 func (h *ProductHandler) GetAll(c *fiber.Ctx) error {
-	products, err := h.productService.GetAllProducts(c.Context())
+	var filter repositories.ProductFilter
+	if categoryID := c.Query("categoryId"); categoryID != "" {
+		id := types.ID(categoryID)
+		filter.CategoryID = &id
+	}
+	if excluded := c.Query("excludeAllergens"); excluded != "" {
+		allergens, err := parseAllergens(strings.Split(excluded, ","))
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		filter.ExcludeAllergens = allergens
+	}
+
+	products, err := h.productService.GetAllProducts(c.Context(), filter)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
@@ -79,7 +109,7 @@ func (h *ProductHandler) GetByID(c *fiber.Ctx) error {
 // @Param id path string true "Product ID"
 // @Param product body UpdateProductRequest true "Product information"
 // @Success 200 {object} ProductResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /products/{id} [put]
 func (h *ProductHandler) Update(c *fiber.Ctx) error {
@@ -88,12 +118,20 @@ func (h *ProductHandler) Update(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
 	}
 	var req UpdateProductRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	input, err := newProductInput(CreateProductRequest(req))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 
-	product, err := h.productService.UpdateProduct(c.Context(), types.ID(id), req.Name, req.Price)
+	product, err := h.productService.UpdateProduct(c.Context(), types.ID(id), input)
 	if err != nil {
+		if err == services.ErrInvalidBundle || err == services.ErrInvalidProduct {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusNotFound, "Product not found")
 	}
 
@@ -117,3 +155,215 @@ func (h *ProductHandler) Delete(c *fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// @Summary Upload a product image
+// @Tags products
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param image formData file true "Image file (JPEG, PNG, GIF or WebP)"
+// @Success 200 {object} ProductResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /products/{id}/image [put]
+func (h *ProductHandler) UploadImage(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	fileHeader, err := c.FormFile("image")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Image file is required")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid image file")
+	}
+	defer file.Close()
+
+	data := make([]byte, fileHeader.Size)
+	if _, err := io.ReadFull(file, data); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid image file")
+	}
+
+	product, err := h.productService.UploadProductImage(c.Context(), types.ID(id), data)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	return c.JSON(NewProductResponse(product))
+}
+
+// @Summary Get a product image
+// @Tags products
+// @Produce image/jpeg
+// @Produce image/png
+// @Param id path string true "Product ID"
+// @Success 200 {file} binary
+// @Failure 404 {object} ErrorResponse
+// @Router /products/{id}/image [get]
+func (h *ProductHandler) GetImage(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	image, err := h.productService.GetProductImage(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Image not found")
+	}
+	defer image.Close()
+
+	data, err := io.ReadAll(image)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, http.DetectContentType(data))
+	return c.Send(data)
+}
+
+// @Summary Get the price timeline of a product
+// @Description Includes past prices and scheduled future changes, oldest first.
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {array} ProductPriceResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /products/{id}/prices [get]
+func (h *ProductHandler) GetPrices(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	prices, err := h.productService.GetPriceHistory(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Product not found")
+	}
+
+	return c.JSON(NewProductPriceResponseList(prices, time.Now()))
+}
+
+// @Summary Schedule a price change
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param price body SchedulePriceChangeRequest true "New price and when it takes effect"
+// @Success 201 {object} ProductPriceResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /products/{id}/prices [post]
+func (h *ProductHandler) SchedulePrice(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req SchedulePriceChangeRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	effectiveFrom, err := time.Parse(time.RFC3339, req.EffectiveFrom)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid effective time format")
+	}
+
+	price, err := h.productService.SchedulePriceChange(c.Context(), types.ID(id), req.Price, effectiveFrom)
+	if err != nil {
+		if err == services.ErrInvalidPriceChange {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Product not found")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewProductPriceResponse(price, time.Now()))
+}
+
+// @Summary Cancel a scheduled price change
+// @Tags products
+// @Param id path string true "Product ID"
+// @Param priceId path string true "Price entry ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /products/{id}/prices/{priceId} [delete]
+func (h *ProductHandler) CancelPrice(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	priceID, err := url.PathUnescape(c.Params("priceId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	if err := h.productService.CancelPriceChange(c.Context(), types.ID(id), types.ID(priceID)); err != nil {
+		if err == services.ErrInvalidPriceChange {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Price change not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func newProductInput(req CreateProductRequest) (services.ProductInput, error) {
+	allergens, err := parseAllergens(req.Allergens)
+	if err != nil {
+		return services.ProductInput{}, err
+	}
+
+	var tags []types.DietaryTag
+	for _, name := range req.DietaryTags {
+		tag, ok := types.ParseDietaryTag(name)
+		if !ok {
+			return services.ProductInput{}, fmt.Errorf("unknown dietary tag: %s", name)
+		}
+		tags = append(tags, tag)
+	}
+
+	taxRate := types.STANDARD_RATE
+	if req.TaxRate != "" {
+		rate, ok := types.ParseTaxRate(req.TaxRate)
+		if !ok {
+			return services.ProductInput{}, fmt.Errorf("unknown tax rate: %s", req.TaxRate)
+		}
+		taxRate = rate
+	}
+
+	var categoryID *types.ID
+	if req.CategoryID != nil && *req.CategoryID != "" {
+		id := types.ID(*req.CategoryID)
+		categoryID = &id
+	}
+
+	var components []services.BundleComponentInput
+	for _, c := range req.Components {
+		components = append(components, services.BundleComponentInput{
+			ProductID: types.ID(c.ProductID),
+			Quantity:  c.Quantity,
+		})
+	}
+
+	return services.ProductInput{
+		Name:        req.Name,
+		Price:       req.Price,
+		CategoryID:  categoryID,
+		Description: req.Description,
+		Allergens:   allergens,
+		DietaryTags: types.NewDietaryTagSet(tags...),
+		TaxRate:     taxRate,
+		Components:  components,
+	}, nil
+}
+
+func parseAllergens(names []string) (types.AllergenSet, error) {
+	var allergens []types.Allergen
+	for _, name := range names {
+		allergen, ok := types.ParseAllergen(strings.TrimSpace(name))
+		if !ok {
+			return 0, fmt.Errorf("unknown allergen: %s", name)
+		}
+		allergens = append(allergens, allergen)
+	}
+	return types.NewAllergenSet(allergens...), nil
+}
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -16,19 +19,24 @@ import (
 
 type mockProductService struct {
 	products map[types.ID]*models.Product
+	prices   map[types.ID][]models.ProductPrice
 }
 
 func newMockProductService() *mockProductService {
 	return &mockProductService{
 		products: make(map[types.ID]*models.Product),
+		prices:   make(map[types.ID][]models.ProductPrice),
 	}
 }
 
-func (s *mockProductService) CreateProduct(ctx context.Context, name string, price int) (*models.Product, error) {
+func (s *mockProductService) CreateProduct(ctx context.Context, input services.ProductInput) (*models.Product, error) {
 	product := &models.Product{
-		ID:    types.ID("test-id-" + name), // 名前をIDに含めて一意性を確保
-		Name:  name,
-		Price: price,
+		ID:          types.ID("test-id-" + input.Name), // 名前をIDに含めて一意性を確保
+		Name:        input.Name,
+		Price:       input.Price,
+		CategoryID:  input.CategoryID,
+		Allergens:   input.Allergens,
+		DietaryTags: input.DietaryTags,
 	}
 	s.products[product.ID] = product
 	return product, nil
@@ -41,18 +49,21 @@ func (s *mockProductService) GetProduct(ctx context.Context, id types.ID) (*mode
 	return nil, &services.ServiceError{Message: "Product not found"}
 }
 
-func (s *mockProductService) GetAllProducts(ctx context.Context) ([]models.Product, error) {
+func (s *mockProductService) GetAllProducts(ctx context.Context, filter repositories.ProductFilter) ([]models.Product, error) {
 	var products []models.Product
 	for _, p := range s.products {
+		if p.Allergens&filter.ExcludeAllergens != 0 {
+			continue
+		}
 		products = append(products, *p)
 	}
 	return products, nil
 }
 
-func (s *mockProductService) UpdateProduct(ctx context.Context, id types.ID, name string, price int) (*models.Product, error) {
+func (s *mockProductService) UpdateProduct(ctx context.Context, id types.ID, input services.ProductInput) (*models.Product, error) {
 	if product, exists := s.products[id]; exists {
-		product.Name = name
-		product.Price = price
+		product.Name = input.Name
+		product.Price = input.Price
 		return product, nil
 	}
 	return nil, &services.ServiceError{Message: "Product not found"}
@@ -66,6 +77,50 @@ func (s *mockProductService) DeleteProduct(ctx context.Context, id types.ID) err
 	return nil
 }
 
+func (s *mockProductService) UploadProductImage(ctx context.Context, id types.ID, data []byte) (*models.Product, error) {
+	if product, exists := s.products[id]; exists {
+		product.ImagePath = string(id) + ".png"
+		return product, nil
+	}
+	return nil, &services.ServiceError{Message: "Product not found"}
+}
+
+func (s *mockProductService) GetProductImage(ctx context.Context, id types.ID) (io.ReadCloser, error) {
+	return nil, &services.ServiceError{Message: "Image not found"}
+}
+
+func (s *mockProductService) GetPriceHistory(ctx context.Context, id types.ID) ([]models.ProductPrice, error) {
+	if _, exists := s.products[id]; !exists {
+		return nil, &services.ServiceError{Message: "Product not found"}
+	}
+	return s.prices[id], nil
+}
+
+func (s *mockProductService) SchedulePriceChange(ctx context.Context, id types.ID, price int, effectiveFrom time.Time) (*models.ProductPrice, error) {
+	if _, exists := s.products[id]; !exists {
+		return nil, &services.ServiceError{Message: "Product not found"}
+	}
+	if !effectiveFrom.After(time.Now()) {
+		return nil, services.ErrInvalidPriceChange
+	}
+	entry := models.ProductPrice{
+		ID:            types.ID("price-" + string(id)),
+		ProductID:     id,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+	}
+	s.prices[id] = append(s.prices[id], entry)
+	return &entry, nil
+}
+
+func (s *mockProductService) CancelPriceChange(ctx context.Context, id, priceID types.ID) error {
+	return nil
+}
+
+func (s *mockProductService) ApplyScheduledPrices(ctx context.Context) error {
+	return nil
+}
+
 func TestProductHandler_Create(t *testing.T) {
 	app := fiber.New()
 	mockService := newMockProductService()
@@ -111,8 +166,8 @@ func TestProductHandler_GetAll(t *testing.T) {
 
 	// Add test data
 	ctx := context.Background()
-	mockService.CreateProduct(ctx, "Product 1", 1000)
-	mockService.CreateProduct(ctx, "Product 2", 2000)
+	mockService.CreateProduct(ctx, services.ProductInput{Name: "Product 1", Price: 1000})
+	mockService.CreateProduct(ctx, services.ProductInput{Name: "Product 2", Price: 2000})
 
 	app.Get("/products", handler.GetAll)
 
@@ -141,7 +196,7 @@ func TestProductHandler_GetByID(t *testing.T) {
 	handler := NewProductHandler(mockService)
 
 	ctx := context.Background()
-	product, _ := mockService.CreateProduct(ctx, "Test Product", 1000)
+	product, _ := mockService.CreateProduct(ctx, services.ProductInput{Name: "Test Product", Price: 1000})
 
 	app.Get("/products/:id", handler.GetByID)
 
@@ -171,7 +226,7 @@ func TestProductHandler_Update(t *testing.T) {
 
 	// Add test data
 	ctx := context.Background()
-	product, _ := mockService.CreateProduct(ctx, "Test Product", 1000)
+	product, _ := mockService.CreateProduct(ctx, services.ProductInput{Name: "Test Product", Price: 1000})
 
 	app.Put("/products/:id", handler.Update)
 
@@ -212,7 +267,7 @@ func TestProductHandler_Delete(t *testing.T) {
 
 	// Add test data
 	ctx := context.Background()
-	product, _ := mockService.CreateProduct(ctx, "Test Product", 1000)
+	product, _ := mockService.CreateProduct(ctx, services.ProductInput{Name: "Test Product", Price: 1000})
 
 	app.Delete("/products/:id", handler.Delete)
 
@@ -227,3 +282,75 @@ func TestProductHandler_Delete(t *testing.T) {
 		t.Errorf("Expected status code %d, got %d", fiber.StatusNoContent, resp.StatusCode)
 	}
 }
+
+func TestProductHandler_GetAllExcludingAllergens(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockProductService()
+	handler := NewProductHandler(mockService)
+
+	ctx := context.Background()
+	mockService.CreateProduct(ctx, services.ProductInput{Name: "Crepe", Price: 300, Allergens: types.NewAllergenSet(types.EGG, types.MILK)})
+	mockService.CreateProduct(ctx, services.ProductInput{Name: "Tea", Price: 150})
+
+	app.Get("/products", handler.GetAll)
+
+	req := httptest.NewRequest("GET", "/products?excludeAllergens=EGG", nil)
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	var response []ProductResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+
+	if len(response) != 1 || response[0].Name != "Tea" {
+		t.Errorf("Expected only Tea, got %v", response)
+	}
+
+	req = httptest.NewRequest("GET", "/products?excludeAllergens=UNKNOWN", nil)
+	resp, _ = app.Test(req)
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestProductHandler_CreateWithAllergens(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockProductService()
+	handler := NewProductHandler(mockService)
+
+	app.Post("/products", handler.Create)
+
+	reqBody := CreateProductRequest{
+		Name:        "Crepe",
+		Price:       300,
+		Allergens:   []string{"EGG", "MILK"},
+		DietaryTags: []string{"VEGETARIAN"},
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/products", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusCreated, resp.StatusCode)
+	}
+
+	var response ProductResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+
+	if len(response.Allergens) != 2 || response.Allergens[0] != "EGG" || response.Allergens[1] != "MILK" {
+		t.Errorf("Expected allergens [EGG MILK], got %v", response.Allergens)
+	}
+
+	if len(response.DietaryTags) != 1 || response.DietaryTags[0] != "VEGETARIAN" {
+		t.Errorf("Expected dietary tags [VEGETARIAN], got %v", response.DietaryTags)
+	}
+}
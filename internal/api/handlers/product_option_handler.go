@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"net/url"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProductOptionHandler struct {
+	optionService services.ProductOptionService
+}
+
+func NewProductOptionHandler(optionService services.ProductOptionService) *ProductOptionHandler {
+	return &ProductOptionHandler{optionService: optionService}
+}
+
+// @Summary Add an option group to a product
+// @Tags product-options
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param group body OptionGroupRequest true "Option group"
+// @Success 201 {object} OptionGroupResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Router /products/{id}/option-groups [post]
+func (h *ProductOptionHandler) Create(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req OptionGroupRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	input, err := toOptionGroupInput(req)
+	if err != nil {
+		return err
+	}
+
+	group, err := h.optionService.CreateOptionGroup(c.Context(), types.ID(id), input)
+	if err != nil {
+		if err == services.ErrInvalidOptionGroup {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewOptionGroupResponse(group))
+}
+
+// @Summary Get the option groups of a product
+// @Tags product-options
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {array} OptionGroupResponse
+// @Router /products/{id}/option-groups [get]
+func (h *ProductOptionHandler) GetByProduct(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	groups, err := h.optionService.GetOptionGroups(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewOptionGroupResponseList(groups))
+}
+
+// @Summary Update an option group
+// @Description Replaces the group's options with the ones in the request. Pass an option's id to keep it.
+// @Tags product-options
+// @Accept json
+// @Produce json
+// @Param id path string true "Option group ID"
+// @Param group body OptionGroupRequest true "Option group"
+// @Success 200 {object} OptionGroupResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /option-groups/{id} [put]
+func (h *ProductOptionHandler) Update(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req OptionGroupRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	input, err := toOptionGroupInput(req)
+	if err != nil {
+		return err
+	}
+
+	group, err := h.optionService.UpdateOptionGroup(c.Context(), types.ID(id), input)
+	if err != nil {
+		if err == services.ErrInvalidOptionGroup {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Option group not found")
+	}
+
+	return c.JSON(NewOptionGroupResponse(group))
+}
+
+// @Summary Delete an option group
+// @Tags product-options
+// @Param id path string true "Option group ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} ErrorResponse
+// @Router /option-groups/{id} [delete]
+func (h *ProductOptionHandler) Delete(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	if err := h.optionService.DeleteOptionGroup(c.Context(), types.ID(id)); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Option group not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func toOptionGroupInput(req OptionGroupRequest) (services.OptionGroupInput, error) {
+	selectionType := types.SINGLE
+	if req.SelectionType != "" {
+		var ok bool
+		if selectionType, ok = types.ParseSelectionType(req.SelectionType); !ok {
+			return services.OptionGroupInput{}, fiber.NewError(fiber.StatusBadRequest, "Invalid selection type")
+		}
+	}
+
+	input := services.OptionGroupInput{
+		Name:          req.Name,
+		SelectionType: selectionType,
+		Required:      req.Required,
+		MinSelect:     req.MinSelect,
+		MaxSelect:     req.MaxSelect,
+		DisplayOrder:  req.DisplayOrder,
+	}
+	for _, opt := range req.Options {
+		var id *types.ID
+		if opt.ID != nil {
+			optionID := types.ID(*opt.ID)
+			id = &optionID
+		}
+		input.Options = append(input.Options, services.OptionInput{
+			ID:           id,
+			Name:         opt.Name,
+			PriceDelta:   opt.PriceDelta,
+			DisplayOrder: opt.DisplayOrder,
+		})
+	}
+	return input, nil
+}
+
+func toIDs(ids []string) []types.ID {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]types.ID, len(ids))
+	for i, id := range ids {
+		result[i] = types.ID(id)
+	}
+	return result
+}
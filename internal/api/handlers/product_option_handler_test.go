@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockProductOptionService struct {
+	groups map[types.ID]*models.ProductOptionGroup
+}
+
+func newMockProductOptionService() *mockProductOptionService {
+	return &mockProductOptionService{
+		groups: make(map[types.ID]*models.ProductOptionGroup),
+	}
+}
+
+func (s *mockProductOptionService) CreateOptionGroup(ctx context.Context, productID types.ID, input services.OptionGroupInput) (*models.ProductOptionGroup, error) {
+	group := &models.ProductOptionGroup{
+		ID:            types.ID("test-id-" + input.Name),
+		ProductID:     productID,
+		Name:          input.Name,
+		SelectionType: input.SelectionType,
+		Required:      input.Required,
+		MinSelect:     input.MinSelect,
+		MaxSelect:     input.MaxSelect,
+	}
+	for _, opt := range input.Options {
+		group.Options = append(group.Options, models.ProductOption{
+			ID:         types.ID("test-id-" + opt.Name),
+			Name:       opt.Name,
+			PriceDelta: opt.PriceDelta,
+		})
+	}
+	s.groups[group.ID] = group
+	return group, nil
+}
+
+func (s *mockProductOptionService) GetOptionGroup(ctx context.Context, id types.ID) (*models.ProductOptionGroup, error) {
+	if group, exists := s.groups[id]; exists {
+		return group, nil
+	}
+	return nil, &services.ServiceError{Message: "Option group not found"}
+}
+
+func (s *mockProductOptionService) GetOptionGroups(ctx context.Context, productID types.ID) ([]models.ProductOptionGroup, error) {
+	var groups []models.ProductOptionGroup
+	for _, g := range s.groups {
+		if g.ProductID == productID {
+			groups = append(groups, *g)
+		}
+	}
+	return groups, nil
+}
+
+func (s *mockProductOptionService) UpdateOptionGroup(ctx context.Context, id types.ID, input services.OptionGroupInput) (*models.ProductOptionGroup, error) {
+	if group, exists := s.groups[id]; exists {
+		group.Name = input.Name
+		return group, nil
+	}
+	return nil, &services.ServiceError{Message: "Option group not found"}
+}
+
+func (s *mockProductOptionService) DeleteOptionGroup(ctx context.Context, id types.ID) error {
+	if _, exists := s.groups[id]; !exists {
+		return &services.ServiceError{Message: "Option group not found"}
+	}
+	delete(s.groups, id)
+	return nil
+}
+
+func TestProductOptionHandler_Create(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockProductOptionService()
+	handler := NewProductOptionHandler(mockService)
+
+	app.Post("/products/:id/option-groups", handler.Create)
+
+	reqBody := OptionGroupRequest{
+		Name:          "Toppings",
+		SelectionType: "MULTIPLE",
+		MaxSelect:     2,
+		Options: []OptionRequest{
+			{Name: "Fried egg", PriceDelta: 100},
+			{Name: "Mayonnaise", PriceDelta: 0},
+		},
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/products/test-product-id/option-groups", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusCreated {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusCreated, resp.StatusCode)
+	}
+
+	var response OptionGroupResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+
+	if response.SelectionType != types.MULTIPLE.String() {
+		t.Errorf("Expected selection type %s, got %s", types.MULTIPLE, response.SelectionType)
+	}
+
+	if len(response.Options) != 2 {
+		t.Errorf("Expected 2 options, got %d", len(response.Options))
+	}
+}
+
+func TestProductOptionHandler_CreateInvalidSelectionType(t *testing.T) {
+	app := fiber.New()
+	handler := NewProductOptionHandler(newMockProductOptionService())
+
+	app.Post("/products/:id/option-groups", handler.Create)
+
+	body, _ := json.Marshal(OptionGroupRequest{Name: "Size", SelectionType: "SOME"})
+
+	req := httptest.NewRequest("POST", "/products/test-product-id/option-groups", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PromotionHandler struct {
+	promotionService services.PromotionService
+}
+
+func NewPromotionHandler(promotionService services.PromotionService) *PromotionHandler {
+	return &PromotionHandler{promotionService: promotionService}
+}
+
+// @Summary Create a new promotion
+// @Description Promotions without a code apply automatically to matching orders. Promotions with a code apply only when the code is sent with the order.
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param promotion body PromotionRequest true "Promotion information"
+// @Success 201 {object} PromotionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Router /promotions [post]
+func (h *PromotionHandler) Create(c *fiber.Ctx) error {
+	var req PromotionRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	input, err := toPromotionInput(req)
+	if err != nil {
+		return err
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(c.Context(), input)
+	if err != nil {
+		if err == services.ErrInvalidPromotion || err == services.ErrInvalidTimeRange {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewPromotionResponse(promotion))
+}
+
+// @Summary Get all promotions
+// @Tags promotions
+// @Produce json
+// @Success 200 {array} PromotionResponse
+// @Router /promotions [get]
+func (h *PromotionHandler) GetAll(c *fiber.Ctx) error {
+	promotions, err := h.promotionService.GetAllPromotions(c.Context())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewPromotionResponseList(promotions))
+}
+
+// @Summary Get a promotion by ID
+// @Tags promotions
+// @Produce json
+// @Param id path string true "Promotion ID"
+// @Success 200 {object} PromotionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /promotions/{id} [get]
+func (h *PromotionHandler) GetByID(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	promotion, err := h.promotionService.GetPromotion(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+	}
+
+	return c.JSON(NewPromotionResponse(promotion))
+}
+
+// @Summary Update a promotion
+// @Tags promotions
+// @Accept json
+// @Produce json
+// @Param id path string true "Promotion ID"
+// @Param promotion body PromotionRequest true "Promotion information"
+// @Success 200 {object} PromotionResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /promotions/{id} [put]
+func (h *PromotionHandler) Update(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req PromotionRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	input, err := toPromotionInput(req)
+	if err != nil {
+		return err
+	}
+
+	promotion, err := h.promotionService.UpdatePromotion(c.Context(), types.ID(id), input)
+	if err != nil {
+		if err == services.ErrInvalidPromotion || err == services.ErrInvalidTimeRange {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+	}
+
+	return c.JSON(NewPromotionResponse(promotion))
+}
+
+// @Summary Delete a promotion
+// @Tags promotions
+// @Param id path string true "Promotion ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} ErrorResponse
+// @Router /promotions/{id} [delete]
+func (h *PromotionHandler) Delete(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	if err := h.promotionService.DeletePromotion(c.Context(), types.ID(id)); err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Promotion not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Get the discount cost of each promotion
+// @Description Counts only orders that were not cancelled.
+// @Tags promotions
+// @Produce json
+// @Success 200 {array} PromotionUsageResponse
+// @Router /promotions/report [get]
+func (h *PromotionHandler) Report(c *fiber.Ctx) error {
+	usage, err := h.promotionService.GetUsageReport(c.Context())
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	result := make([]PromotionUsageResponse, len(usage))
+	for i, u := range usage {
+		result[i] = PromotionUsageResponse{
+			PromotionID:    string(u.PromotionID),
+			Name:           u.Name,
+			Uses:           u.Uses,
+			DiscountAmount: u.DiscountAmount,
+		}
+	}
+	return c.JSON(result)
+}
+
+func toPromotionInput(req PromotionRequest) (services.PromotionInput, error) {
+	promotionType, ok := types.ParsePromotionType(req.Type)
+	if !ok {
+		return services.PromotionInput{}, fiber.NewError(fiber.StatusBadRequest, "Invalid promotion type")
+	}
+
+	startsAt, err := parseOptionalTime(req.StartsAt)
+	if err != nil {
+		return services.PromotionInput{}, fiber.NewError(fiber.StatusBadRequest, "Invalid start time format")
+	}
+	endsAt, err := parseOptionalTime(req.EndsAt)
+	if err != nil {
+		return services.PromotionInput{}, fiber.NewError(fiber.StatusBadRequest, "Invalid end time format")
+	}
+
+	// Promotions are active unless they are explicitly created inactive.
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	return services.PromotionInput{
+		Name:         req.Name,
+		Type:         promotionType,
+		Value:        req.Value,
+		BuyQuantity:  req.BuyQuantity,
+		FreeQuantity: req.FreeQuantity,
+		ProductID:    (*types.ID)(req.ProductID),
+		SalesSlotID:  (*types.ID)(req.SalesSlotID),
+		Code:         req.Code,
+		MinAmount:    req.MinAmount,
+		UsageLimit:   req.UsageLimit,
+		StartsAt:     startsAt,
+		EndsAt:       endsAt,
+		IsActive:     isActive,
+	}, nil
+}
+
+func parseOptionalTime(s *string) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockPromotionService struct {
+	promotions map[types.ID]*models.Promotion
+}
+
+func newMockPromotionService() *mockPromotionService {
+	return &mockPromotionService{
+		promotions: make(map[types.ID]*models.Promotion),
+	}
+}
+
+func (s *mockPromotionService) apply(promotion *models.Promotion, input services.PromotionInput) {
+	promotion.Name = input.Name
+	promotion.Type = input.Type
+	promotion.Value = input.Value
+	promotion.Code = input.Code
+	promotion.IsActive = input.IsActive
+}
+
+func (s *mockPromotionService) CreatePromotion(ctx context.Context, input services.PromotionInput) (*models.Promotion, error) {
+	if input.Type == types.PERCENTAGE && input.Value > 100 {
+		return nil, services.ErrInvalidPromotion
+	}
+	promotion := &models.Promotion{ID: types.ID("test-id-" + input.Name)}
+	s.apply(promotion, input)
+	s.promotions[promotion.ID] = promotion
+	return promotion, nil
+}
+
+func (s *mockPromotionService) GetPromotion(ctx context.Context, id types.ID) (*models.Promotion, error) {
+	if promotion, exists := s.promotions[id]; exists {
+		return promotion, nil
+	}
+	return nil, repositories.NewErrNotFound("Promotion", id)
+}
+
+func (s *mockPromotionService) GetAllPromotions(ctx context.Context) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	for _, p := range s.promotions {
+		promotions = append(promotions, *p)
+	}
+	return promotions, nil
+}
+
+func (s *mockPromotionService) UpdatePromotion(ctx context.Context, id types.ID, input services.PromotionInput) (*models.Promotion, error) {
+	promotion, exists := s.promotions[id]
+	if !exists {
+		return nil, repositories.NewErrNotFound("Promotion", id)
+	}
+	s.apply(promotion, input)
+	return promotion, nil
+}
+
+func (s *mockPromotionService) DeletePromotion(ctx context.Context, id types.ID) error {
+	if _, exists := s.promotions[id]; !exists {
+		return repositories.NewErrNotFound("Promotion", id)
+	}
+	delete(s.promotions, id)
+	return nil
+}
+
+func (s *mockPromotionService) GetUsageReport(ctx context.Context) ([]repositories.PromotionUsage, error) {
+	return nil, nil
+}
+
+func TestPromotionHandler_Create(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockPromotionService()
+	handler := NewPromotionHandler(mockService)
+
+	app.Post("/promotions", handler.Create)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		isActive       bool
+	}{
+		{name: "active by default", body: `{"name":"Opening","type":"PERCENTAGE","value":10}`, expectedStatus: fiber.StatusCreated, isActive: true},
+		{name: "created inactive", body: `{"name":"Next week","type":"FIXED_AMOUNT","value":100,"isActive":false}`, expectedStatus: fiber.StatusCreated},
+		{name: "invalid percentage", body: `{"name":"Too much","type":"PERCENTAGE","value":150}`, expectedStatus: fiber.StatusBadRequest},
+		{name: "unknown type", body: `{"name":"Odd","type":"RANDOM","value":10}`, expectedStatus: fiber.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/promotions", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedStatus != fiber.StatusCreated {
+				return
+			}
+
+			var response PromotionResponse
+			json.NewDecoder(resp.Body).Decode(&response)
+			if response.IsActive != tt.isActive {
+				t.Errorf("Expected isActive %v, got %v", tt.isActive, response.IsActive)
+			}
+			if mockService.promotions[types.ID(response.ID)].IsActive != tt.isActive {
+				t.Errorf("Expected the stored promotion to have isActive %v", tt.isActive)
+			}
+		})
+	}
+}
+
+func TestPromotionHandler_Update(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockPromotionService()
+	handler := NewPromotionHandler(mockService)
+
+	ctx := context.Background()
+	promotion, _ := mockService.CreatePromotion(ctx, services.PromotionInput{Name: "Opening", Type: types.PERCENTAGE, Value: 10, IsActive: true})
+
+	app.Put("/promotions/:id", handler.Update)
+
+	req := httptest.NewRequest("PUT", "/promotions/"+string(promotion.ID), strings.NewReader(`{"name":"Opening","type":"PERCENTAGE","value":10,"isActive":false}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response PromotionResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.IsActive || mockService.promotions[promotion.ID].IsActive {
+		t.Error("Expected the promotion to be deactivated")
+	}
+
+	req = httptest.NewRequest("PUT", "/promotions/missing", strings.NewReader(`{"name":"Opening","type":"PERCENTAGE","value":10}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+	}
+}
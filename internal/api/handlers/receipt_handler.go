@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReceiptHandler struct {
+	receiptService services.ReceiptService
+}
+
+func NewReceiptHandler(receiptService services.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{receiptService: receiptService}
+}
+
+// receiptError maps the errors of rendering and printing tickets to a
+// response.
+func receiptError(err error) error {
+	if errors.Is(err, services.ErrPrinterNotConfigured) {
+		return fiber.NewError(fiber.StatusServiceUnavailable, err.Error())
+	}
+	var notFound *repositories.ErrNotFound
+	if errors.As(err, &notFound) {
+		return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
+	}
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+}
+
+// @Summary Get the receipt of an order ticket
+// @Tags order-tickets
+// @Produce application/pdf
+// @Produce application/octet-stream
+// @Param id path string true "Ticket ID"
+// @Param format query string false "Output format" Enums(pdf, escpos)
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /order-tickets/{id}/receipt [get]
+func (h *ReceiptHandler) GetReceipt(c *fiber.Ctx) error {
+	return h.render(c, receipt.KindReceipt)
+}
+
+// @Summary Get the kitchen slip of an order ticket
+// @Tags order-tickets
+// @Produce application/pdf
+// @Produce application/octet-stream
+// @Param id path string true "Ticket ID"
+// @Param format query string false "Output format" Enums(pdf, escpos)
+// @Success 200 {file} binary
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /order-tickets/{id}/kitchen-slip [get]
+func (h *ReceiptHandler) GetKitchenSlip(c *fiber.Ctx) error {
+	return h.render(c, receipt.KindKitchenSlip)
+}
+
+// @Summary Print a receipt or kitchen slip
+// @Tags order-tickets
+// @Accept json
+// @Param id path string true "Ticket ID"
+// @Param job body PrintTicketRequest true "Print job"
+// @Success 202 "Accepted"
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /order-tickets/{id}/print [post]
+func (h *ReceiptHandler) Print(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req PrintTicketRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	kind, ok := receipt.ParseKind(req.Kind)
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid print kind")
+	}
+
+	if err := h.receiptService.PrintTicket(c.Context(), types.ID(id), kind, req.Reprint); err != nil {
+		return receiptError(err)
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+func (h *ReceiptHandler) render(c *fiber.Ctx, kind receipt.Kind) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	format, ok := receipt.ParseFormat(c.Query("format", string(receipt.FormatPDF)))
+	if !ok {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid format")
+	}
+
+	data, err := h.receiptService.RenderTicket(c.Context(), types.ID(id), kind, format)
+	if err != nil {
+		return receiptError(err)
+	}
+
+	if format == receipt.FormatPDF {
+		c.Set(fiber.HeaderContentType, "application/pdf")
+	} else {
+		c.Set(fiber.HeaderContentType, fiber.MIMEOctetStream)
+	}
+	return c.Send(data)
+}
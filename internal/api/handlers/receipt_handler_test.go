@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockReceiptService struct {
+	printed []receipt.Kind
+	// noPrinter makes printing fail as if no printer were configured.
+	noPrinter bool
+}
+
+func newMockReceiptService() *mockReceiptService {
+	return &mockReceiptService{}
+}
+
+func (s *mockReceiptService) RenderTicket(ctx context.Context, ticketID types.ID, kind receipt.Kind, format receipt.Format) ([]byte, error) {
+	switch ticketID {
+	case "test-id":
+	case "broken-id":
+		return nil, errors.New("connection refused")
+	default:
+		return nil, repositories.NewErrNotFound("OrderTicket", ticketID)
+	}
+	return []byte(string(kind) + "/" + string(format)), nil
+}
+
+func (s *mockReceiptService) PrintTicket(ctx context.Context, ticketID types.ID, kind receipt.Kind, reprint bool) error {
+	if s.noPrinter {
+		return services.ErrPrinterNotConfigured
+	}
+	if ticketID != "test-id" {
+		return repositories.NewErrNotFound("OrderTicket", ticketID)
+	}
+	s.printed = append(s.printed, kind)
+	return nil
+}
+
+func TestReceiptHandler_GetReceipt(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockReceiptService()
+	handler := NewReceiptHandler(mockService)
+
+	app.Get("/order-tickets/:id/receipt", handler.GetReceipt)
+	app.Get("/order-tickets/:id/kitchen-slip", handler.GetKitchenSlip)
+
+	req := httptest.NewRequest("GET", "/order-tickets/test-id/receipt", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	if resp.Header.Get("Content-Type") != "application/pdf" {
+		t.Errorf("Expected PDF content type, got %s", resp.Header.Get("Content-Type"))
+	}
+
+	req = httptest.NewRequest("GET", "/order-tickets/test-id/kitchen-slip?format=escpos", nil)
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "kitchen/escpos" {
+		t.Errorf("Expected kitchen/escpos, got %s", body)
+	}
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{path: "/order-tickets/test-id/receipt?format=png", expectedStatus: fiber.StatusBadRequest},
+		{path: "/order-tickets/missing/receipt", expectedStatus: fiber.StatusNotFound},
+		{path: "/order-tickets/broken-id/receipt", expectedStatus: fiber.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		resp, _ = app.Test(httptest.NewRequest("GET", tt.path, nil))
+		if resp.StatusCode != tt.expectedStatus {
+			t.Errorf("%s: expected status code %d, got %d", tt.path, tt.expectedStatus, resp.StatusCode)
+		}
+	}
+}
+
+func TestReceiptHandler_Print(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockReceiptService()
+	handler := NewReceiptHandler(mockService)
+
+	app.Post("/order-tickets/:id/print", handler.Print)
+
+	reqBody := PrintTicketRequest{
+		Kind:    "kitchen",
+		Reprint: true,
+	}
+	body, _ := json.Marshal(reqBody)
+
+	req := httptest.NewRequest("POST", "/order-tickets/test-id/print", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusAccepted {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusAccepted, resp.StatusCode)
+	}
+
+	if len(mockService.printed) != 1 || mockService.printed[0] != receipt.KindKitchenSlip {
+		t.Errorf("Expected one kitchen slip print job, got %v", mockService.printed)
+	}
+
+	req = httptest.NewRequest("POST", "/order-tickets/missing/print", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNotFound, resp.StatusCode)
+	}
+
+	mockService.noPrinter = true
+	req = httptest.NewRequest("POST", "/order-tickets/test-id/print", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusServiceUnavailable, resp.StatusCode)
+	}
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/url"
 	"time"
 
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -21,14 +23,16 @@ func NewSalesSlotHandler(salesSlotService services.SalesSlotService) *SalesSlotH
 // @Tags sales-slots
 // @Accept json
 // @Produce json
+// @Description The slot may not overlap other slots, or under the PER_BOOTH policy other slots at the same booth.
 // @Param slot body CreateSalesSlotRequest true "Sales slot information"
 // @Success 201 {object} SalesSlotResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 409 {object} SlotConflictResponse
 // @Router /sales-slots [post]
 func (h *SalesSlotHandler) Create(c *fiber.Ctx) error {
 	var req CreateSalesSlotRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &req); !ok {
+		return err
 	}
 
 	startTime, err := time.Parse(time.RFC3339, req.StartTime)
@@ -41,14 +45,105 @@ func (h *SalesSlotHandler) Create(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid end time format")
 	}
 
-	slot, err := h.salesSlotService.CreateSalesSlot(c.Context(), startTime, endTime)
+	slot, err := h.salesSlotService.CreateSalesSlot(c.Context(), req.Booth, startTime, endTime)
 	if err != nil {
+		if isSlotConflict(err) {
+			return slotConflict(c, err)
+		}
+		if err == services.ErrInvalidTimeRange {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(NewSalesSlotResponse(slot))
 }
 
+// @Summary Generate sales slots from a daily pattern
+// @Description Slots that already exist at the same booth and times are kept and only get the products they lack, so a pattern can be generated again. With dryRun the slots are only previewed, including any slots they overlap.
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param pattern body GenerateSalesSlotsRequest true "Slot pattern"
+// @Success 200 {array} GeneratedSlotResponse
+// @Success 201 {array} GeneratedSlotResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} SlotConflictResponse
+// @Router /sales-slots/generate [post]
+func (h *SalesSlotHandler) Generate(c *fiber.Ctx) error {
+	var req GenerateSalesSlotsRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	pattern, err := toSlotPattern(req)
+	if err != nil {
+		return err
+	}
+
+	generated, err := h.salesSlotService.GenerateSalesSlots(c.Context(), pattern, req.DryRun)
+	if err != nil {
+		if isSlotConflict(err) {
+			return slotConflict(c, err)
+		}
+		switch err {
+		case services.ErrInvalidSlotPattern, services.ErrTooManySlots, services.ErrBundleInventory:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Product not found")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	status := fiber.StatusCreated
+	if req.DryRun {
+		status = fiber.StatusOK
+	}
+	return c.Status(status).JSON(NewGeneratedSlotResponseList(generated))
+}
+
+// toSlotPattern converts a validated request into a slot pattern.
+func toSlotPattern(req GenerateSalesSlotsRequest) (services.SlotPattern, error) {
+	zone := req.TimeZone
+	if zone == "" {
+		zone = "Asia/Tokyo"
+	}
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return services.SlotPattern{}, fiber.NewError(fiber.StatusBadRequest, "Invalid time zone")
+	}
+
+	firstDay, _ := time.Parse(time.DateOnly, req.FirstDay)
+	lastDay, _ := time.Parse(time.DateOnly, req.LastDay)
+	opensAt, _ := services.ParseClock(req.OpensAt)
+	closesAt, _ := services.ParseClock(req.ClosesAt)
+	pattern := services.SlotPattern{
+		Booth:      req.Booth,
+		FirstDay:   firstDay,
+		LastDay:    lastDay,
+		OpensAt:    opensAt,
+		ClosesAt:   closesAt,
+		SlotLength: time.Duration(req.SlotMinutes) * time.Minute,
+		Location:   location,
+	}
+	for _, b := range req.Breaks {
+		start, _ := services.ParseClock(b.Start)
+		end, _ := services.ParseClock(b.End)
+		pattern.Breaks = append(pattern.Breaks, services.SlotBreak{Start: start, End: end})
+	}
+	for _, p := range req.Products {
+		pattern.Products = append(pattern.Products, services.SlotTemplateProduct{
+			ProductID:       types.ID(p.ProductID),
+			InitialQuantity: p.InitialQuantity,
+			Price:           p.Price,
+		})
+	}
+	return pattern, nil
+}
+
 // @Summary Get all sales slots
 // @Tags sales-slots
 // @Produce json
@@ -83,12 +178,84 @@ func (h *SalesSlotHandler) GetByID(c *fiber.Ctx) error {
 	return c.JSON(NewSalesSlotResponse(slot))
 }
 
+// @Summary Update a sales slot
+// @Description The times of a slot with orders are only changed with force; its orders are kept.
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param slot body UpdateSalesSlotRequest true "Sales slot information"
+// @Param force query bool false "Change the times even if the slot has orders"
+// @Success 200 {object} SalesSlotResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} SlotConflictResponse
+// @Router /sales-slots/{id} [put]
+func (h *SalesSlotHandler) Update(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req UpdateSalesSlotRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	startTime, _ := time.Parse(time.RFC3339, req.StartTime)
+	endTime, _ := time.Parse(time.RFC3339, req.EndTime)
+
+	slot, err := h.salesSlotService.UpdateSalesSlot(c.Context(), types.ID(id), req.Booth, startTime, endTime, c.QueryBool("force"))
+	if err != nil {
+		if isSlotConflict(err) {
+			return slotConflict(c, err)
+		}
+		switch err {
+		case services.ErrInvalidTimeRange:
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		case services.ErrSlotHasOrders:
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
+	}
+
+	return c.JSON(NewSalesSlotResponse(slot))
+}
+
+// @Summary Delete a sales slot
+// @Description A slot with orders is only deleted with force, which cancels its orders and voids their tickets. Slots with confirmed or paid orders cannot be deleted.
+// @Tags sales-slots
+// @Param id path string true "Sales Slot ID"
+// @Param force query bool false "Cancel the slot's orders and delete it"
+// @Success 204 "No Content"
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /sales-slots/{id} [delete]
+func (h *SalesSlotHandler) Delete(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	if err := h.salesSlotService.DeleteSalesSlot(c.Context(), types.ID(id), c.QueryBool("force")); err != nil {
+		if err == services.ErrSlotHasOrders || err == services.ErrSlotHasSales {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 // @Summary Activate a sales slot
+// @Description Slots that are not pinned are closed again when they are outside their schedule. Only one slot per booth can be active.
 // @Tags sales-slots
 // @Produce json
 // @Param id path string true "Sales Slot ID"
 // @Success 200 {object} SalesSlotResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} SlotConflictResponse
 // @Router /sales-slots/{id}/activate [put]
 func (h *SalesSlotHandler) Activate(c *fiber.Ctx) error {
 	id, err := url.PathUnescape(c.Params("id"))
@@ -96,6 +263,9 @@ func (h *SalesSlotHandler) Activate(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
 	}
 	if err := h.salesSlotService.ActivateSalesSlot(c.Context(), types.ID(id)); err != nil {
+		if isSlotConflict(err) {
+			return slotConflict(c, err)
+		}
 		return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
 	}
 
@@ -104,6 +274,7 @@ func (h *SalesSlotHandler) Activate(c *fiber.Ctx) error {
 }
 
 // @Summary Deactivate a sales slot
+// @Description Slots that are not pinned are opened again when they are within their schedule.
 // @Tags sales-slots
 // @Produce json
 // @Param id path string true "Sales Slot ID"
@@ -123,6 +294,96 @@ func (h *SalesSlotHandler) Deactivate(c *fiber.Ctx) error {
 	return c.JSON(NewSalesSlotResponse(slot))
 }
 
+// @Summary Pin a sales slot open or closed
+// @Description With AUTO the slot is opened and closed according to its start and end time. A slot is not opened while another slot at its booth is active.
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param override body SetSlotOverrideRequest true "Override"
+// @Success 200 {object} SalesSlotResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} SlotConflictResponse
+// @Router /sales-slots/{id}/override [put]
+func (h *SalesSlotHandler) SetOverride(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req SetSlotOverrideRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	override, _ := types.ParseSlotOverride(req.Override)
+
+	slot, err := h.salesSlotService.SetOverride(c.Context(), types.ID(id), override)
+	if err != nil {
+		if isSlotConflict(err) {
+			return slotConflict(c, err)
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
+	}
+
+	return c.JSON(NewSalesSlotResponse(slot))
+}
+
+// @Summary Get the history of a sales slot being opened and closed
+// @Tags sales-slots
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Success 200 {array} SalesSlotTransitionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/transitions [get]
+func (h *SalesSlotHandler) GetTransitions(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	transitions, err := h.salesSlotService.GetTransitions(c.Context(), types.ID(id))
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
+	}
+
+	return c.JSON(NewSalesSlotTransitionResponseList(transitions))
+}
+
+// @Summary Set or clear the order capacity of a sales slot
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param capacity body SetSlotCapacityRequest true "Slot capacity"
+// @Success 200 {object} SalesSlotResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/capacity [put]
+func (h *SalesSlotHandler) SetCapacity(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req SetSlotCapacityRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	slot, err := h.salesSlotService.SetCapacity(c.Context(), types.ID(id), req.MaxOrders, req.MaxItems)
+	if err != nil {
+		if err == services.ErrInvalidCapacity {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewSalesSlotResponse(slot))
+}
+
 // @Summary Add a product to a sales slot
 // @Tags sales-slots
 // @Accept json
@@ -130,7 +391,7 @@ func (h *SalesSlotHandler) Deactivate(c *fiber.Ctx) error {
 // @Param id path string true "Sales Slot ID"
 // @Param product body AddProductToSlotRequest true "Product information"
 // @Success 201 {object} ProductInventoryResponse
-// @Failure 400 {object} ErrorResponse
+// @Failure 400 {object} ValidationErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /sales-slots/{id}/products [post]
 func (h *SalesSlotHandler) AddProduct(c *fiber.Ctx) error {
@@ -139,8 +400,8 @@ func (h *SalesSlotHandler) AddProduct(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
 	}
 	var req AddProductToSlotRequest
-	if err := c.BodyParser(&req); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if ok, err := parseBody(c, &req); !ok {
+		return err
 	}
 
 	inventory, err := h.salesSlotService.AddProductToSlot(
@@ -148,12 +409,155 @@ func (h *SalesSlotHandler) AddProduct(c *fiber.Ctx) error {
 		types.ID(id),
 		types.ID(req.ProductID),
 		req.InitialQuantity,
+		req.Price,
 	)
 	if err != nil {
+		if err == services.ErrInvalidSlotPrice || err == services.ErrInvalidQuantity {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(NewProductInventoryResponse(inventory))
+}
+
+// @Summary Set or clear the price of a product in a sales slot
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Param price body SetSlotPriceRequest true "Slot price"
+// @Success 200 {object} ProductInventoryResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/price [put]
+func (h *SalesSlotHandler) SetProductPrice(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	productID, err := url.PathUnescape(c.Params("productId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req SetSlotPriceRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	inventory, err := h.salesSlotService.SetSlotPrice(c.Context(), types.ID(id), types.ID(productID), req.Price)
+	if err != nil {
+		if err == services.ErrInvalidSlotPrice {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Product is not in the sales slot")
+		}
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(inventory)
+	return c.JSON(NewProductInventoryResponse(inventory))
+}
+
+// @Summary Set or clear the purchase limits of a product in a sales slot
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Param limits body SetPurchaseLimitsRequest true "Purchase limits"
+// @Success 200 {object} ProductInventoryResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/limits [put]
+func (h *SalesSlotHandler) SetPurchaseLimits(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	productID, err := url.PathUnescape(c.Params("productId"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	var req SetPurchaseLimitsRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	inventory, err := h.salesSlotService.SetPurchaseLimits(c.Context(), types.ID(id), types.ID(productID), req.MaxPerOrder, req.MaxPerCustomer)
+	if err != nil {
+		if err == services.ErrInvalidPurchaseLimit {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Product is not in the sales slot")
+	}
+
+	return c.JSON(NewProductInventoryResponse(inventory))
+}
+
+// @Summary Set or clear the low-stock threshold of a product in a sales slot
+// @Description Staff are alerted when the available stock falls to the threshold and again when it sells out.
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Param threshold body SetLowStockThresholdRequest true "Low-stock threshold"
+// @Success 200 {object} ProductInventoryResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/low-stock-threshold [put]
+func (h *SalesSlotHandler) SetLowStockThreshold(c *fiber.Ctx) error {
+	id, productID, err := inventoryParams(c)
+	if err != nil {
+		return err
+	}
+	var req SetLowStockThresholdRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	inventory, err := h.salesSlotService.SetLowStockThreshold(c.Context(), id, productID, req.Threshold)
+	if err != nil {
+		if err == services.ErrInvalidStockThreshold {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Product is not in the sales slot")
+	}
+
+	return c.JSON(NewProductInventoryResponse(inventory))
+}
+
+// @Summary Stop or resume orders of a product in a sales slot
+// @Description Marks the product unavailable, e.g. when the kitchen runs out of an ingredient, even though stock remains.
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Param availability body SetAvailabilityRequest true "Availability"
+// @Success 200 {object} ProductInventoryResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/availability [put]
+func (h *SalesSlotHandler) SetAvailability(c *fiber.Ctx) error {
+	id, productID, err := inventoryParams(c)
+	if err != nil {
+		return err
+	}
+	var req SetAvailabilityRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	inventory, err := h.salesSlotService.SetAvailability(c.Context(), id, productID, *req.Available)
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Product is not in the sales slot")
+	}
+
+	return c.JSON(NewProductInventoryResponse(inventory))
 }
 
 // @Summary Get all products in a sales slot
@@ -173,5 +577,177 @@ func (h *SalesSlotHandler) GetProducts(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
 	}
 
-	return c.JSON(inventories)
+	return c.JSON(NewProductInventoryResponseList(inventories))
+}
+
+// @Summary Get the stock ledger of a product in a sales slot
+// @Tags sales-slots
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Success 200 {object} StockLedgerResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/stock [get]
+func (h *SalesSlotHandler) GetStock(c *fiber.Ctx) error {
+	id, productID, err := inventoryParams(c)
+	if err != nil {
+		return err
+	}
+
+	ledger, err := h.salesSlotService.GetStockLedger(c.Context(), id, productID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Product is not in the sales slot")
+	}
+
+	return c.JSON(NewStockLedgerResponse(ledger))
+}
+
+// @Summary Restock, write off or correct the stock of a product in a sales slot
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Param adjustment body AdjustStockRequest true "Stock adjustment"
+// @Success 200 {object} StockLedgerResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/stock/adjustments [post]
+func (h *SalesSlotHandler) AdjustStock(c *fiber.Ctx) error {
+	id, productID, err := inventoryParams(c)
+	if err != nil {
+		return err
+	}
+	var req AdjustStockRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+	movementType, _ := types.ParseStockMovementType(req.Type)
+
+	ledger, err := h.salesSlotService.AdjustStock(c.Context(), id, productID, movementType, req.Quantity, req.Reason)
+	if err != nil {
+		if err == services.ErrInvalidStockAdjustment || err == services.ErrInsufficientInventory {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Product is not in the sales slot")
+	}
+
+	return c.JSON(NewStockLedgerResponse(ledger))
+}
+
+// @Summary Reconcile the stock of a product in a sales slot with its ledger
+// @Description Sets the stock to the ledger's totals. Stock without ledger entries gets entries for its current quantities instead.
+// @Tags sales-slots
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Success 200 {object} StockLedgerResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/stock/reconcile [post]
+func (h *SalesSlotHandler) ReconcileStock(c *fiber.Ctx) error {
+	id, productID, err := inventoryParams(c)
+	if err != nil {
+		return err
+	}
+
+	ledger, err := h.salesSlotService.ReconcileStock(c.Context(), id, productID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusNotFound, "Product is not in the sales slot")
+	}
+
+	return c.JSON(NewStockLedgerResponse(ledger))
+}
+
+// @Summary Move available stock of a product to another sales slot
+// @Description Only unreserved stock is moved. The product is added to the other slot with the same price and limits if it is not sold there yet.
+// @Tags sales-slots
+// @Accept json
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Param productId path string true "Product ID"
+// @Param transfer body TransferStockRequest true "Stock transfer"
+// @Success 200 {object} StockTransferResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/products/{productId}/stock/transfers [post]
+func (h *SalesSlotHandler) TransferStock(c *fiber.Ctx) error {
+	id, productID, err := inventoryParams(c)
+	if err != nil {
+		return err
+	}
+	var req TransferStockRequest
+	if ok, err := parseBody(c, &req); !ok {
+		return err
+	}
+
+	transfer, err := h.salesSlotService.TransferStock(c.Context(), id, types.ID(req.ToSalesSlotID), productID, req.Quantity, req.Reason)
+	if err != nil {
+		if err == services.ErrInvalidStockTransfer || err == services.ErrInsufficientInventory {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		return fiber.NewError(fiber.StatusNotFound, "Product or sales slot not found")
+	}
+
+	return c.JSON(NewStockTransferResponse(transfer))
+}
+
+// @Summary Carry the unsold stock of a sales slot over to the next slot of its booth
+// @Description Moves the available stock of every product to the next slot of the same booth. Nothing is moved when there is none.
+// @Tags sales-slots
+// @Produce json
+// @Param id path string true "Sales Slot ID"
+// @Success 200 {array} StockTransferResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/roll-over [post]
+func (h *SalesSlotHandler) RollOverStock(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	transfers, err := h.salesSlotService.RollOverStock(c.Context(), types.ID(id))
+	if err != nil {
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to roll over stock")
+	}
+
+	response := make([]StockTransferResponse, len(transfers))
+	for i := range transfers {
+		response[i] = NewStockTransferResponse(&transfers[i])
+	}
+	return c.JSON(response)
+}
+
+// inventoryParams returns the slot and product IDs of routes for a product
+// in a slot.
+func inventoryParams(c *fiber.Ctx) (types.ID, types.ID, error) {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return "", "", fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	productID, err := url.PathUnescape(c.Params("productId"))
+	if err != nil {
+		return "", "", fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	return types.ID(id), types.ID(productID), nil
+}
+
+// isSlotConflict reports whether err rejects a slot for clashing with other
+// slots.
+func isSlotConflict(err error) bool {
+	var conflict *services.SlotConflictError
+	return errors.As(err, &conflict)
+}
+
+// slotConflict responds with the slots that clash with the requested one.
+func slotConflict(c *fiber.Ctx, err error) error {
+	var conflict *services.SlotConflictError
+	errors.As(err, &conflict)
+	return c.Status(fiber.StatusConflict).JSON(SlotConflictResponse{
+		Message:   conflict.Message,
+		Conflicts: NewSalesSlotResponseList(conflict.Conflicts),
+	})
 }
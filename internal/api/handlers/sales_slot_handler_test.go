@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/gofiber/fiber/v2"
@@ -18,18 +20,22 @@ import (
 type mockSalesSlotService struct {
 	slots       map[types.ID]*models.SalesSlot
 	inventories map[types.ID]*models.ProductInventory
+	// ordered marks slots that have orders.
+	ordered map[types.ID]bool
 }
 
 func newMockSalesSlotService() *mockSalesSlotService {
 	return &mockSalesSlotService{
 		slots:       make(map[types.ID]*models.SalesSlot),
 		inventories: make(map[types.ID]*models.ProductInventory),
+		ordered:     make(map[types.ID]bool),
 	}
 }
 
-func (s *mockSalesSlotService) CreateSalesSlot(ctx context.Context, startTime, endTime time.Time) (*models.SalesSlot, error) {
+func (s *mockSalesSlotService) CreateSalesSlot(ctx context.Context, booth string, startTime, endTime time.Time) (*models.SalesSlot, error) {
 	slot := &models.SalesSlot{
 		ID:        types.ID("test-id"),
+		Booth:     booth,
 		StartTime: startTime,
 		EndTime:   endTime,
 		IsActive:  false,
@@ -38,6 +44,58 @@ func (s *mockSalesSlotService) CreateSalesSlot(ctx context.Context, startTime, e
 	return slot, nil
 }
 
+func (s *mockSalesSlotService) GenerateSalesSlots(ctx context.Context, pattern services.SlotPattern, dryRun bool) ([]services.GeneratedSlot, error) {
+	day := time.Date(pattern.FirstDay.Year(), pattern.FirstDay.Month(), pattern.FirstDay.Day(), 0, 0, 0, 0, pattern.Location)
+	slot := models.SalesSlot{
+		ID:        types.ID("generated-id"),
+		Booth:     pattern.Booth,
+		StartTime: day.Add(pattern.OpensAt),
+		EndTime:   day.Add(pattern.OpensAt + pattern.SlotLength),
+	}
+	var added []types.ID
+	for _, p := range pattern.Products {
+		added = append(added, p.ProductID)
+	}
+	if !dryRun {
+		s.slots[slot.ID] = &slot
+	}
+	return []services.GeneratedSlot{{Slot: slot, Created: true, AddedProducts: added}}, nil
+}
+
+func (s *mockSalesSlotService) UpdateSalesSlot(ctx context.Context, id types.ID, booth string, startTime, endTime time.Time, force bool) (*models.SalesSlot, error) {
+	slot, exists := s.slots[id]
+	if !exists {
+		return nil, &services.ServiceError{Message: "Sales slot not found"}
+	}
+	if !endTime.After(startTime) {
+		return nil, services.ErrInvalidTimeRange
+	}
+	if s.ordered[id] && !force {
+		return nil, services.ErrSlotHasOrders
+	}
+	slot.Booth = booth
+	slot.StartTime = startTime
+	slot.EndTime = endTime
+	return slot, nil
+}
+
+func (s *mockSalesSlotService) DeleteSalesSlot(ctx context.Context, id types.ID, force bool) error {
+	switch id {
+	case "sold-id":
+		return services.ErrSlotHasSales
+	case "broken-id":
+		return &repositories.RepositoryError{Operation: "DeleteWithOrders", Err: errors.New("connection refused")}
+	}
+	if _, exists := s.slots[id]; !exists {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	if s.ordered[id] && !force {
+		return services.ErrSlotHasOrders
+	}
+	delete(s.slots, id)
+	return nil
+}
+
 func (s *mockSalesSlotService) GetSalesSlot(ctx context.Context, id types.ID) (*models.SalesSlot, error) {
 	if slot, exists := s.slots[id]; exists {
 		return slot, nil
@@ -66,6 +124,14 @@ func (s *mockSalesSlotService) FindByTimeRange(ctx context.Context, startTime, e
 
 func (s *mockSalesSlotService) ActivateSalesSlot(ctx context.Context, id types.ID) error {
 	if slot, exists := s.slots[id]; exists {
+		for _, other := range s.slots {
+			if other.ID != id && other.Booth == slot.Booth && other.IsActive {
+				return &services.SlotConflictError{
+					Message:   "同じブースで別の販売枠が受付中です",
+					Conflicts: []models.SalesSlot{*other},
+				}
+			}
+		}
 		slot.IsActive = true
 		return nil
 	}
@@ -80,21 +146,178 @@ func (s *mockSalesSlotService) DeactivateSalesSlot(ctx context.Context, id types
 	return &services.ServiceError{Message: "Sales slot not found"}
 }
 
-func (s *mockSalesSlotService) AddProductToSlot(ctx context.Context, slotID, productID types.ID, initialQuantity int) (*models.ProductInventory, error) {
+func (s *mockSalesSlotService) SetOverride(ctx context.Context, id types.ID, override types.SlotOverride) (*models.SalesSlot, error) {
+	slot, exists := s.slots[id]
+	if !exists {
+		return nil, &services.ServiceError{Message: "Sales slot not found"}
+	}
+	slot.Override = override
+	switch override {
+	case types.PINNED_OPEN:
+		slot.IsActive = true
+	case types.PINNED_CLOSED:
+		slot.IsActive = false
+	}
+	return slot, nil
+}
+
+func (s *mockSalesSlotService) GetTransitions(ctx context.Context, id types.ID) ([]models.SalesSlotTransition, error) {
+	if _, exists := s.slots[id]; !exists {
+		return nil, &services.ServiceError{Message: "Sales slot not found"}
+	}
+	return nil, nil
+}
+
+func (s *mockSalesSlotService) ApplySchedule(ctx context.Context) error {
+	return nil
+}
+
+func (s *mockSalesSlotService) SetCapacity(ctx context.Context, id types.ID, maxOrders, maxItems *int) (*models.SalesSlot, error) {
+	if (maxOrders != nil && *maxOrders < 1) || (maxItems != nil && *maxItems < 1) {
+		return nil, services.ErrInvalidCapacity
+	}
+	if id == "broken-id" {
+		return nil, &repositories.RepositoryError{Operation: "Update", Err: errors.New("connection refused")}
+	}
+	slot, exists := s.slots[id]
+	if !exists {
+		return nil, repositories.NewErrNotFound("SalesSlot", id)
+	}
+	slot.MaxOrders = maxOrders
+	slot.MaxItems = maxItems
+	return slot, nil
+}
+
+func (s *mockSalesSlotService) AddProductToSlot(ctx context.Context, slotID, productID types.ID, initialQuantity int, price *int) (*models.ProductInventory, error) {
 	inventory := &models.ProductInventory{
 		ID:              types.ID("inv-test-id"),
 		SalesSlotID:     slotID,
 		ProductID:       productID,
 		InitialQuantity: initialQuantity,
+		Price:           price,
+		Product:         &models.Product{ID: productID, Price: 500},
 	}
 	s.inventories[inventory.ID] = inventory
 	return inventory, nil
 }
 
-func (s *mockSalesSlotService) UpdateInventory(ctx context.Context, slotID, productID types.ID, reserved, sold int) error {
+func (s *mockSalesSlotService) SetSlotPrice(ctx context.Context, slotID, productID types.ID, price *int) (*models.ProductInventory, error) {
+	if price != nil && *price < 0 {
+		return nil, services.ErrInvalidSlotPrice
+	}
+	if productID == "broken-product-id" {
+		return nil, &repositories.RepositoryError{Operation: "UpdatePrice", Err: errors.New("connection refused")}
+	}
+	for _, inv := range s.inventories {
+		if inv.SalesSlotID == slotID && inv.ProductID == productID {
+			inv.Price = price
+			return inv, nil
+		}
+	}
+	return nil, repositories.NewErrNotFound("ProductInventory", "")
+}
+
+func (s *mockSalesSlotService) SetPurchaseLimits(ctx context.Context, slotID, productID types.ID, maxPerOrder, maxPerCustomer *int) (*models.ProductInventory, error) {
+	if (maxPerOrder != nil && *maxPerOrder < 1) || (maxPerCustomer != nil && *maxPerCustomer < 1) {
+		return nil, services.ErrInvalidPurchaseLimit
+	}
+	for _, inv := range s.inventories {
+		if inv.SalesSlotID == slotID && inv.ProductID == productID {
+			inv.MaxPerOrder = maxPerOrder
+			inv.MaxPerCustomer = maxPerCustomer
+			return inv, nil
+		}
+	}
+	return nil, &services.ServiceError{Message: "Inventory not found"}
+}
+
+func (s *mockSalesSlotService) SetLowStockThreshold(ctx context.Context, slotID, productID types.ID, threshold *int) (*models.ProductInventory, error) {
+	if threshold != nil && *threshold < 0 {
+		return nil, services.ErrInvalidStockThreshold
+	}
+	inv := s.findInventory(slotID, productID)
+	if inv == nil {
+		return nil, &services.ServiceError{Message: "Inventory not found"}
+	}
+	inv.LowStockThreshold = threshold
+	return inv, nil
+}
+
+func (s *mockSalesSlotService) SetAvailability(ctx context.Context, slotID, productID types.ID, available bool) (*models.ProductInventory, error) {
+	inv := s.findInventory(slotID, productID)
+	if inv == nil {
+		return nil, &services.ServiceError{Message: "Inventory not found"}
+	}
+	inv.Unavailable = !available
+	return inv, nil
+}
+
+func (s *mockSalesSlotService) findInventory(slotID, productID types.ID) *models.ProductInventory {
+	for _, inv := range s.inventories {
+		if inv.SalesSlotID == slotID && inv.ProductID == productID {
+			return inv
+		}
+	}
 	return nil
 }
 
+func (s *mockSalesSlotService) GetStockLedger(ctx context.Context, slotID, productID types.ID) (*services.StockLedger, error) {
+	inv := s.findInventory(slotID, productID)
+	if inv == nil {
+		return nil, &services.ServiceError{Message: "Inventory not found"}
+	}
+	return &services.StockLedger{Inventory: *inv, Initial: inv.InitialQuantity, Reserved: inv.ReservedQuantity, Sold: inv.SoldQuantity}, nil
+}
+
+func (s *mockSalesSlotService) AdjustStock(ctx context.Context, slotID, productID types.ID, movementType types.StockMovementType, quantity int, reason string) (*services.StockLedger, error) {
+	inv := s.findInventory(slotID, productID)
+	if inv == nil {
+		return nil, &services.ServiceError{Message: "Inventory not found"}
+	}
+	if movementType == types.WASTE {
+		quantity = -quantity
+	}
+	if inv.GetAvailableQuantity()+quantity < 0 {
+		return nil, services.ErrInsufficientInventory
+	}
+	inv.InitialQuantity += quantity
+	ledger, _ := s.GetStockLedger(ctx, slotID, productID)
+	ledger.Movements = []models.StockMovement{{Type: movementType, Quantity: quantity, Reason: reason}}
+	return ledger, nil
+}
+
+func (s *mockSalesSlotService) ReconcileStock(ctx context.Context, slotID, productID types.ID) (*services.StockLedger, error) {
+	return s.GetStockLedger(ctx, slotID, productID)
+}
+
+func (s *mockSalesSlotService) TransferStock(ctx context.Context, fromSlotID, toSlotID, productID types.ID, quantity int, reason string) (*services.StockTransfer, error) {
+	if fromSlotID == toSlotID || quantity <= 0 || reason == "" {
+		return nil, services.ErrInvalidStockTransfer
+	}
+	from := s.findInventory(fromSlotID, productID)
+	if from == nil {
+		return nil, &services.ServiceError{Message: "Inventory not found"}
+	}
+	if from.GetAvailableQuantity() < quantity {
+		return nil, services.ErrInsufficientInventory
+	}
+	to := s.findInventory(toSlotID, productID)
+	if to == nil {
+		to = &models.ProductInventory{ID: "inv-" + toSlotID, SalesSlotID: toSlotID, ProductID: productID, Price: from.Price}
+		s.inventories[to.ID] = to
+	}
+	from.InitialQuantity -= quantity
+	to.InitialQuantity += quantity
+	return &services.StockTransfer{From: *from, To: *to, Quantity: quantity}, nil
+}
+
+func (s *mockSalesSlotService) RollOverStock(ctx context.Context, slotID types.ID) ([]services.StockTransfer, error) {
+	if _, exists := s.slots[slotID]; !exists {
+		return nil, repositories.NewErrNotFound("SalesSlot", slotID)
+	}
+	return nil, nil
+}
+
 func (s *mockSalesSlotService) GetSlotInventories(ctx context.Context, slotID types.ID) ([]models.ProductInventory, error) {
 	var inventories []models.ProductInventory
 	for _, inv := range s.inventories {
@@ -147,7 +370,7 @@ func TestSalesSlotHandler_Activate(t *testing.T) {
 	handler := NewSalesSlotHandler(mockService)
 
 	ctx := context.Background()
-	slot, _ := mockService.CreateSalesSlot(ctx, time.Now(), time.Now().Add(2*time.Hour))
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
 
 	app.Put("/sales-slots/:id/activate", handler.Activate)
 
@@ -176,12 +399,12 @@ func TestSalesSlotHandler_AddProduct(t *testing.T) {
 	handler := NewSalesSlotHandler(mockService)
 
 	ctx := context.Background()
-	slot, _ := mockService.CreateSalesSlot(ctx, time.Now(), time.Now().Add(2*time.Hour))
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
 
 	app.Post("/sales-slots/:id/products", handler.AddProduct)
 
 	reqBody := AddProductToSlotRequest{
-		ProductID:       "test-product-id",
+		ProductID:       "0f8a3c52-1e4b-4d7a-b6c9-8e2d5f7a1b34",
 		InitialQuantity: 100,
 	}
 	body, _ := json.Marshal(reqBody)
@@ -205,3 +428,515 @@ func TestSalesSlotHandler_AddProduct(t *testing.T) {
 		t.Errorf("Expected initial quantity 100, got %d", response.InitialQuantity)
 	}
 }
+
+func TestSalesSlotHandler_SetProductPrice(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
+	mockService.AddProductToSlot(ctx, slot.ID, types.ID("test-product-id"), 100, nil)
+
+	app.Put("/sales-slots/:id/products/:productId/price", handler.SetProductPrice)
+
+	path := "/sales-slots/" + url.PathEscape(string(slot.ID)) + "/products/test-product-id/price"
+
+	req := httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"price": 300}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response ProductInventoryResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.Price != 300 || response.PriceOverride == nil {
+		t.Errorf("Expected slot price 300, got %d", response.Price)
+	}
+
+	// Clearing the override falls back to the product price
+	req = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"price": null}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	response = ProductInventoryResponse{}
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.Price != 500 || response.PriceOverride != nil {
+		t.Errorf("Expected product price 500 without override, got %d", response.Price)
+	}
+
+	req = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"price": -1}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	for productID, expectedStatus := range map[string]int{
+		"missing-product-id": fiber.StatusNotFound,
+		"broken-product-id":  fiber.StatusInternalServerError,
+	} {
+		path := "/sales-slots/" + url.PathEscape(string(slot.ID)) + "/products/" + productID + "/price"
+		req = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"price": 300}`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ = app.Test(req)
+		if resp.StatusCode != expectedStatus {
+			t.Errorf("%s: expected status code %d, got %d", productID, expectedStatus, resp.StatusCode)
+		}
+	}
+}
+
+func TestSalesSlotHandler_SetPurchaseLimits(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
+	mockService.AddProductToSlot(ctx, slot.ID, types.ID("test-product-id"), 50, nil)
+
+	app.Put("/sales-slots/:id/products/:productId/limits", handler.SetPurchaseLimits)
+
+	path := "/sales-slots/" + url.PathEscape(string(slot.ID)) + "/products/test-product-id/limits"
+
+	req := httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"maxPerOrder": 3, "maxPerCustomer": 5}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response ProductInventoryResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.MaxPerOrder == nil || *response.MaxPerOrder != 3 || response.MaxPerCustomer == nil || *response.MaxPerCustomer != 5 {
+		t.Errorf("Expected limits 3 per order and 5 per customer, got %+v", response)
+	}
+
+	req = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"maxPerOrder": 0}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestSalesSlotHandler_StockAlerts(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
+	mockService.AddProductToSlot(ctx, slot.ID, types.ID("test-product-id"), 5, nil)
+
+	app.Put("/sales-slots/:id/products/:productId/low-stock-threshold", handler.SetLowStockThreshold)
+	app.Put("/sales-slots/:id/products/:productId/availability", handler.SetAvailability)
+
+	path := "/sales-slots/" + url.PathEscape(string(slot.ID)) + "/products/test-product-id/"
+
+	req := httptest.NewRequest("PUT", path+"low-stock-threshold", bytes.NewReader([]byte(`{"threshold": 5}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	var response ProductInventoryResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if resp.StatusCode != fiber.StatusOK || !response.LowStock || response.SoldOut || response.AvailableQuantity != 5 {
+		t.Errorf("Expected low stock of 5, got %d %+v", resp.StatusCode, response)
+	}
+
+	req = httptest.NewRequest("PUT", path+"low-stock-threshold", bytes.NewReader([]byte(`{"threshold": -1}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	req = httptest.NewRequest("PUT", path+"availability", bytes.NewReader([]byte(`{"available": false}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	response = ProductInventoryResponse{}
+	json.NewDecoder(resp.Body).Decode(&response)
+	if resp.StatusCode != fiber.StatusOK || !response.Unavailable {
+		t.Errorf("Expected the product to be unavailable, got %d %+v", resp.StatusCode, response)
+	}
+
+	req = httptest.NewRequest("PUT", path+"availability", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestSalesSlotHandler_SetCapacity(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
+	slot.OrderCount = 27
+
+	app.Put("/sales-slots/:id/capacity", handler.SetCapacity)
+
+	path := "/sales-slots/" + url.PathEscape(string(slot.ID)) + "/capacity"
+
+	req := httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"maxOrders": 30}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response SalesSlotResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.RemainingOrders == nil || *response.RemainingOrders != 3 || !response.AlmostFull {
+		t.Errorf("Expected 3 remaining orders and almost full, got %+v", response)
+	}
+
+	req = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"maxItems": 0}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	for id, expectedStatus := range map[string]int{
+		"missing-id": fiber.StatusNotFound,
+		"broken-id":  fiber.StatusInternalServerError,
+	} {
+		req = httptest.NewRequest("PUT", "/sales-slots/"+id+"/capacity", bytes.NewReader([]byte(`{"maxOrders": 30}`)))
+		req.Header.Set("Content-Type", "application/json")
+		resp, _ = app.Test(req)
+		if resp.StatusCode != expectedStatus {
+			t.Errorf("%s: expected status code %d, got %d", id, expectedStatus, resp.StatusCode)
+		}
+	}
+}
+
+func TestSalesSlotHandler_SetOverride(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
+
+	app.Put("/sales-slots/:id/override", handler.SetOverride)
+
+	path := "/sales-slots/" + url.PathEscape(string(slot.ID)) + "/override"
+
+	req := httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"override": "PINNED_OPEN"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusOK, resp.StatusCode)
+	}
+
+	var response SalesSlotResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.Override != "PINNED_OPEN" || !response.IsActive {
+		t.Errorf("Expected the slot pinned open, got %+v", response)
+	}
+
+	req = httptest.NewRequest("PUT", path, bytes.NewReader([]byte(`{"override": "OPEN"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, _ = app.Test(req)
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestSalesSlotHandler_Activate_Conflict(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	now := time.Now()
+	mockService.slots["open"] = &models.SalesSlot{ID: "open", Booth: "A", StartTime: now, EndTime: now.Add(time.Hour), IsActive: true}
+	mockService.slots["next"] = &models.SalesSlot{ID: "next", Booth: "A", StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour)}
+
+	app.Put("/sales-slots/:id/activate", handler.Activate)
+
+	req := httptest.NewRequest("PUT", "/sales-slots/next/activate", nil)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusConflict, resp.StatusCode)
+	}
+
+	var response SlotConflictResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if response.Message == "" || len(response.Conflicts) != 1 || response.Conflicts[0].ID != "open" {
+		t.Errorf("Expected the open slot as conflict, got %+v", response)
+	}
+	if mockService.slots["next"].IsActive {
+		t.Error("Expected the slot to stay closed")
+	}
+}
+
+func TestSalesSlotHandler_Generate(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	app.Post("/sales-slots/generate", handler.Generate)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		created        bool
+	}{
+		{
+			name:           "preview",
+			body:           `{"booth": "A", "firstDay": "2026-11-03", "lastDay": "2026-11-04", "opensAt": "10:00", "closesAt": "16:00", "slotMinutes": 30, "dryRun": true}`,
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "generate",
+			body:           `{"booth": "A", "firstDay": "2026-11-03", "lastDay": "2026-11-04", "opensAt": "10:00", "closesAt": "16:00", "slotMinutes": 30, "breaks": [{"start": "12:00", "end": "13:00"}], "products": [{"productId": "0f8a3c52-1e4b-4d7a-b6c9-8e2d5f7a1b34", "initialQuantity": 20}]}`,
+			expectedStatus: fiber.StatusCreated,
+			created:        true,
+		},
+		{
+			name:           "invalid times",
+			body:           `{"firstDay": "11/03", "lastDay": "2026-11-04", "opensAt": "10時", "closesAt": "16:00", "slotMinutes": 30}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "unknown time zone",
+			body:           `{"firstDay": "2026-11-03", "lastDay": "2026-11-04", "opensAt": "10:00", "closesAt": "16:00", "slotMinutes": 30, "timeZone": "Mars/Olympus"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/sales-slots/generate", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedStatus >= 400 {
+				return
+			}
+
+			var response []GeneratedSlotResponse
+			json.NewDecoder(resp.Body).Decode(&response)
+			if len(response) != 1 || response[0].Slot.Booth != "A" {
+				t.Fatalf("Expected one generated slot, got %+v", response)
+			}
+			if start := response[0].Slot.StartTime.UTC(); start.Hour() != 1 || start.Day() != 3 {
+				t.Errorf("Expected the slot to start at 10:00 JST, got %s", start)
+			}
+			if _, exists := mockService.slots["generated-id"]; exists != tt.created {
+				t.Errorf("Expected slot stored=%v", tt.created)
+			}
+		})
+	}
+}
+
+func TestSalesSlotHandler_Update(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
+	mockService.ordered[slot.ID] = true
+
+	app.Put("/sales-slots/:id", handler.Update)
+
+	tests := []struct {
+		name           string
+		query          string
+		body           string
+		expectedStatus int
+	}{
+		{
+			name:           "slot with orders",
+			body:           `{"booth": "B", "startTime": "2026-11-03T10:00:00+09:00", "endTime": "2026-11-03T10:30:00+09:00"}`,
+			expectedStatus: fiber.StatusConflict,
+		},
+		{
+			name:           "end before start",
+			query:          "?force=true",
+			body:           `{"startTime": "2026-11-03T10:30:00+09:00", "endTime": "2026-11-03T10:00:00+09:00"}`,
+			expectedStatus: fiber.StatusBadRequest,
+		},
+		{
+			name:           "forced",
+			query:          "?force=true",
+			body:           `{"booth": "B", "startTime": "2026-11-03T10:00:00+09:00", "endTime": "2026-11-03T10:30:00+09:00"}`,
+			expectedStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/sales-slots/"+url.PathEscape(string(slot.ID))+tt.query, bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+
+	if slot.Booth != "B" || slot.EndTime.Sub(slot.StartTime) != 30*time.Minute {
+		t.Errorf("Expected the slot to be moved, got %+v", slot)
+	}
+}
+
+func TestSalesSlotHandler_Delete(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	slot, _ := mockService.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
+	mockService.ordered[slot.ID] = true
+
+	app.Delete("/sales-slots/:id", handler.Delete)
+
+	path := "/sales-slots/" + url.PathEscape(string(slot.ID))
+	resp, _ := app.Test(httptest.NewRequest("DELETE", path, nil))
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusConflict, resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("DELETE", path+"?force=true", nil))
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusNoContent, resp.StatusCode)
+	}
+	if _, exists := mockService.slots[slot.ID]; exists {
+		t.Error("Expected the slot to be deleted")
+	}
+
+	for id, expectedStatus := range map[string]int{
+		string(slot.ID): fiber.StatusNotFound,
+		"sold-id":       fiber.StatusConflict,
+		"broken-id":     fiber.StatusInternalServerError,
+	} {
+		resp, _ = app.Test(httptest.NewRequest("DELETE", "/sales-slots/"+url.PathEscape(id)+"?force=true", nil))
+		if resp.StatusCode != expectedStatus {
+			t.Errorf("%s: expected status code %d, got %d", id, expectedStatus, resp.StatusCode)
+		}
+	}
+}
+
+func TestSalesSlotHandler_AdjustStock(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	mockService.AddProductToSlot(ctx, "slot-id", "product-id", 10, nil)
+
+	app.Post("/sales-slots/:id/products/:productId/stock/adjustments", handler.AdjustStock)
+	app.Get("/sales-slots/:id/products/:productId/stock", handler.GetStock)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedStock  int
+	}{
+		{name: "restock", body: `{"type": "RESTOCK", "quantity": 5, "reason": "追加仕入れ"}`, expectedStatus: fiber.StatusOK, expectedStock: 15},
+		{name: "waste", body: `{"type": "waste", "quantity": 3, "reason": "焦げ"}`, expectedStatus: fiber.StatusOK, expectedStock: 12},
+		{name: "missing reason", body: `{"type": "WASTE", "quantity": 3}`, expectedStatus: fiber.StatusBadRequest, expectedStock: 12},
+		{name: "sale", body: `{"type": "SALE", "quantity": 1, "reason": "手売り"}`, expectedStatus: fiber.StatusBadRequest, expectedStock: 12},
+		{name: "below zero", body: `{"type": "CORRECTION", "quantity": -13, "reason": "棚卸し"}`, expectedStatus: fiber.StatusBadRequest, expectedStock: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/sales-slots/slot-id/products/product-id/stock/adjustments", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+
+			resp, _ = app.Test(httptest.NewRequest("GET", "/sales-slots/slot-id/products/product-id/stock", nil))
+			var response StockLedgerResponse
+			json.NewDecoder(resp.Body).Decode(&response)
+			if response.Inventory.InitialQuantity != tt.expectedStock || !response.Consistent {
+				t.Errorf("Expected stock %d, got %+v", tt.expectedStock, response)
+			}
+		})
+	}
+}
+
+func TestSalesSlotHandler_TransferStock(t *testing.T) {
+	app := fiber.New()
+	mockService := newMockSalesSlotService()
+	handler := NewSalesSlotHandler(mockService)
+
+	ctx := context.Background()
+	mockService.AddProductToSlot(ctx, "slot-id", "product-id", 10, nil)
+	mockService.findInventory("slot-id", "product-id").ReservedQuantity = 4
+
+	app.Post("/sales-slots/:id/products/:productId/stock/transfers", handler.TransferStock)
+
+	toSlotID := "123e4567-e89b-12d3-a456-426614174000"
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedStock  int
+	}{
+		{name: "transfer", body: `{"toSalesSlotId": "` + toSlotID + `", "quantity": 4, "reason": "売れ残り"}`, expectedStatus: fiber.StatusOK, expectedStock: 6},
+		{name: "reserved stock", body: `{"toSalesSlotId": "` + toSlotID + `", "quantity": 3, "reason": "売れ残り"}`, expectedStatus: fiber.StatusBadRequest, expectedStock: 6},
+		{name: "missing reason", body: `{"toSalesSlotId": "` + toSlotID + `", "quantity": 1}`, expectedStatus: fiber.StatusBadRequest, expectedStock: 6},
+		{name: "invalid slot ID", body: `{"toSalesSlotId": "slot-2", "quantity": 1, "reason": "売れ残り"}`, expectedStatus: fiber.StatusBadRequest, expectedStock: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/sales-slots/slot-id/products/product-id/stock/transfers", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if stock := mockService.findInventory("slot-id", "product-id").InitialQuantity; stock != tt.expectedStock {
+				t.Errorf("Expected stock %d, got %d", tt.expectedStock, stock)
+			}
+		})
+	}
+
+	if to := mockService.findInventory(types.ID(toSlotID), "product-id"); to == nil || to.InitialQuantity != 4 {
+		t.Errorf("Expected 4 to be moved to the other slot, got %+v", to)
+	}
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"math"
+	"sort"
 	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 )
 
@@ -12,30 +16,94 @@ type ErrorResponse struct {
 }
 
 type CreateProductRequest struct {
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	Name        string                   `json:"name" validate:"required,max=100"`
+	Price       int                      `json:"price" validate:"min=0"`
+	CategoryID  *string                  `json:"categoryId,omitempty" validate:"uuid"`
+	Description string                   `json:"description" validate:"max=1000"`
+	Allergens   []string                 `json:"allergens"`
+	DietaryTags []string                 `json:"dietaryTags"`
+	TaxRate     string                   `json:"taxRate,omitempty" example:"REDUCED" validate:"oneof=STANDARD REDUCED"`
+	Components  []BundleComponentRequest `json:"components,omitempty" validate:"dive"`
 }
 
 type UpdateProductRequest struct {
-	Name  string `json:"name"`
-	Price int    `json:"price"`
+	Name        string                   `json:"name" validate:"required,max=100"`
+	Price       int                      `json:"price" validate:"min=0"`
+	CategoryID  *string                  `json:"categoryId,omitempty" validate:"uuid"`
+	Description string                   `json:"description" validate:"max=1000"`
+	Allergens   []string                 `json:"allergens"`
+	DietaryTags []string                 `json:"dietaryTags"`
+	TaxRate     string                   `json:"taxRate,omitempty" example:"REDUCED" validate:"oneof=STANDARD REDUCED"`
+	Components  []BundleComponentRequest `json:"components,omitempty" validate:"dive"`
+}
+
+type BundleComponentRequest struct {
+	ProductID string `json:"productId" validate:"required,uuid"`
+	Quantity  int    `json:"quantity" validate:"min=1"`
+}
+
+type BundleComponentResponse struct {
+	ProductID string `json:"productId"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
 }
 
 type ProductResponse struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Price     int       `json:"price"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID          string                    `json:"id"`
+	CategoryID  *string                   `json:"categoryId"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Price       int                       `json:"price"`
+	ImageURL    *string                   `json:"imageUrl"`
+	Allergens   []string                  `json:"allergens"`
+	DietaryTags []string                  `json:"dietaryTags"`
+	IsBundle    bool                      `json:"isBundle"`
+	TaxRate     string                    `json:"taxRate"`
+	TaxPercent  int                       `json:"taxPercent"`
+	Components  []BundleComponentResponse `json:"components"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+	UpdatedAt   time.Time                 `json:"updatedAt"`
 }
 
 func NewProductResponse(p *models.Product) ProductResponse {
+	var categoryID *string
+	if p.CategoryID != nil {
+		id := string(*p.CategoryID)
+		categoryID = &id
+	}
+
+	var imageURL *string
+	if p.ImagePath != "" {
+		u := "/api/v1/products/" + string(p.ID) + "/image"
+		imageURL = &u
+	}
+
+	components := make([]BundleComponentResponse, len(p.Components))
+	for i, c := range p.Components {
+		components[i] = BundleComponentResponse{
+			ProductID: string(c.ProductID),
+			Quantity:  c.Quantity,
+		}
+		if c.Product != nil {
+			components[i].Name = c.Product.Name
+		}
+	}
+
 	return ProductResponse{
-		ID:        string(p.ID),
-		Name:      p.Name,
-		Price:     p.Price,
-		CreatedAt: p.CreatedAt,
-		UpdatedAt: p.UpdatedAt,
+		ID:          string(p.ID),
+		CategoryID:  categoryID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		ImageURL:    imageURL,
+		Allergens:   p.Allergens.Strings(),
+		DietaryTags: p.DietaryTags.Strings(),
+		IsBundle:    p.IsBundle,
+		TaxRate:     p.TaxRate.String(),
+		TaxPercent:  p.TaxRate.Percent(),
+		Components:  components,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
 	}
 }
 
@@ -47,33 +115,407 @@ func NewProductResponseList(products []models.Product) []ProductResponse {
 	return result
 }
 
-type CreateSalesSlotRequest struct {
-	StartTime string `json:"startTime"`
-	EndTime   string `json:"endTime"`
+type SchedulePriceChangeRequest struct {
+	Price         int    `json:"price" validate:"min=0"`
+	EffectiveFrom string `json:"effectiveFrom" validate:"required,rfc3339"`
 }
 
-type UpdateSalesSlotRequest struct {
-	StartTime string `json:"startTime"`
-	EndTime   string `json:"endTime"`
+type ProductPriceResponse struct {
+	ID            string    `json:"id"`
+	ProductID     string    `json:"productId"`
+	Price         int       `json:"price"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+	IsScheduled   bool      `json:"isScheduled"`
+	CreatedAt     time.Time `json:"createdAt"`
 }
 
-type SalesSlotResponse struct {
+func NewProductPriceResponse(p *models.ProductPrice, now time.Time) ProductPriceResponse {
+	return ProductPriceResponse{
+		ID:            string(p.ID),
+		ProductID:     string(p.ProductID),
+		Price:         p.Price,
+		EffectiveFrom: p.EffectiveFrom,
+		IsScheduled:   p.EffectiveFrom.After(now),
+		CreatedAt:     p.CreatedAt,
+	}
+}
+
+func NewProductPriceResponseList(prices []models.ProductPrice, now time.Time) []ProductPriceResponse {
+	result := make([]ProductPriceResponse, len(prices))
+	for i, p := range prices {
+		result[i] = NewProductPriceResponse(&p, now)
+	}
+	return result
+}
+
+type CreateCategoryRequest struct {
+	Name         string `json:"name" validate:"required,max=50"`
+	DisplayOrder int    `json:"displayOrder" validate:"min=0"`
+	Station      string `json:"station,omitempty" validate:"max=30"`
+}
+
+type UpdateCategoryRequest struct {
+	Name         string `json:"name" validate:"required,max=50"`
+	DisplayOrder int    `json:"displayOrder" validate:"min=0"`
+	Station      string `json:"station,omitempty" validate:"max=30"`
+}
+
+type CategoryResponse struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	DisplayOrder int       `json:"displayOrder"`
+	Station      string    `json:"station,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func NewCategoryResponse(c *models.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:           string(c.ID),
+		Name:         c.Name,
+		DisplayOrder: c.DisplayOrder,
+		Station:      c.Station,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
+
+func NewCategoryResponseList(categories []models.Category) []CategoryResponse {
+	result := make([]CategoryResponse, len(categories))
+	for i, c := range categories {
+		result[i] = NewCategoryResponse(&c)
+	}
+	return result
+}
+
+// IngredientRequest creates or updates an ingredient. Unit is what its
+// amounts are counted in, e.g. g, 個 or 枚.
+type IngredientRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Unit string `json:"unit" validate:"required,max=20"`
+}
+
+type IngredientResponse struct {
 	ID        string    `json:"id"`
-	StartTime time.Time `json:"startTime"`
-	EndTime   time.Time `json:"endTime"`
-	IsActive  bool      `json:"isActive"`
+	Name      string    `json:"name"`
+	Unit      string    `json:"unit"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+func NewIngredientResponse(i *models.Ingredient) IngredientResponse {
+	return IngredientResponse{
+		ID:        string(i.ID),
+		Name:      i.Name,
+		Unit:      i.Unit,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
+
+func NewIngredientResponseList(ingredients []models.Ingredient) []IngredientResponse {
+	result := make([]IngredientResponse, len(ingredients))
+	for i, ingredient := range ingredients {
+		result[i] = NewIngredientResponse(&ingredient)
+	}
+	return result
+}
+
+// SetRecipeRequest replaces a product's recipe. An empty list removes it.
+type SetRecipeRequest struct {
+	Items []RecipeItemRequest `json:"items" validate:"dive"`
+}
+
+// RecipeItemRequest is the amount of an ingredient, in its unit, one of the
+// product takes. It must be more than zero.
+type RecipeItemRequest struct {
+	IngredientID string  `json:"ingredientId" validate:"required,uuid"`
+	Quantity     float64 `json:"quantity"`
+}
+
+type RecipeItemResponse struct {
+	IngredientID string  `json:"ingredientId"`
+	Name         string  `json:"name"`
+	Unit         string  `json:"unit"`
+	Quantity     float64 `json:"quantity"`
+}
+
+func NewRecipeResponse(items []models.RecipeItem) []RecipeItemResponse {
+	result := make([]RecipeItemResponse, len(items))
+	for i, item := range items {
+		result[i] = RecipeItemResponse{IngredientID: string(item.IngredientID), Quantity: item.Quantity}
+		if item.Ingredient != nil {
+			result[i].Name = item.Ingredient.Name
+			result[i].Unit = item.Ingredient.Unit
+		}
+	}
+	return result
+}
+
+// SetIngredientStockRequest sets the amount of an ingredient a booth has on
+// hand, which cannot be negative.
+type SetIngredientStockRequest struct {
+	Booth    string  `json:"booth" validate:"max=50"`
+	Quantity float64 `json:"quantity"`
+}
+
+// IngredientStockResponse is the amount of an ingredient a booth has on
+// hand. It is negative when more was used than was counted in.
+type IngredientStockResponse struct {
+	IngredientID string    `json:"ingredientId"`
+	Name         string    `json:"name"`
+	Unit         string    `json:"unit"`
+	Booth        string    `json:"booth"`
+	Quantity     float64   `json:"quantity"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func NewIngredientStockResponseList(stock []models.IngredientStock) []IngredientStockResponse {
+	result := make([]IngredientStockResponse, len(stock))
+	for i, s := range stock {
+		result[i] = IngredientStockResponse{
+			IngredientID: string(s.IngredientID),
+			Booth:        s.Booth,
+			Quantity:     s.Quantity,
+			UpdatedAt:    s.UpdatedAt,
+		}
+		if s.Ingredient != nil {
+			result[i].Name = s.Ingredient.Name
+			result[i].Unit = s.Ingredient.Unit
+		}
+	}
+	return result
+}
+
+type OptionGroupRequest struct {
+	Name          string          `json:"name" validate:"required,max=50"`
+	SelectionType string          `json:"selectionType" enums:"SINGLE,MULTIPLE" validate:"required,oneof=SINGLE MULTIPLE"`
+	Required      bool            `json:"required"`
+	MinSelect     int             `json:"minSelect" validate:"min=0"`
+	MaxSelect     int             `json:"maxSelect" validate:"min=0"`
+	DisplayOrder  int             `json:"displayOrder" validate:"min=0"`
+	Options       []OptionRequest `json:"options" validate:"required,dive"`
+}
+
+type OptionRequest struct {
+	ID           *string `json:"id,omitempty" validate:"uuid"`
+	Name         string  `json:"name" validate:"required,max=50"`
+	PriceDelta   int     `json:"priceDelta"`
+	DisplayOrder int     `json:"displayOrder" validate:"min=0"`
+}
+
+type OptionGroupResponse struct {
+	ID            string           `json:"id"`
+	ProductID     string           `json:"productId"`
+	Name          string           `json:"name"`
+	SelectionType string           `json:"selectionType"`
+	Required      bool             `json:"required"`
+	MinSelect     int              `json:"minSelect"`
+	MaxSelect     int              `json:"maxSelect"`
+	DisplayOrder  int              `json:"displayOrder"`
+	Options       []OptionResponse `json:"options"`
+}
+
+type OptionResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	PriceDelta   int    `json:"priceDelta"`
+	DisplayOrder int    `json:"displayOrder"`
+}
+
+func NewOptionGroupResponse(g *models.ProductOptionGroup) OptionGroupResponse {
+	options := make([]OptionResponse, len(g.Options))
+	for i, opt := range g.Options {
+		options[i] = OptionResponse{
+			ID:           string(opt.ID),
+			Name:         opt.Name,
+			PriceDelta:   opt.PriceDelta,
+			DisplayOrder: opt.DisplayOrder,
+		}
+	}
+
+	return OptionGroupResponse{
+		ID:            string(g.ID),
+		ProductID:     string(g.ProductID),
+		Name:          g.Name,
+		SelectionType: g.SelectionType.String(),
+		Required:      g.Required,
+		MinSelect:     g.MinSelect,
+		MaxSelect:     g.MaxSelect,
+		DisplayOrder:  g.DisplayOrder,
+		Options:       options,
+	}
+}
+
+func NewOptionGroupResponseList(groups []models.ProductOptionGroup) []OptionGroupResponse {
+	result := make([]OptionGroupResponse, len(groups))
+	for i, g := range groups {
+		result[i] = NewOptionGroupResponse(&g)
+	}
+	return result
+}
+
+type PromotionRequest struct {
+	Name         string  `json:"name" validate:"required,max=100"`
+	Type         string  `json:"type" enums:"PERCENTAGE,FIXED_AMOUNT,BUY_X_GET_Y" validate:"required,oneof=PERCENTAGE FIXED_AMOUNT BUY_X_GET_Y"`
+	Value        int     `json:"value" validate:"min=0"`
+	BuyQuantity  int     `json:"buyQuantity" validate:"min=0"`
+	FreeQuantity int     `json:"freeQuantity" validate:"min=0"`
+	ProductID    *string `json:"productId,omitempty" validate:"uuid"`
+	SalesSlotID  *string `json:"salesSlotId,omitempty" validate:"uuid"`
+	Code         *string `json:"code,omitempty" validate:"max=32"`
+	MinAmount    int     `json:"minAmount" validate:"min=0"`
+	UsageLimit   *int    `json:"usageLimit,omitempty" validate:"min=1"`
+	StartsAt     *string `json:"startsAt,omitempty" validate:"rfc3339"`
+	EndsAt       *string `json:"endsAt,omitempty" validate:"rfc3339"`
+	IsActive     *bool   `json:"isActive,omitempty" default:"true"`
+}
+
+type PromotionResponse struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Type         string     `json:"type"`
+	Value        int        `json:"value"`
+	BuyQuantity  int        `json:"buyQuantity"`
+	FreeQuantity int        `json:"freeQuantity"`
+	ProductID    *string    `json:"productId"`
+	SalesSlotID  *string    `json:"salesSlotId"`
+	Code         *string    `json:"code"`
+	MinAmount    int        `json:"minAmount"`
+	UsageLimit   *int       `json:"usageLimit"`
+	StartsAt     *time.Time `json:"startsAt"`
+	EndsAt       *time.Time `json:"endsAt"`
+	IsActive     bool       `json:"isActive"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+func NewPromotionResponse(p *models.Promotion) PromotionResponse {
+	return PromotionResponse{
+		ID:           string(p.ID),
+		Name:         p.Name,
+		Type:         p.Type.String(),
+		Value:        p.Value,
+		BuyQuantity:  p.BuyQuantity,
+		FreeQuantity: p.FreeQuantity,
+		ProductID:    (*string)(p.ProductID),
+		SalesSlotID:  (*string)(p.SalesSlotID),
+		Code:         p.Code,
+		MinAmount:    p.MinAmount,
+		UsageLimit:   p.UsageLimit,
+		StartsAt:     p.StartsAt,
+		EndsAt:       p.EndsAt,
+		IsActive:     p.IsActive,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}
+}
+
+func NewPromotionResponseList(promotions []models.Promotion) []PromotionResponse {
+	result := make([]PromotionResponse, len(promotions))
+	for i, p := range promotions {
+		result[i] = NewPromotionResponse(&p)
+	}
+	return result
+}
+
+type PromotionUsageResponse struct {
+	PromotionID    string `json:"promotionId"`
+	Name           string `json:"name"`
+	Uses           int    `json:"uses"`
+	DiscountAmount int    `json:"discountAmount"`
+}
+
+type CreateSalesSlotRequest struct {
+	Booth     string `json:"booth" validate:"max=50"`
+	StartTime string `json:"startTime" validate:"required,rfc3339"`
+	EndTime   string `json:"endTime" validate:"required,rfc3339"`
+}
+
+// GenerateSalesSlotsRequest describes slots of equal length repeated every
+// day. Times of day are in timeZone, Asia/Tokyo by default.
+type GenerateSalesSlotsRequest struct {
+	Booth       string                       `json:"booth" validate:"max=50"`
+	FirstDay    string                       `json:"firstDay" validate:"required,date"`
+	LastDay     string                       `json:"lastDay" validate:"required,date"`
+	OpensAt     string                       `json:"opensAt" validate:"required,clock"`
+	ClosesAt    string                       `json:"closesAt" validate:"required,clock"`
+	SlotMinutes int                          `json:"slotMinutes" validate:"required,min=1"`
+	Breaks      []SlotBreakRequest           `json:"breaks" validate:"dive"`
+	TimeZone    string                       `json:"timeZone"`
+	Products    []SlotTemplateProductRequest `json:"products" validate:"dive"`
+	// DryRun previews the slots without creating them.
+	DryRun bool `json:"dryRun"`
+}
+
+type SlotBreakRequest struct {
+	Start string `json:"start" validate:"required,clock"`
+	End   string `json:"end" validate:"required,clock"`
+}
+
+type SlotTemplateProductRequest struct {
+	ProductID       string `json:"productId" validate:"required,uuid"`
+	InitialQuantity int    `json:"initialQuantity" validate:"min=0"`
+	Price           *int   `json:"price" validate:"min=0"`
+}
+
+type UpdateSalesSlotRequest struct {
+	Booth     string `json:"booth" validate:"max=50"`
+	StartTime string `json:"startTime" validate:"required,rfc3339"`
+	EndTime   string `json:"endTime" validate:"required,rfc3339"`
+}
+
+// SetSlotCapacityRequest limits how many orders, and items across them, a
+// slot takes. A null limit removes it.
+type SetSlotCapacityRequest struct {
+	MaxOrders *int `json:"maxOrders" validate:"min=1"`
+	MaxItems  *int `json:"maxItems" validate:"min=1"`
+}
+
+type SetSlotOverrideRequest struct {
+	// Override pins the slot open or closed; AUTO lets the schedule open and
+	// close it.
+	Override string `json:"override" example:"PINNED_OPEN" validate:"required,oneof=AUTO PINNED_OPEN PINNED_CLOSED"`
+}
+
+type SalesSlotResponse struct {
+	ID         string    `json:"id"`
+	Booth      string    `json:"booth"`
+	StartTime  time.Time `json:"startTime"`
+	EndTime    time.Time `json:"endTime"`
+	IsActive   bool      `json:"isActive"`
+	Override   string    `json:"override"`
+	MaxOrders  *int      `json:"maxOrders,omitempty"`
+	MaxItems   *int      `json:"maxItems,omitempty"`
+	OrderCount int       `json:"orderCount"`
+	ItemCount  int       `json:"itemCount"`
+	// RemainingOrders and RemainingItems are only set for limited slots.
+	RemainingOrders *int `json:"remainingOrders,omitempty"`
+	RemainingItems  *int `json:"remainingItems,omitempty"`
+	// AlmostFull is set when a fifth or less of the capacity is left, for
+	// showing 残りわずか.
+	AlmostFull bool      `json:"almostFull"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
 func NewSalesSlotResponse(s *models.SalesSlot) SalesSlotResponse {
 	return SalesSlotResponse{
-		ID:        string(s.ID),
-		StartTime: s.StartTime,
-		EndTime:   s.EndTime,
-		IsActive:  s.IsActive,
-		CreatedAt: s.CreatedAt,
-		UpdatedAt: s.UpdatedAt,
+		ID:              string(s.ID),
+		Booth:           s.Booth,
+		StartTime:       s.StartTime,
+		EndTime:         s.EndTime,
+		IsActive:        s.IsActive,
+		Override:        s.Override.String(),
+		MaxOrders:       s.MaxOrders,
+		MaxItems:        s.MaxItems,
+		OrderCount:      s.OrderCount,
+		ItemCount:       s.ItemCount,
+		RemainingOrders: s.RemainingOrders(),
+		RemainingItems:  s.RemainingItems(),
+		AlmostFull:      s.IsAlmostFull(),
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
 	}
 }
 
@@ -85,78 +527,440 @@ func NewSalesSlotResponseList(slots []models.SalesSlot) []SalesSlotResponse {
 	return result
 }
 
+type GeneratedSlotResponse struct {
+	Slot          SalesSlotResponse   `json:"slot"`
+	Created       bool                `json:"created"`
+	AddedProducts []string            `json:"addedProducts"`
+	Conflicts     []SalesSlotResponse `json:"conflicts,omitempty"`
+}
+
+func NewGeneratedSlotResponseList(generated []services.GeneratedSlot) []GeneratedSlotResponse {
+	result := make([]GeneratedSlotResponse, len(generated))
+	for i, g := range generated {
+		added := make([]string, len(g.AddedProducts))
+		for j, id := range g.AddedProducts {
+			added[j] = string(id)
+		}
+		result[i] = GeneratedSlotResponse{
+			Slot:          NewSalesSlotResponse(&g.Slot),
+			Created:       g.Created,
+			AddedProducts: added,
+		}
+		if len(g.Conflicts) > 0 {
+			result[i].Conflicts = NewSalesSlotResponseList(g.Conflicts)
+		}
+	}
+	return result
+}
+
+// SlotConflictResponse lists the sales slots that keep a slot from being
+// created or opened.
+type SlotConflictResponse struct {
+	Message   string              `json:"message"`
+	Conflicts []SalesSlotResponse `json:"conflicts"`
+}
+
+type SalesSlotTransitionResponse struct {
+	ID          string    `json:"id"`
+	SalesSlotID string    `json:"salesSlotId"`
+	IsActive    bool      `json:"isActive"`
+	Cause       string    `json:"cause" enums:"SCHEDULED,MANUAL,OVERRIDE"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func NewSalesSlotTransitionResponseList(transitions []models.SalesSlotTransition) []SalesSlotTransitionResponse {
+	result := make([]SalesSlotTransitionResponse, len(transitions))
+	for i, t := range transitions {
+		result[i] = SalesSlotTransitionResponse{
+			ID:          string(t.ID),
+			SalesSlotID: string(t.SalesSlotID),
+			IsActive:    t.IsActive,
+			Cause:       t.Cause.String(),
+			CreatedAt:   t.CreatedAt,
+		}
+	}
+	return result
+}
+
 type AddProductToSlotRequest struct {
-	ProductID       string `json:"productId"`
-	InitialQuantity int    `json:"initialQuantity"`
+	ProductID       string `json:"productId" validate:"required,uuid"`
+	InitialQuantity int    `json:"initialQuantity" validate:"min=0"`
+	Price           *int   `json:"price,omitempty" validate:"min=0"`
+}
+
+type SetSlotPriceRequest struct {
+	// Price clears the slot's override when null.
+	Price *int `json:"price" validate:"min=0"`
+}
+
+// SetPurchaseLimitsRequest limits how many of a product one order and one
+// customer may take in the slot. A null limit removes it.
+type SetPurchaseLimitsRequest struct {
+	MaxPerOrder    *int `json:"maxPerOrder" validate:"min=1"`
+	MaxPerCustomer *int `json:"maxPerCustomer" validate:"min=1"`
+}
+
+// SetLowStockThresholdRequest sets the available quantity at which staff are
+// alerted. A null threshold removes it.
+type SetLowStockThresholdRequest struct {
+	Threshold *int `json:"threshold" validate:"min=0"`
+}
+
+// SetAvailabilityRequest stops or resumes orders of a product in the slot
+// while stock remains.
+type SetAvailabilityRequest struct {
+	Available *bool `json:"available" validate:"required"`
+}
+
+// AdjustStockRequest records a restock, waste or correction. Restocks and
+// waste take a positive quantity; corrections may be negative.
+type AdjustStockRequest struct {
+	Type     string `json:"type" validate:"required,oneof=RESTOCK WASTE CORRECTION"`
+	Quantity int    `json:"quantity" validate:"required"`
+	Reason   string `json:"reason" validate:"required,max=200"`
+}
+
+// TransferStockRequest moves available stock to the same product in another
+// sales slot.
+type TransferStockRequest struct {
+	ToSalesSlotID string `json:"toSalesSlotId" validate:"required,uuid"`
+	Quantity      int    `json:"quantity" validate:"required,min=1"`
+	Reason        string `json:"reason" validate:"required,max=200"`
+}
+
+// StockMovementResponse is a ledger entry. transferSlotId is the other slot
+// of a transfer.
+type StockMovementResponse struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	Quantity       int       `json:"quantity"`
+	Reason         string    `json:"reason"`
+	OrderID        *string   `json:"orderId,omitempty"`
+	TransferSlotID *string   `json:"transferSlotId,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// StockLedgerResponse is a product's stock in a slot with its ledger.
+// ledgerInitialQuantity, ledgerReservedQuantity and ledgerSoldQuantity are
+// the ledger's totals; consistent is false when they differ from the stock.
+type StockLedgerResponse struct {
+	Inventory              ProductInventoryResponse `json:"inventory"`
+	LedgerInitialQuantity  int                      `json:"ledgerInitialQuantity"`
+	LedgerReservedQuantity int                      `json:"ledgerReservedQuantity"`
+	LedgerSoldQuantity     int                      `json:"ledgerSoldQuantity"`
+	Consistent             bool                     `json:"consistent"`
+	Movements              []StockMovementResponse  `json:"movements"`
+}
+
+func NewStockLedgerResponse(l *services.StockLedger) StockLedgerResponse {
+	movements := make([]StockMovementResponse, len(l.Movements))
+	for i, m := range l.Movements {
+		movements[i] = StockMovementResponse{
+			ID:        string(m.ID),
+			Type:      m.Type.String(),
+			Quantity:  m.Quantity,
+			Reason:    m.Reason,
+			CreatedAt: m.CreatedAt,
+		}
+		if m.OrderID != nil {
+			orderID := string(*m.OrderID)
+			movements[i].OrderID = &orderID
+		}
+		if m.TransferSlotID != nil {
+			slotID := string(*m.TransferSlotID)
+			movements[i].TransferSlotID = &slotID
+		}
+	}
+	return StockLedgerResponse{
+		Inventory:              NewProductInventoryResponse(&l.Inventory),
+		LedgerInitialQuantity:  l.Initial,
+		LedgerReservedQuantity: l.Reserved,
+		LedgerSoldQuantity:     l.Sold,
+		Consistent:             l.Consistent(),
+		Movements:              movements,
+	}
+}
+
+// StockTransferResponse is stock moved between slots with both inventories
+// after the move.
+type StockTransferResponse struct {
+	From     ProductInventoryResponse `json:"from"`
+	To       ProductInventoryResponse `json:"to"`
+	Quantity int                      `json:"quantity"`
+}
+
+func NewStockTransferResponse(t *services.StockTransfer) StockTransferResponse {
+	return StockTransferResponse{
+		From:     NewProductInventoryResponse(&t.From),
+		To:       NewProductInventoryResponse(&t.To),
+		Quantity: t.Quantity,
+	}
 }
 
+// ProductInventoryResponse is a product's stock in a slot. availableQuantity
+// is the stock left to order; soldOut is true when none is left and
+// unavailable when orders are stopped while stock remains. makeableQuantity
+// is how many more the booth's ingredients make, for products with a recipe.
 type ProductInventoryResponse struct {
-	ID               string    `json:"id"`
-	SalesSlotID      string    `json:"salesSlotId"`
-	ProductID        string    `json:"productId"`
-	InitialQuantity  int       `json:"initialQuantity"`
-	ReservedQuantity int       `json:"reservedQuantity"`
-	SoldQuantity     int       `json:"soldQuantity"`
-	CreatedAt        time.Time `json:"createdAt"`
-	UpdatedAt        time.Time `json:"updatedAt"`
+	ID                string    `json:"id"`
+	SalesSlotID       string    `json:"salesSlotId"`
+	ProductID         string    `json:"productId"`
+	InitialQuantity   int       `json:"initialQuantity"`
+	ReservedQuantity  int       `json:"reservedQuantity"`
+	SoldQuantity      int       `json:"soldQuantity"`
+	Price             int       `json:"price"`
+	PriceOverride     *int      `json:"priceOverride,omitempty"`
+	MaxPerOrder       *int      `json:"maxPerOrder,omitempty"`
+	MaxPerCustomer    *int      `json:"maxPerCustomer,omitempty"`
+	AvailableQuantity int       `json:"availableQuantity"`
+	MakeableQuantity  *int      `json:"makeableQuantity,omitempty"`
+	LowStockThreshold *int      `json:"lowStockThreshold,omitempty"`
+	LowStock          bool      `json:"lowStock"`
+	SoldOut           bool      `json:"soldOut"`
+	Unavailable       bool      `json:"unavailable"`
+	CreatedAt         time.Time `json:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt"`
+}
+
+// NewProductInventoryResponse reports the price customers pay in the slot,
+// which is the slot's override when one is set.
+func NewProductInventoryResponse(pi *models.ProductInventory) ProductInventoryResponse {
+	basePrice := 0
+	if pi.Product != nil {
+		basePrice = pi.Product.Price
+	}
+	return ProductInventoryResponse{
+		ID:                string(pi.ID),
+		SalesSlotID:       string(pi.SalesSlotID),
+		ProductID:         string(pi.ProductID),
+		InitialQuantity:   pi.InitialQuantity,
+		ReservedQuantity:  pi.ReservedQuantity,
+		SoldQuantity:      pi.SoldQuantity,
+		Price:             pi.GetPrice(basePrice),
+		PriceOverride:     pi.Price,
+		MaxPerOrder:       pi.MaxPerOrder,
+		MaxPerCustomer:    pi.MaxPerCustomer,
+		AvailableQuantity: pi.GetAvailableQuantity(),
+		MakeableQuantity:  pi.MakeableQuantity,
+		LowStockThreshold: pi.LowStockThreshold,
+		LowStock:          pi.IsLowStock(),
+		SoldOut:           pi.IsSoldOut(),
+		Unavailable:       pi.Unavailable,
+		CreatedAt:         pi.CreatedAt,
+		UpdatedAt:         pi.UpdatedAt,
+	}
+}
+
+func NewProductInventoryResponseList(inventories []models.ProductInventory) []ProductInventoryResponse {
+	result := make([]ProductInventoryResponse, len(inventories))
+	for i, pi := range inventories {
+		result[i] = NewProductInventoryResponse(&pi)
+	}
+	return result
 }
 
 type CreateOrderRequest struct {
-	SalesSlotID string                 `json:"salesSlotId"`
-	Items       []OrderItemCreateInput `json:"items"`
+	SalesSlotID string `json:"salesSlotId" validate:"required,uuid"`
+	// CustomerID identifies the customer for per-customer purchase limits,
+	// e.g. a phone number, a session token or the token of an earlier ticket.
+	CustomerID  string                 `json:"customerId,omitempty" validate:"max=200"`
+	Items       []OrderItemCreateInput `json:"items" validate:"required,dive"`
+	CouponCodes []string               `json:"couponCodes,omitempty" validate:"max=5"`
 }
 
 type OrderItemCreateInput struct {
-	ProductID string `json:"productId"`
-	Quantity  int    `json:"quantity"`
+	ProductID string   `json:"productId" validate:"required,uuid"`
+	Quantity  int      `json:"quantity" validate:"min=1"`
+	OptionIDs []string `json:"optionIds,omitempty" validate:"uuid"`
 }
 
+// OrderResponse is an order. PrepStartedAt is when the kitchen started the
+// first item and ReadyAt when the last became ready. EstimatedWaitMinutes,
+// how long the order is expected to take to be ready once confirmed, is only
+// returned when the order is created.
 type OrderResponse struct {
-	ID          string              `json:"id"`
-	SalesSlotID string              `json:"salesSlotId"`
-	Status      string              `json:"status"`
-	TotalAmount int                 `json:"totalAmount"`
-	Items       []OrderItemResponse `json:"items"`
-	CreatedAt   time.Time           `json:"createdAt"`
-	UpdatedAt   time.Time           `json:"updatedAt"`
+	ID                   string                  `json:"id"`
+	SalesSlotID          string                  `json:"salesSlotId"`
+	CustomerID           string                  `json:"customerId,omitempty"`
+	Status               string                  `json:"status"`
+	Subtotal             int                     `json:"subtotal"`
+	TotalAmount          int                     `json:"totalAmount"`
+	TaxMode              string                  `json:"taxMode"`
+	TaxAmount            int                     `json:"taxAmount"`
+	Items                []OrderItemResponse     `json:"items"`
+	Discounts            []OrderDiscountResponse `json:"discounts"`
+	Taxes                []OrderTaxResponse      `json:"taxes"`
+	ConfirmedAt          *time.Time              `json:"confirmedAt,omitempty"`
+	PrepStartedAt        *time.Time              `json:"prepStartedAt,omitempty"`
+	ReadyAt              *time.Time              `json:"readyAt,omitempty"`
+	EstimatedWaitMinutes *int                    `json:"estimatedWaitMinutes,omitempty"`
+	CreatedAt            time.Time               `json:"createdAt"`
+	UpdatedAt            time.Time               `json:"updatedAt"`
+}
+
+type UpdateOrderItemRequest struct {
+	Quantity int `json:"quantity" validate:"min=1"`
+}
+
+type OrderItemChangeResponse struct {
+	OrderItemID string    `json:"orderItemId"`
+	ProductID   string    `json:"productId"`
+	UnitPrice   int       `json:"unitPrice"`
+	OldQuantity int       `json:"oldQuantity"`
+	NewQuantity int       `json:"newQuantity"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type OrderTaxResponse struct {
+	Rate          string `json:"rate"`
+	Percent       int    `json:"percent"`
+	TaxableAmount int    `json:"taxableAmount"`
+	TaxAmount     int    `json:"taxAmount"`
+}
+
+type TaxSummaryResponse struct {
+	Rate        string `json:"rate"`
+	Percent     int    `json:"percent"`
+	Orders      int    `json:"orders"`
+	SalesAmount int    `json:"salesAmount"`
+	TaxAmount   int    `json:"taxAmount"`
+	NetAmount   int    `json:"netAmount"`
 }
 
 type OrderItemResponse struct {
-	ID        string `json:"id"`
-	ProductID string `json:"productId"`
-	Quantity  int    `json:"quantity"`
-	Price     int    `json:"price"`
+	ID            string                    `json:"id"`
+	ProductID     string                    `json:"productId"`
+	Quantity      int                       `json:"quantity"`
+	Price         int                       `json:"price"`
+	UnitPrice     int                       `json:"unitPrice"`
+	Subtotal      int                       `json:"subtotal"`
+	TaxRate       string                    `json:"taxRate"`
+	PrepStatus    string                    `json:"prepStatus"`
+	PrepStartedAt *time.Time                `json:"prepStartedAt,omitempty"`
+	ReadyAt       *time.Time                `json:"readyAt,omitempty"`
+	Options       []OrderItemOptionResponse `json:"options"`
+	Components    []BundleComponentResponse `json:"components"`
+}
+
+type OrderDiscountResponse struct {
+	PromotionID string  `json:"promotionId"`
+	Name        string  `json:"name"`
+	Code        *string `json:"code"`
+	Amount      int     `json:"amount"`
+}
+
+type OrderItemOptionResponse struct {
+	OptionID   string `json:"optionId"`
+	GroupName  string `json:"groupName"`
+	Name       string `json:"name"`
+	PriceDelta int    `json:"priceDelta"`
 }
 
 type CreateOrderTicketRequest struct {
-	OrderID       string              `json:"orderId"`
-	TicketNumber  string              `json:"ticketNumber"`
-	PaymentMethod types.PaymentMethod `json:"paymentMethod"`
+	OrderID       string              `json:"orderId" validate:"required,uuid"`
+	TicketNumber  string              `json:"ticketNumber" validate:"required,max=20"`
+	PaymentMethod types.PaymentMethod `json:"paymentMethod" validate:"min=1,max=3"`
 }
 
 type OrderTicketResponse struct {
-	ID            string    `json:"id"`
-	TicketNumber  string    `json:"ticketNumber"`
-	OrderID       string    `json:"orderId"`
-	PaymentMethod string    `json:"paymentMethod"`
-	TransactionID *string   `json:"transactionId"`
-	IsPaid        bool      `json:"isPaid"`
-	IsDelivered   bool      `json:"isDelivered"`
-	CreatedAt     time.Time `json:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt"`
+	ID             string     `json:"id"`
+	TicketNumber   string     `json:"ticketNumber"`
+	OrderID        string     `json:"orderId"`
+	Token          string     `json:"token"`
+	PaymentMethod  string     `json:"paymentMethod"`
+	TransactionID  *string    `json:"transactionId"`
+	TenderedAmount *int       `json:"tenderedAmount"`
+	ChangeAmount   *int       `json:"changeAmount"`
+	IsPaid         bool       `json:"isPaid"`
+	IsDelivered    bool       `json:"isDelivered"`
+	PaidAt         *time.Time `json:"paidAt,omitempty"`
+	DeliveredAt    *time.Time `json:"deliveredAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+func NewOrderTicketResponse(t *models.OrderTicket) OrderTicketResponse {
+	return OrderTicketResponse{
+		ID:             string(t.ID),
+		TicketNumber:   t.TicketNumber,
+		OrderID:        string(t.OrderID),
+		Token:          t.Token,
+		PaymentMethod:  t.PaymentMethod.String(),
+		TransactionID:  t.TransactionID,
+		TenderedAmount: t.TenderedAmount,
+		ChangeAmount:   t.ChangeAmount,
+		IsPaid:         t.IsPaid,
+		IsDelivered:    t.IsDelivered,
+		PaidAt:         t.PaidAt,
+		DeliveredAt:    t.DeliveredAt,
+		CreatedAt:      t.CreatedAt,
+		UpdatedAt:      t.UpdatedAt,
+	}
+}
+
+func NewOrderTicketResponseList(tickets []models.OrderTicket) []OrderTicketResponse {
+	result := make([]OrderTicketResponse, len(tickets))
+	for i := range tickets {
+		result[i] = NewOrderTicketResponse(&tickets[i])
+	}
+	return result
+}
+
+type VerifyTicketRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type VerifyTicketResponse struct {
+	Ticket OrderTicketResponse `json:"ticket"`
+	Order  OrderResponse       `json:"order"`
+}
+
+type PrintTicketRequest struct {
+	Kind    string `json:"kind" enums:"receipt,kitchen" validate:"required,oneof=receipt kitchen"`
+	Reprint bool   `json:"reprint"`
 }
 
 type UpdatePaymentStatusRequest struct {
-	IsPaid        bool    `json:"isPaid"`
-	TransactionID *string `json:"transactionId,omitempty"`
+	IsPaid         bool    `json:"isPaid"`
+	TransactionID  *string `json:"transactionId,omitempty" validate:"max=100"`
+	TenderedAmount *int    `json:"tenderedAmount,omitempty" validate:"min=0"`
 }
 
 func NewOrderItemResponse(item *models.OrderItem) OrderItemResponse {
+	options := make([]OrderItemOptionResponse, len(item.Options))
+	for i, opt := range item.Options {
+		options[i] = OrderItemOptionResponse{
+			OptionID:   string(opt.OptionID),
+			GroupName:  opt.GroupName,
+			Name:       opt.Name,
+			PriceDelta: opt.PriceDelta,
+		}
+	}
+
+	components := make([]BundleComponentResponse, len(item.Components))
+	for i, c := range item.Components {
+		components[i] = BundleComponentResponse{
+			ProductID: string(c.ProductID),
+			Quantity:  c.Quantity,
+		}
+		if c.Product != nil {
+			components[i].Name = c.Product.Name
+		}
+	}
+
 	return OrderItemResponse{
-		ID:        string(item.ID),
-		ProductID: string(item.ProductID),
-		Quantity:  item.Quantity,
-		Price:     item.Price,
+		ID:            string(item.ID),
+		ProductID:     string(item.ProductID),
+		Quantity:      item.Quantity,
+		Price:         item.Price,
+		UnitPrice:     item.GetUnitPrice(),
+		Subtotal:      item.GetSubtotal(),
+		TaxRate:       item.TaxRate.String(),
+		PrepStatus:    item.PrepStatus.String(),
+		PrepStartedAt: item.PrepStartedAt,
+		ReadyAt:       item.ReadyAt,
+		Options:       options,
+		Components:    components,
 	}
 }
 
@@ -166,15 +970,49 @@ func NewOrderResponse(o *models.Order) OrderResponse {
 		items[i] = NewOrderItemResponse(&item)
 	}
 
-	return OrderResponse{
-		ID:          string(o.ID),
-		SalesSlotID: string(o.SalesSlotID),
-		Status:      o.Status.String(),
-		TotalAmount: o.TotalAmount,
-		Items:       items,
-		CreatedAt:   o.CreatedAt,
-		UpdatedAt:   o.UpdatedAt,
+	discounts := make([]OrderDiscountResponse, len(o.Discounts))
+	for i, d := range o.Discounts {
+		discounts[i] = OrderDiscountResponse{
+			PromotionID: string(d.PromotionID),
+			Name:        d.Name,
+			Code:        d.Code,
+			Amount:      d.Amount,
+		}
+	}
+
+	taxes := make([]OrderTaxResponse, len(o.Taxes))
+	for i, t := range o.Taxes {
+		taxes[i] = OrderTaxResponse{
+			Rate:          t.Rate.String(),
+			Percent:       t.Rate.Percent(),
+			TaxableAmount: t.TaxableAmount,
+			TaxAmount:     t.TaxAmount,
+		}
 	}
+
+	response := OrderResponse{
+		ID:            string(o.ID),
+		SalesSlotID:   string(o.SalesSlotID),
+		CustomerID:    o.CustomerID,
+		Status:        o.Status.String(),
+		Subtotal:      o.GetSubtotal(),
+		TotalAmount:   o.TotalAmount,
+		TaxMode:       o.TaxMode.String(),
+		TaxAmount:     o.GetTaxAmount(),
+		Items:         items,
+		Discounts:     discounts,
+		Taxes:         taxes,
+		ConfirmedAt:   o.ConfirmedAt,
+		PrepStartedAt: o.PrepStartedAt(),
+		ReadyAt:       o.ReadyAt(),
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+	}
+	if o.EstimatedWait != nil {
+		minutes := waitMinutes(*o.EstimatedWait)
+		response.EstimatedWaitMinutes = &minutes
+	}
+	return response
 }
 
 func NewOrderResponseList(orders []models.Order) []OrderResponse {
@@ -184,3 +1022,142 @@ func NewOrderResponseList(orders []models.Order) []OrderResponse {
 	}
 	return result
 }
+
+// KitchenOrderResponse is a confirmed order on the kitchen queue with the
+// items routed to the requested station.
+type KitchenOrderResponse struct {
+	OrderID      string                `json:"orderId"`
+	SalesSlotID  string                `json:"salesSlotId"`
+	TicketNumber string                `json:"ticketNumber,omitempty"`
+	ConfirmedAt  *time.Time            `json:"confirmedAt"`
+	Items        []KitchenItemResponse `json:"items"`
+}
+
+type KitchenItemResponse struct {
+	ID            string                    `json:"id"`
+	ProductID     string                    `json:"productId"`
+	ProductName   string                    `json:"productName"`
+	Station       string                    `json:"station,omitempty"`
+	Quantity      int                       `json:"quantity"`
+	PrepStatus    string                    `json:"prepStatus"`
+	PrepStartedAt *time.Time                `json:"prepStartedAt,omitempty"`
+	Options       []OrderItemOptionResponse `json:"options"`
+	Components    []BundleComponentResponse `json:"components"`
+}
+
+func NewKitchenItemResponse(item *models.OrderItem) KitchenItemResponse {
+	details := NewOrderItemResponse(item)
+	response := KitchenItemResponse{
+		ID:            details.ID,
+		ProductID:     details.ProductID,
+		Quantity:      details.Quantity,
+		PrepStatus:    details.PrepStatus,
+		PrepStartedAt: details.PrepStartedAt,
+		Options:       details.Options,
+		Components:    details.Components,
+	}
+	if item.Product != nil {
+		response.ProductName = item.Product.Name
+		if item.Product.Category != nil {
+			response.Station = item.Product.Category.Station
+		}
+	}
+	return response
+}
+
+func NewKitchenOrderResponse(o *models.Order) KitchenOrderResponse {
+	items := make([]KitchenItemResponse, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = NewKitchenItemResponse(&item)
+	}
+
+	response := KitchenOrderResponse{
+		OrderID:     string(o.ID),
+		SalesSlotID: string(o.SalesSlotID),
+		ConfirmedAt: o.ConfirmedAt,
+		Items:       items,
+	}
+	if o.Ticket != nil {
+		response.TicketNumber = o.Ticket.TicketNumber
+	}
+	return response
+}
+
+func NewKitchenQueueResponse(orders []models.Order) []KitchenOrderResponse {
+	result := make([]KitchenOrderResponse, len(orders))
+	for i, o := range orders {
+		result[i] = NewKitchenOrderResponse(&o)
+	}
+	return result
+}
+
+// waitMinutes rounds the wait up to whole minutes.
+func waitMinutes(d time.Duration) int {
+	return int(math.Ceil(d.Minutes()))
+}
+
+// TicketWaitResponse is a ticket on the call-number display. Status is
+// PREPARING or READY; the estimates are 0 once the order is ready.
+type TicketWaitResponse struct {
+	TicketID             string    `json:"ticketId"`
+	TicketNumber         string    `json:"ticketNumber"`
+	OrderID              string    `json:"orderId"`
+	SalesSlotID          string    `json:"salesSlotId"`
+	Status               string    `json:"status" enums:"PREPARING,READY"`
+	IsPaid               bool      `json:"isPaid"`
+	EstimatedWaitMinutes int       `json:"estimatedWaitMinutes"`
+	EstimatedReadyAt     time.Time `json:"estimatedReadyAt"`
+}
+
+func NewTicketWaitResponse(w *services.TicketWait, now time.Time) TicketWaitResponse {
+	status := types.PREPARING
+	if w.Ready {
+		status = types.READY
+	}
+	return TicketWaitResponse{
+		TicketID:             string(w.Ticket.ID),
+		TicketNumber:         w.Ticket.TicketNumber,
+		OrderID:              string(w.Ticket.OrderID),
+		SalesSlotID:          string(w.SalesSlotID),
+		Status:               status.String(),
+		IsPaid:               w.Ticket.IsPaid,
+		EstimatedWaitMinutes: waitMinutes(w.EstimatedWait),
+		EstimatedReadyAt:     now.Add(w.EstimatedWait),
+	}
+}
+
+func NewTicketWaitResponseList(waits []services.TicketWait, now time.Time) []TicketWaitResponse {
+	result := make([]TicketWaitResponse, len(waits))
+	for i, w := range waits {
+		result[i] = NewTicketWaitResponse(&w, now)
+	}
+	return result
+}
+
+// PrepTimesResponse has rolling average preparation times, from starting an
+// item to it being ready, in seconds. Products without a ready item are left
+// out and the slot average is 0 before any item in the slot is ready.
+type PrepTimesResponse struct {
+	SalesSlotID        string                    `json:"salesSlotId"`
+	SlotAverageSeconds int                       `json:"slotAverageSeconds"`
+	Products           []ProductPrepTimeResponse `json:"products"`
+}
+
+type ProductPrepTimeResponse struct {
+	ProductID      string `json:"productId"`
+	AverageSeconds int    `json:"averageSeconds"`
+}
+
+func NewPrepTimesResponse(salesSlotID types.ID, times *repositories.PrepTimes) PrepTimesResponse {
+	products := make([]ProductPrepTimeResponse, 0, len(times.ByProduct))
+	for id, d := range times.ByProduct {
+		products = append(products, ProductPrepTimeResponse{ProductID: string(id), AverageSeconds: int(d.Round(time.Second).Seconds())})
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].ProductID < products[j].ProductID })
+
+	return PrepTimesResponse{
+		SalesSlotID:        string(salesSlotID),
+		SlotAverageSeconds: int(times.Slot.Round(time.Second).Seconds()),
+		Products:           products,
+	}
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// FieldError describes why one field of a request was rejected. Field is the
+// JSON path of the field, e.g. "items[0].quantity".
+type FieldError struct {
+	Field     string `json:"field"`
+	Code      string `json:"code" enums:"required,min,max,uuid,rfc3339,oneof"`
+	Message   string `json:"message"`
+	MessageEn string `json:"messageEn"`
+}
+
+type ValidationErrorResponse struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
+
+// parseBody parses the request body into req and checks it against the
+// `validate` tags of its fields. When the body is rejected it returns false
+// and the handler should return the error as is; for validation failures
+// the response listing the field errors has already been written.
+func parseBody(c *fiber.Ctx, req interface{}) (bool, error) {
+	if err := c.BodyParser(req); err != nil {
+		return false, fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	if errs := validate(req); len(errs) > 0 {
+		return false, c.Status(fiber.StatusBadRequest).JSON(ValidationErrorResponse{
+			Message: "入力内容に誤りがあります",
+			Errors:  errs,
+		})
+	}
+	return true, nil
+}
+
+// validate checks v against the rules in the `validate` struct tags of its
+// fields and returns every violation. Supported rules:
+//
+//	required      the field must not be empty, zero or null
+//	min=N, max=N  bounds on numbers, and on the length of strings and lists
+//	uuid          strings, or each string in a list, must be UUIDs
+//	rfc3339       strings must be RFC3339 date-times
+//	date          strings must be dates such as 2026-11-03
+//	clock         strings must be times of day such as 09:30
+//	oneof=A B     strings must be one of the listed values
+//	dive          validate each element of a list of structs
+//
+// Rules other than required are skipped for empty strings and null values.
+func validate(v interface{}) []FieldError {
+	var errs []FieldError
+	validateValue(reflect.ValueOf(v), "", &errs)
+	return errs
+}
+
+func validateValue(v reflect.Value, path string, errs *[]FieldError) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := jsonName(field)
+			if path != "" {
+				name = path + "." + name
+			}
+			validateField(v.Field(i), name, field.Tag.Get("validate"), errs)
+		}
+	}
+}
+
+func validateField(v reflect.Value, path, tag string, errs *[]FieldError) {
+	if tag == "" {
+		return
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+
+		if name == "required" {
+			if isEmpty(v) {
+				*errs = append(*errs, fieldError(path, "required", "required", ""))
+				return
+			}
+			continue
+		}
+		if name == "dive" {
+			validateValue(v, path, errs)
+			continue
+		}
+
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.String && v.Len() == 0 {
+			return
+		}
+
+		if fe := checkRule(v, path, name, param); fe != nil {
+			*errs = append(*errs, *fe)
+			return
+		}
+	}
+}
+
+func checkRule(v reflect.Value, path, name, param string) *FieldError {
+	switch name {
+	case "min", "max":
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			panic(fmt.Sprintf("invalid %s rule on %s: %q", name, path, param))
+		}
+		var n int
+		message := name
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n = int(v.Int())
+		case reflect.String:
+			n = len([]rune(v.String()))
+			message += "Length"
+		case reflect.Slice, reflect.Array, reflect.Map:
+			n = v.Len()
+			message += "Items"
+		default:
+			return nil
+		}
+		if (name == "min" && n < limit) || (name == "max" && n > limit) {
+			fe := fieldError(path, name, message, param)
+			return &fe
+		}
+	case "uuid":
+		if v.Kind() == reflect.Slice {
+			for i := 0; i < v.Len(); i++ {
+				if fe := checkRule(v.Index(i), fmt.Sprintf("%s[%d]", path, i), name, param); fe != nil {
+					return fe
+				}
+			}
+			return nil
+		}
+		if s := v.String(); len(s) != 36 || uuid.Validate(s) != nil {
+			fe := fieldError(path, "uuid", "uuid", "")
+			return &fe
+		}
+	case "rfc3339":
+		if _, err := time.Parse(time.RFC3339, v.String()); err != nil {
+			fe := fieldError(path, "rfc3339", "rfc3339", "")
+			return &fe
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, v.String()); err != nil {
+			fe := fieldError(path, "date", "date", "")
+			return &fe
+		}
+	case "clock":
+		if _, ok := services.ParseClock(v.String()); !ok {
+			fe := fieldError(path, "clock", "clock", "")
+			return &fe
+		}
+	case "oneof":
+		allowed := strings.Fields(param)
+		s := strings.ToUpper(v.String())
+		for _, a := range allowed {
+			if s == strings.ToUpper(a) {
+				return nil
+			}
+		}
+		fe := fieldError(path, "oneof", "oneof", strings.Join(allowed, ", "))
+		return &fe
+	default:
+		panic(fmt.Sprintf("unknown validation rule on %s: %q", path, name))
+	}
+	return nil
+}
+
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// fieldMessages holds the Japanese and English message for each rule. Length
+// and item count bounds get their own wording.
+var fieldMessages = map[string][2]string{
+	"required":  {"必須項目です", "is required"},
+	"min":       {"%s以上の値を指定してください", "must be at least %s"},
+	"max":       {"%s以下の値を指定してください", "must be at most %s"},
+	"minLength": {"%s文字以上で入力してください", "must be at least %s characters"},
+	"maxLength": {"%s文字以内で入力してください", "must be at most %s characters"},
+	"minItems":  {"%s件以上指定してください", "must contain at least %s items"},
+	"maxItems":  {"%s件以内で指定してください", "must contain at most %s items"},
+	"uuid":      {"UUID形式で指定してください", "must be a UUID"},
+	"rfc3339":   {"RFC3339形式の日時で指定してください", "must be an RFC3339 date-time"},
+	"date":      {"YYYY-MM-DD形式の日付で指定してください", "must be a date in YYYY-MM-DD format"},
+	"clock":     {"HH:MM形式の時刻で指定してください", "must be a time of day in HH:MM format"},
+	"oneof":     {"%sのいずれかを指定してください", "must be one of %s"},
+}
+
+func fieldError(path, code, message, param string) FieldError {
+	messages := fieldMessages[message]
+	ja, en := messages[0], messages[1]
+	if strings.Contains(ja, "%s") {
+		ja = fmt.Sprintf(ja, param)
+		en = fmt.Sprintf(en, param)
+	}
+	return FieldError{
+		Field:     path,
+		Code:      code,
+		Message:   ja,
+		MessageEn: en,
+	}
+}
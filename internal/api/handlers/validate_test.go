@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestValidate(t *testing.T) {
+	price := -1
+	startsAt, endsAt := "tomorrow", ""
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected []FieldError
+	}{
+		{
+			name: "valid order",
+			value: &CreateOrderRequest{
+				SalesSlotID: "5b0c2d0e-8f6a-4c1e-9d3b-2a7e4f1c6b90",
+				Items: []OrderItemCreateInput{
+					{ProductID: "0f8a3c52-1e4b-4d7a-b6c9-8e2d5f7a1b34", Quantity: 1},
+				},
+			},
+		},
+		{
+			name:  "missing fields",
+			value: &CreateOrderRequest{},
+			expected: []FieldError{
+				{Field: "salesSlotId", Code: "required"},
+				{Field: "items", Code: "required"},
+			},
+		},
+		{
+			name: "nested items",
+			value: &CreateOrderRequest{
+				SalesSlotID: "slot-1",
+				Items: []OrderItemCreateInput{
+					{ProductID: "0f8a3c52-1e4b-4d7a-b6c9-8e2d5f7a1b34", Quantity: 1},
+					{ProductID: "0f8a3c52-1e4b-4d7a-b6c9-8e2d5f7a1b34", Quantity: 0, OptionIDs: []string{"x"}},
+				},
+			},
+			expected: []FieldError{
+				{Field: "salesSlotId", Code: "uuid"},
+				{Field: "items[1].quantity", Code: "min"},
+				{Field: "items[1].optionIds[0]", Code: "uuid"},
+			},
+		},
+		{
+			name:  "top-level list",
+			value: &[]OrderItemCreateInput{{ProductID: "", Quantity: 1}},
+			expected: []FieldError{
+				{Field: "[0].productId", Code: "required"},
+			},
+		},
+		{
+			name: "pointers, lengths and enums",
+			value: &PromotionRequest{
+				Name:     strings.Repeat("あ", 101),
+				Type:     "HALF_PRICE",
+				StartsAt: &startsAt,
+				EndsAt:   &endsAt,
+			},
+			expected: []FieldError{
+				{Field: "name", Code: "max"},
+				{Field: "type", Code: "oneof"},
+				{Field: "startsAt", Code: "rfc3339"},
+			},
+		},
+		{
+			name:  "optional number",
+			value: &SetSlotPriceRequest{Price: &price},
+			expected: []FieldError{
+				{Field: "price", Code: "min"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validate(tt.value)
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("Expected %d errors, got %+v", len(tt.expected), errs)
+			}
+			for i, expected := range tt.expected {
+				if errs[i].Field != expected.Field || errs[i].Code != expected.Code {
+					t.Errorf("Expected %s %s, got %s %s", expected.Field, expected.Code, errs[i].Field, errs[i].Code)
+				}
+				if errs[i].Message == "" || errs[i].MessageEn == "" {
+					t.Errorf("Expected messages for %s, got %+v", expected.Field, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseBody_ReturnsFieldErrors(t *testing.T) {
+	app := fiber.New()
+	handler := NewProductHandler(newMockProductService())
+
+	app.Post("/products", handler.Create)
+
+	body, _ := json.Marshal(CreateProductRequest{Price: -100})
+	req := httptest.NewRequest("POST", "/products", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Errorf("Expected status code %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	var response ValidationErrorResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+
+	if len(response.Errors) != 2 {
+		t.Fatalf("Expected 2 field errors, got %+v", response.Errors)
+	}
+	if response.Errors[0].Field != "name" || response.Errors[0].Message != "必須項目です" {
+		t.Errorf("Expected name to be required, got %+v", response.Errors[0])
+	}
+	if response.Errors[1].Field != "price" || response.Errors[1].MessageEn != "must be at least 0" {
+		t.Errorf("Expected price minimum error, got %+v", response.Errors[1])
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WaitTimeHandler struct {
+	waitTimeService services.WaitTimeService
+}
+
+func NewWaitTimeHandler(waitTimeService services.WaitTimeService) *WaitTimeHandler {
+	return &WaitTimeHandler{waitTimeService: waitTimeService}
+}
+
+// @Summary Get the call-number display
+// @Description Undelivered tickets of the active sales slots, earliest confirmed first, with whether their order is ready and how long it is expected to take.
+// @Tags order-tickets
+// @Produce json
+// @Param booth query string false "Only the active slot of this booth"
+// @Success 200 {array} TicketWaitResponse
+// @Router /order-tickets/display [get]
+func (h *WaitTimeHandler) GetCallDisplay(c *fiber.Ctx) error {
+	waits, err := h.waitTimeService.GetCallDisplay(c.Context(), c.Query("booth"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewTicketWaitResponseList(waits, time.Now()))
+}
+
+// @Summary Get the expected wait of an order ticket
+// @Tags order-tickets
+// @Produce json
+// @Param id path string true "Ticket ID"
+// @Success 200 {object} TicketWaitResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /order-tickets/{id}/wait [get]
+func (h *WaitTimeHandler) GetTicketWait(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	wait, err := h.waitTimeService.GetTicketWait(c.Context(), types.ID(id))
+	if err != nil {
+		if err == services.ErrOrderNotConfirmed {
+			return fiber.NewError(fiber.StatusConflict, err.Error())
+		}
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewTicketWaitResponse(wait, time.Now()))
+}
+
+// @Summary Get the average preparation times of a sales slot
+// @Description Rolling averages over the most recent ready items of each product and of the slot.
+// @Tags sales-slots
+// @Produce json
+// @Param id path string true "Sales slot ID"
+// @Success 200 {object} PrepTimesResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /sales-slots/{id}/prep-times [get]
+func (h *WaitTimeHandler) GetPrepTimes(c *fiber.Ctx) error {
+	id, err := url.PathUnescape(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	times, err := h.waitTimeService.GetPrepTimes(c.Context(), types.ID(id))
+	if err != nil {
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Sales slot not found")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(NewPrepTimesResponse(types.ID(id), times))
+}
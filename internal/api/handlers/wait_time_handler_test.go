@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/services"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/gofiber/fiber/v2"
+)
+
+type mockWaitTimeService struct {
+	waits []services.TicketWait
+}
+
+func (s *mockWaitTimeService) GetPrepTimes(ctx context.Context, salesSlotID types.ID) (*repositories.PrepTimes, error) {
+	if salesSlotID != "slot1" {
+		return nil, repositories.NewErrNotFound("SalesSlot", salesSlotID)
+	}
+	return &repositories.PrepTimes{ByProduct: map[types.ID]time.Duration{"prod1": 90 * time.Second}, Slot: 90 * time.Second}, nil
+}
+
+func (s *mockWaitTimeService) GetCallDisplay(ctx context.Context, booth string) ([]services.TicketWait, error) {
+	return s.waits, nil
+}
+
+func (s *mockWaitTimeService) GetTicketWait(ctx context.Context, ticketID types.ID) (*services.TicketWait, error) {
+	if ticketID == "reserved" {
+		return nil, services.ErrOrderNotConfirmed
+	}
+	for i := range s.waits {
+		if s.waits[i].Ticket.ID == ticketID {
+			return &s.waits[i], nil
+		}
+	}
+	return nil, repositories.NewErrNotFound("OrderTicket", ticketID)
+}
+
+func TestWaitTimeHandler(t *testing.T) {
+	app := fiber.New()
+	handler := NewWaitTimeHandler(&mockWaitTimeService{waits: []services.TicketWait{
+		{Ticket: models.OrderTicket{ID: "ticket1", OrderID: "order1", TicketNumber: "A-1"}, SalesSlotID: "slot1", Ready: true},
+		{Ticket: models.OrderTicket{ID: "ticket2", OrderID: "order2", TicketNumber: "A-2"}, SalesSlotID: "slot1", EstimatedWait: 150 * time.Second},
+	}})
+	app.Get("/order-tickets/display", handler.GetCallDisplay)
+	app.Get("/order-tickets/:id/wait", handler.GetTicketWait)
+	app.Get("/sales-slots/:id/prep-times", handler.GetPrepTimes)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/order-tickets/display", nil))
+	if err != nil {
+		t.Fatalf("Failed to test request: %v", err)
+	}
+	var display []TicketWaitResponse
+	json.NewDecoder(resp.Body).Decode(&display)
+	if len(display) != 2 || display[0].Status != "READY" || display[0].EstimatedWaitMinutes != 0 {
+		t.Fatalf("Expected the ready ticket first, got %+v", display)
+	}
+	if display[1].Status != "PREPARING" || display[1].EstimatedWaitMinutes != 3 {
+		t.Errorf("Expected the wait to be rounded up to 3 minutes, got %+v", display[1])
+	}
+
+	var prepTimes PrepTimesResponse
+	resp, _ = app.Test(httptest.NewRequest("GET", "/sales-slots/slot1/prep-times", nil))
+	json.NewDecoder(resp.Body).Decode(&prepTimes)
+	if prepTimes.SlotAverageSeconds != 90 || len(prepTimes.Products) != 1 {
+		t.Errorf("Expected the averages in seconds, got %+v", prepTimes)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "ticket wait", path: "/order-tickets/ticket2/wait", expectedStatus: fiber.StatusOK},
+		{name: "unconfirmed order", path: "/order-tickets/reserved/wait", expectedStatus: fiber.StatusConflict},
+		{name: "unknown ticket", path: "/order-tickets/missing/wait", expectedStatus: fiber.StatusNotFound},
+		{name: "unknown slot", path: "/sales-slots/missing/prep-times", expectedStatus: fiber.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+			if err != nil {
+				t.Fatalf("Failed to test request: %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status code %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
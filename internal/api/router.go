@@ -20,9 +20,16 @@ func SetupRouter(app *fiber.App, serviceFactory services.ServiceFactory) {
 	api := app.Group("/api/v1")
 
 	productHandler := handlers.NewProductHandler(serviceFactory.ProductService())
+	categoryHandler := handlers.NewCategoryHandler(serviceFactory.CategoryService())
+	optionHandler := handlers.NewProductOptionHandler(serviceFactory.ProductOptionService())
 	salesSlotHandler := handlers.NewSalesSlotHandler(serviceFactory.SalesSlotService())
 	orderHandler := handlers.NewOrderHandler(serviceFactory.OrderService())
 	ticketHandler := handlers.NewOrderTicketHandler(serviceFactory.OrderTicketService())
+	receiptHandler := handlers.NewReceiptHandler(serviceFactory.ReceiptService())
+	promotionHandler := handlers.NewPromotionHandler(serviceFactory.PromotionService())
+	ingredientHandler := handlers.NewIngredientHandler(serviceFactory.IngredientService())
+	kitchenHandler := handlers.NewKitchenHandler(serviceFactory.KitchenService())
+	waitTimeHandler := handlers.NewWaitTimeHandler(serviceFactory.WaitTimeService())
 
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
@@ -33,36 +40,116 @@ func SetupRouter(app *fiber.App, serviceFactory services.ServiceFactory) {
 		products.Get("/:id", productHandler.GetByID)
 		products.Put("/:id", productHandler.Update)
 		products.Delete("/:id", productHandler.Delete)
+		products.Put("/:id/image", productHandler.UploadImage)
+		products.Get("/:id/recipe", ingredientHandler.GetRecipe)
+		products.Put("/:id/recipe", ingredientHandler.SetRecipe)
+		products.Get("/:id/image", productHandler.GetImage)
+		products.Get("/:id/prices", productHandler.GetPrices)
+		products.Post("/:id/prices", productHandler.SchedulePrice)
+		products.Delete("/:id/prices/:priceId", productHandler.CancelPrice)
+		products.Post("/:id/option-groups", optionHandler.Create)
+		products.Get("/:id/option-groups", optionHandler.GetByProduct)
+	}
+
+	optionGroups := api.Group("/option-groups")
+	{
+		optionGroups.Put("/:id", optionHandler.Update)
+		optionGroups.Delete("/:id", optionHandler.Delete)
+	}
+
+	categories := api.Group("/categories")
+	{
+		categories.Post("/", categoryHandler.Create)
+		categories.Get("/", categoryHandler.GetAll)
+		categories.Get("/:id", categoryHandler.GetByID)
+		categories.Put("/:id", categoryHandler.Update)
+		categories.Delete("/:id", categoryHandler.Delete)
+	}
+
+	ingredients := api.Group("/ingredients")
+	{
+		ingredients.Post("/", ingredientHandler.Create)
+		ingredients.Get("/", ingredientHandler.GetAll)
+		ingredients.Get("/stock", ingredientHandler.GetStock)
+		ingredients.Get("/:id", ingredientHandler.GetByID)
+		ingredients.Put("/:id", ingredientHandler.Update)
+		ingredients.Delete("/:id", ingredientHandler.Delete)
+		ingredients.Put("/:id/stock", ingredientHandler.SetStock)
 	}
 
 	salesSlots := api.Group("/sales-slots")
 	{
 		salesSlots.Post("/", salesSlotHandler.Create)
+		salesSlots.Post("/generate", salesSlotHandler.Generate)
 		salesSlots.Get("/", salesSlotHandler.GetAll)
 		salesSlots.Get("/:id", salesSlotHandler.GetByID)
+		salesSlots.Put("/:id", salesSlotHandler.Update)
+		salesSlots.Delete("/:id", salesSlotHandler.Delete)
 		salesSlots.Put("/:id/activate", salesSlotHandler.Activate)
 		salesSlots.Put("/:id/deactivate", salesSlotHandler.Deactivate)
+		salesSlots.Put("/:id/capacity", salesSlotHandler.SetCapacity)
+		salesSlots.Put("/:id/override", salesSlotHandler.SetOverride)
+		salesSlots.Get("/:id/transitions", salesSlotHandler.GetTransitions)
+		salesSlots.Get("/:id/prep-times", waitTimeHandler.GetPrepTimes)
 		salesSlots.Post("/:id/products", salesSlotHandler.AddProduct)
 		salesSlots.Get("/:id/products", salesSlotHandler.GetProducts)
+		salesSlots.Put("/:id/products/:productId/price", salesSlotHandler.SetProductPrice)
+		salesSlots.Put("/:id/products/:productId/limits", salesSlotHandler.SetPurchaseLimits)
+		salesSlots.Put("/:id/products/:productId/low-stock-threshold", salesSlotHandler.SetLowStockThreshold)
+		salesSlots.Put("/:id/products/:productId/availability", salesSlotHandler.SetAvailability)
+		salesSlots.Get("/:id/products/:productId/stock", salesSlotHandler.GetStock)
+		salesSlots.Post("/:id/products/:productId/stock/adjustments", salesSlotHandler.AdjustStock)
+		salesSlots.Post("/:id/products/:productId/stock/reconcile", salesSlotHandler.ReconcileStock)
+		salesSlots.Post("/:id/products/:productId/stock/transfers", salesSlotHandler.TransferStock)
+		salesSlots.Post("/:id/roll-over", salesSlotHandler.RollOverStock)
 	}
 
 	orders := api.Group("/orders")
 	{
 		orders.Post("/", orderHandler.Create)
 		orders.Get("/", orderHandler.GetAll)
+		orders.Get("/tax-report", orderHandler.TaxReport)
 		orders.Get("/:id", orderHandler.GetByID)
 		orders.Get("/status/:status", orderHandler.GetByStatus)
 		orders.Put("/:id/cancel", orderHandler.Cancel)
 		orders.Post("/:id/items", orderHandler.AddItems)
+		orders.Put("/:id/items/:itemId", orderHandler.UpdateItem)
+		orders.Delete("/:id/items/:itemId", orderHandler.RemoveItem)
+		orders.Get("/:id/item-changes", orderHandler.GetItemChanges)
+	}
+
+	kitchen := api.Group("/kitchen")
+	{
+		kitchen.Get("/queue", kitchenHandler.GetQueue)
+		kitchen.Get("/stream", kitchenHandler.Stream)
+		kitchen.Post("/items/:id/bump", kitchenHandler.BumpItem)
+		kitchen.Post("/orders/:id/bump", kitchenHandler.BumpOrder)
 	}
 
 	tickets := api.Group("/order-tickets")
 	{
 		tickets.Post("/", ticketHandler.Create)
 		tickets.Get("/", ticketHandler.GetAll)
+		tickets.Post("/verify", ticketHandler.Verify)
+		tickets.Get("/display", waitTimeHandler.GetCallDisplay)
 		tickets.Get("/:id", ticketHandler.GetByID)
 		tickets.Get("/number/:ticketNumber", ticketHandler.GetByNumber)
 		tickets.Put("/:id/payment", ticketHandler.UpdatePayment)
 		tickets.Put("/:id/deliver", ticketHandler.UpdateDelivery)
+		tickets.Get("/:id/wait", waitTimeHandler.GetTicketWait)
+		tickets.Get("/:id/qr", ticketHandler.QRCode)
+		tickets.Get("/:id/receipt", receiptHandler.GetReceipt)
+		tickets.Get("/:id/kitchen-slip", receiptHandler.GetKitchenSlip)
+		tickets.Post("/:id/print", receiptHandler.Print)
+	}
+
+	promotions := api.Group("/promotions")
+	{
+		promotions.Post("/", promotionHandler.Create)
+		promotions.Get("/", promotionHandler.GetAll)
+		promotions.Get("/report", promotionHandler.Report)
+		promotions.Get("/:id", promotionHandler.GetByID)
+		promotions.Put("/:id", promotionHandler.Update)
+		promotions.Delete("/:id", promotionHandler.Delete)
 	}
 }
@@ -18,22 +18,22 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/order-tickets": {
+        "/categories": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order-tickets"
+                    "categories"
                 ],
-                "summary": "Get all order tickets",
+                "summary": "Get all categories in display order",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.OrderTicketResponse"
+                                "$ref": "#/definitions/handlers.CategoryResponse"
                             }
                         }
                     }
@@ -47,17 +47,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order-tickets"
+                    "categories"
                 ],
-                "summary": "Create a new order ticket",
+                "summary": "Create a new category",
                 "parameters": [
                     {
-                        "description": "Ticket information",
-                        "name": "ticket",
+                        "description": "Category information",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateOrderTicketRequest"
+                            "$ref": "#/definitions/handlers.CreateCategoryRequest"
                         }
                     }
                 ],
@@ -65,65 +65,31 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.OrderTicketResponse"
+                            "$ref": "#/definitions/handlers.CategoryResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/order-tickets/number/{ticketNumber}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "order-tickets"
-                ],
-                "summary": "Get an order ticket by ticket number",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Ticket Number",
-                        "name": "ticketNumber",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.OrderTicketResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/order-tickets/{id}": {
+        "/categories/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "order-tickets"
+                    "categories"
                 ],
-                "summary": "Get an order ticket by ID",
+                "summary": "Get a category by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ticket ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -133,7 +99,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.OrderTicketResponse"
+                            "$ref": "#/definitions/handlers.CategoryResponse"
                         }
                     },
                     "404": {
@@ -143,9 +109,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/order-tickets/{id}/deliver": {
+            },
             "put": {
                 "consumes": [
                     "application/json"
@@ -154,23 +118,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "order-tickets"
+                    "categories"
                 ],
-                "summary": "Update delivery status",
+                "summary": "Update a category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ticket ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Category information",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateCategoryRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.OrderTicketResponse"
+                            "$ref": "#/definitions/handlers.CategoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "404": {
@@ -180,44 +159,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/order-tickets/{id}/payment": {
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Products in the category are kept and become uncategorized.",
                 "tags": [
-                    "order-tickets"
+                    "categories"
                 ],
-                "summary": "Update payment status",
+                "summary": "Delete a category",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Ticket ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Payment Status",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdatePaymentStatusRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.OrderTicketResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "404": {
                         "description": "Not Found",
@@ -228,22 +188,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/ingredients": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "ingredients"
                 ],
-                "summary": "Get all orders",
+                "summary": "Get all ingredients",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.OrderResponse"
+                                "$ref": "#/definitions/handlers.IngredientResponse"
                             }
                         }
                     }
@@ -257,17 +217,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "ingredients"
                 ],
-                "summary": "Create a new order",
+                "summary": "Create a new ingredient",
                 "parameters": [
                     {
-                        "description": "Order information",
-                        "name": "order",
+                        "description": "Ingredient information",
+                        "name": "ingredient",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateOrderRequest"
+                            "$ref": "#/definitions/handlers.IngredientRequest"
                         }
                     }
                 ],
@@ -275,39 +235,33 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.OrderResponse"
+                            "$ref": "#/definitions/handlers.IngredientResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/orders/status/{status}": {
+        "/ingredients/stock": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "ingredients"
                 ],
-                "summary": "Get orders by status",
+                "summary": "Get the ingredient stock of a booth",
                 "parameters": [
                     {
-                        "enum": [
-                            "RESERVED",
-                            "CONFIRMED",
-                            "CANCELLED"
-                        ],
                         "type": "string",
-                        "description": "Order Status",
-                        "name": "status",
-                        "in": "query",
-                        "required": true
+                        "description": "Booth",
+                        "name": "booth",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -316,32 +270,26 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.OrderResponse"
+                                "$ref": "#/definitions/handlers.IngredientStockResponse"
                             }
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/orders/{id}": {
+        "/ingredients/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "ingredients"
                 ],
-                "summary": "Get an order by ID",
+                "summary": "Get an ingredient by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -351,7 +299,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.OrderResponse"
+                            "$ref": "#/definitions/handlers.IngredientResponse"
                         }
                     },
                     "404": {
@@ -361,44 +309,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/orders/{id}/cancel": {
+            },
             "put": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "orders"
-                ],
-                "summary": "Cancel an order",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Order ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.OrderResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/orders/{id}/items": {
-            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -406,27 +318,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "orders"
+                    "ingredients"
                 ],
-                "summary": "Add items to an order",
+                "summary": "Update an ingredient",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Order ID",
+                        "description": "Ingredient ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Order items",
-                        "name": "items",
+                        "description": "Ingredient information",
+                        "name": "ingredient",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.OrderItemCreateInput"
-                            }
+                            "$ref": "#/definitions/handlers.IngredientRequest"
                         }
                     }
                 ],
@@ -434,13 +343,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.OrderResponse"
+                            "$ref": "#/definitions/handlers.IngredientResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "404": {
@@ -450,30 +359,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/products": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "The ingredient is removed from recipes and its stock is deleted.",
                 "tags": [
-                    "products"
+                    "ingredients"
+                ],
+                "summary": "Delete an ingredient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ingredient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all products",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.ProductResponse"
-                            }
+                            "$ref": "#/definitions/handlers.ErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/ingredients/{id}/stock": {
+            "put": {
+                "description": "Confirmed orders take the ingredients of their products' recipes from the stock of their slot's booth.",
                 "consumes": [
                     "application/json"
                 ],
@@ -481,29 +398,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "ingredients"
                 ],
-                "summary": "Create a new product",
+                "summary": "Set the amount of an ingredient a booth has on hand",
                 "parameters": [
                     {
-                        "description": "Product information",
-                        "name": "product",
+                        "type": "string",
+                        "description": "Ingredient ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ingredient stock",
+                        "name": "stock",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateProductRequest"
+                            "$ref": "#/definitions/handlers.SetIngredientStockRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ProductResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.IngredientStockResponse"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
@@ -511,19 +444,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
-            "get": {
+        "/kitchen/items/{id}/bump": {
+            "post": {
+                "description": "QUEUED items become PREPARING and PREPARING items READY.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "kitchen"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Move an order item to its next preparation status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Order item ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -533,7 +467,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ProductResponse"
+                            "$ref": "#/definitions/handlers.KitchenItemResponse"
                         }
                     },
                     "404": {
@@ -541,10 +475,123 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/kitchen/orders/{id}/bump": {
+            "post": {
+                "description": "The order's least advanced items at the station move to their next status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kitchen"
+                ],
+                "summary": "Move an order to its next preparation status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items prepared at this station",
+                        "name": "station",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.KitchenOrderResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/kitchen/queue": {
+            "get": {
+                "description": "Confirmed orders of the active sales slots with items still to prepare, earliest confirmed first. Items are routed to stations by their product's category.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "kitchen"
+                ],
+                "summary": "Get the kitchen queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only the active slot of this booth",
+                        "name": "booth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only items prepared at this station",
+                        "name": "station",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.KitchenOrderResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/kitchen/stream": {
+            "get": {
+                "description": "Server-sent events named order.confirmed or order.prep_status_changed whose data is {\"orderId\": \"...\"}. Clients fetch the queue again when they receive one. Events are only passed between requests handled by the same server process, so the API must run as a single instance for every kitchen display to receive them.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "kitchen"
+                ],
+                "summary": "Stream kitchen queue updates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/option-groups/{id}": {
             "put": {
+                "description": "Replaces the group's options with the ones in the request. Pass an option's id to keep it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -552,24 +599,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "product-options"
                 ],
-                "summary": "Update a product",
+                "summary": "Update an option group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Option group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product information",
-                        "name": "product",
+                        "description": "Option group",
+                        "name": "group",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateProductRequest"
+                            "$ref": "#/definitions/handlers.OptionGroupRequest"
                         }
                     }
                 ],
@@ -577,13 +624,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ProductResponse"
+                            "$ref": "#/definitions/handlers.OptionGroupResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "404": {
@@ -596,13 +643,13 @@ const docTemplate = `{
             },
             "delete": {
                 "tags": [
-                    "products"
+                    "product-options"
                 ],
-                "summary": "Delete a product",
+                "summary": "Delete an option group",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Product ID",
+                        "description": "Option group ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -621,22 +668,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/sales-slots": {
+        "/order-tickets": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales-slots"
+                    "order-tickets"
                 ],
-                "summary": "Get all sales slots",
+                "summary": "Get all order tickets",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.SalesSlotResponse"
+                                "$ref": "#/definitions/handlers.OrderTicketResponse"
                             }
                         }
                     }
@@ -650,17 +697,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "sales-slots"
+                    "order-tickets"
                 ],
-                "summary": "Create a new sales slot",
+                "summary": "Create a new order ticket",
                 "parameters": [
                     {
-                        "description": "Sales slot information",
-                        "name": "slot",
+                        "description": "Ticket information",
+                        "name": "ticket",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateSalesSlotRequest"
+                            "$ref": "#/definitions/handlers.CreateOrderTicketRequest"
                         }
                     }
                 ],
@@ -668,32 +715,63 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                            "$ref": "#/definitions/handlers.OrderTicketResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/sales-slots/{id}": {
+        "/order-tickets/display": {
             "get": {
+                "description": "Undelivered tickets of the active sales slots, earliest confirmed first, with whether their order is ready and how long it is expected to take.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales-slots"
+                    "order-tickets"
                 ],
-                "summary": "Get a sales slot by ID",
+                "summary": "Get the call-number display",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sales Slot ID",
-                        "name": "id",
+                        "description": "Only the active slot of this booth",
+                        "name": "booth",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TicketWaitResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/order-tickets/number/{ticketNumber}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order-tickets"
+                ],
+                "summary": "Get an order ticket by ticket number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket Number",
+                        "name": "ticketNumber",
                         "in": "path",
                         "required": true
                     }
@@ -702,7 +780,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                            "$ref": "#/definitions/handlers.OrderTicketResponse"
                         }
                     },
                     "404": {
@@ -714,29 +792,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/sales-slots/{id}/activate": {
-            "put": {
+        "/order-tickets/verify": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales-slots"
+                    "order-tickets"
                 ],
-                "summary": "Activate a sales slot",
+                "summary": "Verify a scanned ticket QR code",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Sales Slot ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Scanned token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VerifyTicketRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                            "$ref": "#/definitions/handlers.VerifyTicketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "404": {
@@ -744,23 +833,29 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handlers.ErrorResponse"
                         }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/sales-slots/{id}/deactivate": {
-            "put": {
+        "/order-tickets/{id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales-slots"
+                    "order-tickets"
                 ],
-                "summary": "Deactivate a sales slot",
+                "summary": "Get an order ticket by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sales Slot ID",
+                        "description": "Ticket ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -770,7 +865,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                            "$ref": "#/definitions/handlers.OrderTicketResponse"
                         }
                     },
                     "404": {
@@ -782,19 +877,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/sales-slots/{id}/products": {
-            "get": {
+        "/order-tickets/{id}/deliver": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "sales-slots"
+                    "order-tickets"
                 ],
-                "summary": "Get all products in a sales slot",
+                "summary": "Update delivery status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sales Slot ID",
+                        "description": "Ticket ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -804,10 +902,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handlers.ProductInventoryResponse"
-                            }
+                            "$ref": "#/definitions/handlers.OrderTicketResponse"
                         }
                     },
                     "404": {
@@ -817,41 +912,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/order-tickets/{id}/kitchen-slip": {
+            "get": {
                 "produces": [
-                    "application/json"
+                    "application/pdf",
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "sales-slots"
+                    "order-tickets"
                 ],
-                "summary": "Add a product to a sales slot",
+                "summary": "Get the kitchen slip of an order ticket",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sales Slot ID",
+                        "description": "Ticket ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product information",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AddProductToSlotRequest"
-                        }
+                        "enum": [
+                            "pdf",
+                            "escpos"
+                        ],
+                        "type": "string",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -868,107 +964,3955 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.AddProductToSlotRequest": {
-            "type": "object",
-            "properties": {
-                "initialQuantity": {
-                    "type": "integer"
-                },
-                "productId": {
-                    "type": "string"
-                }
-            }
         },
-        "handlers.CreateOrderRequest": {
-            "type": "object",
-            "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.OrderItemCreateInput"
-                    }
-                },
+        "/order-tickets/{id}/payment": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order-tickets"
+                ],
+                "summary": "Update payment status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdatePaymentStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderTicketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order-tickets/{id}/print": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order-tickets"
+                ],
+                "summary": "Print a receipt or kitchen slip",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Print job",
+                        "name": "job",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PrintTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order-tickets/{id}/qr": {
+            "get": {
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "order-tickets"
+                ],
+                "summary": "Get the QR code of an order ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order-tickets/{id}/receipt": {
+            "get": {
+                "produces": [
+                    "application/pdf",
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "order-tickets"
+                ],
+                "summary": "Get the receipt of an order ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "escpos"
+                        ],
+                        "type": "string",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/order-tickets/{id}/wait": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "order-tickets"
+                ],
+                "summary": "Get the expected wait of an order ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TicketWaitResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get all orders",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.OrderResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The response includes how long the order is expected to take to be ready once confirmed, estimated from the kitchen queue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Create a new order",
+                "parameters": [
+                    {
+                        "description": "Order information",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/status/{status}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get orders by status",
+                "parameters": [
+                    {
+                        "enum": [
+                            "RESERVED",
+                            "CONFIRMED",
+                            "CANCELLED"
+                        ],
+                        "type": "string",
+                        "description": "Order Status",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.OrderResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/tax-report": {
+            "get": {
+                "description": "Counts only confirmed orders created in the optional time range.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get consumption tax collected per rate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start time (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End time (RFC3339, exclusive)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TaxSummaryResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get an order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/cancel": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Cancel an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/item-changes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Get the item change history of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.OrderItemChangeResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/items": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Add items to an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Order items",
+                        "name": "items",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.OrderItemCreateInput"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/items/{itemId}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Change the quantity of an item on a reserved order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateOrderItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "orders"
+                ],
+                "summary": "Remove an item from a reserved order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only products in this category",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated allergens to exclude (e.g. EGG,MILK)",
+                        "name": "excludeAllergens",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ProductResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product information",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product information",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/image": {
+            "get": {
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Upload a product image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file (JPEG, PNG, GIF or WebP)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/option-groups": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product-options"
+                ],
+                "summary": "Get the option groups of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.OptionGroupResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "product-options"
+                ],
+                "summary": "Add an option group to a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Option group",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OptionGroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OptionGroupResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices": {
+            "get": {
+                "description": "Includes past prices and scheduled future changes, oldest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get the price timeline of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ProductPriceResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Schedule a price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New price and when it takes effect",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SchedulePriceChangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductPriceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/prices/{priceId}": {
+            "delete": {
+                "tags": [
+                    "products"
+                ],
+                "summary": "Cancel a scheduled price change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Price entry ID",
+                        "name": "priceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/recipe": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get the recipe of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.RecipeItemResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set menus have no recipe of their own; the recipes of their components are used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Replace the recipe of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipe",
+                        "name": "recipe",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetRecipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.RecipeItemResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get all promotions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.PromotionResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Promotions without a code apply automatically to matching orders. Promotions with a code apply only when the code is sent with the order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Create a new promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion information",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/report": {
+            "get": {
+                "description": "Counts only orders that were not cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get the discount cost of each promotion",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.PromotionUsageResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get a promotion by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PromotionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Update a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion information",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PromotionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PromotionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Delete a promotion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Get all sales slots",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SalesSlotResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The slot may not overlap other slots, or under the PER_BOOTH policy other slots at the same booth.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Create a new sales slot",
+                "parameters": [
+                    {
+                        "description": "Sales slot information",
+                        "name": "slot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateSalesSlotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlotConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/generate": {
+            "post": {
+                "description": "Slots that already exist at the same booth and times are kept and only get the products they lack, so a pattern can be generated again. With dryRun the slots are only previewed, including any slots they overlap.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Generate sales slots from a daily pattern",
+                "parameters": [
+                    {
+                        "description": "Slot pattern",
+                        "name": "pattern",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GenerateSalesSlotsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.GeneratedSlotResponse"
+                            }
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.GeneratedSlotResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlotConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Get a sales slot by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "The times of a slot with orders are only changed with force; its orders are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Update a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sales slot information",
+                        "name": "slot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateSalesSlotRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Change the times even if the slot has orders",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlotConflictResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "A slot with orders is only deleted with force, which cancels its orders and voids their tickets. Slots with confirmed or paid orders cannot be deleted.",
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Delete a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Cancel the slot's orders and delete it",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/activate": {
+            "put": {
+                "description": "Slots that are not pinned are closed again when they are outside their schedule. Only one slot per booth can be active.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Activate a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlotConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/capacity": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Set or clear the order capacity of a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Slot capacity",
+                        "name": "capacity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetSlotCapacityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/deactivate": {
+            "put": {
+                "description": "Slots that are not pinned are opened again when they are within their schedule.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Deactivate a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/override": {
+            "put": {
+                "description": "With AUTO the slot is opened and closed according to its start and end time. A slot is not opened while another slot at its booth is active.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Pin a sales slot open or closed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Override",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetSlotOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SalesSlotResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SlotConflictResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/prep-times": {
+            "get": {
+                "description": "Rolling averages over the most recent ready items of each product and of the slot.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Get the average preparation times of a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PrepTimesResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Get all products in a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Add a product to a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product information",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddProductToSlotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/availability": {
+            "put": {
+                "description": "Marks the product unavailable, e.g. when the kitchen runs out of an ingredient, even though stock remains.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Stop or resume orders of a product in a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Availability",
+                        "name": "availability",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetAvailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/limits": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Set or clear the purchase limits of a product in a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase limits",
+                        "name": "limits",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetPurchaseLimitsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/low-stock-threshold": {
+            "put": {
+                "description": "Staff are alerted when the available stock falls to the threshold and again when it sells out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Set or clear the low-stock threshold of a product in a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Low-stock threshold",
+                        "name": "threshold",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetLowStockThresholdRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/price": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Set or clear the price of a product in a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Slot price",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SetSlotPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/stock": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Get the stock ledger of a product in a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StockLedgerResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/stock/adjustments": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Restock, write off or correct the stock of a product in a sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock adjustment",
+                        "name": "adjustment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AdjustStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StockLedgerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/stock/reconcile": {
+            "post": {
+                "description": "Sets the stock to the ledger's totals. Stock without ledger entries gets entries for its current quantities instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Reconcile the stock of a product in a sales slot with its ledger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StockLedgerResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/products/{productId}/stock/transfers": {
+            "post": {
+                "description": "Only unreserved stock is moved. The product is added to the other slot with the same price and limits if it is not sold there yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Move available stock of a product to another sales slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock transfer",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TransferStockRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StockTransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/roll-over": {
+            "post": {
+                "description": "Moves the available stock of every product to the next slot of the same booth. Nothing is moved when there is none.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Carry the unsold stock of a sales slot over to the next slot of its booth",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.StockTransferResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sales-slots/{id}/transitions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sales-slots"
+                ],
+                "summary": "Get the history of a sales slot being opened and closed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sales Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SalesSlotTransitionResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AddProductToSlotRequest": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "initialQuantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "productId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AdjustStockRequest": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "reason",
+                "type"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "RESTOCK",
+                        "WASTE",
+                        "CORRECTION"
+                    ]
+                }
+            }
+        },
+        "handlers.BundleComponentRequest": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.BundleComponentResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "displayOrder": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "station": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "displayOrder": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "station": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "handlers.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "salesSlotId"
+            ],
+            "properties": {
+                "couponCodes": {
+                    "type": "array",
+                    "maxItems": 5,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "customerId": {
+                    "description": "CustomerID identifies the customer for per-customer purchase limits,\ne.g. a phone number, a session token or the token of an earlier ticket.",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrderItemCreateInput"
+                    }
+                },
+                "salesSlotId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateOrderTicketRequest": {
+            "type": "object",
+            "required": [
+                "orderId",
+                "ticketNumber"
+            ],
+            "properties": {
+                "orderId": {
+                    "type": "string"
+                },
+                "paymentMethod": {
+                    "maximum": 3,
+                    "minimum": 1,
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.PaymentMethod"
+                        }
+                    ]
+                },
+                "ticketNumber": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "handlers.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BundleComponentRequest"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "dietaryTags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "taxRate": {
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "REDUCED"
+                    ],
+                    "example": "REDUCED"
+                }
+            }
+        },
+        "handlers.CreateSalesSlotRequest": {
+            "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
+            "properties": {
+                "booth": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "startTime": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "enum": [
+                        "required",
+                        "min",
+                        "max",
+                        "uuid",
+                        "rfc3339",
+                        "oneof"
+                    ]
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "messageEn": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.GenerateSalesSlotsRequest": {
+            "type": "object",
+            "required": [
+                "closesAt",
+                "firstDay",
+                "lastDay",
+                "opensAt",
+                "slotMinutes"
+            ],
+            "properties": {
+                "booth": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "breaks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SlotBreakRequest"
+                    }
+                },
+                "closesAt": {
+                    "type": "string"
+                },
+                "dryRun": {
+                    "description": "DryRun previews the slots without creating them.",
+                    "type": "boolean"
+                },
+                "firstDay": {
+                    "type": "string"
+                },
+                "lastDay": {
+                    "type": "string"
+                },
+                "opensAt": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SlotTemplateProductRequest"
+                    }
+                },
+                "slotMinutes": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "timeZone": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.GeneratedSlotResponse": {
+            "type": "object",
+            "properties": {
+                "addedProducts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SalesSlotResponse"
+                    }
+                },
+                "created": {
+                    "type": "boolean"
+                },
+                "slot": {
+                    "$ref": "#/definitions/handlers.SalesSlotResponse"
+                }
+            }
+        },
+        "handlers.IngredientRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "unit"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "handlers.IngredientResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.IngredientStockResponse": {
+            "type": "object",
+            "properties": {
+                "booth": {
+                    "type": "string"
+                },
+                "ingredientId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.KitchenItemResponse": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BundleComponentResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrderItemOptionResponse"
+                    }
+                },
+                "prepStartedAt": {
+                    "type": "string"
+                },
+                "prepStatus": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "station": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.KitchenOrderResponse": {
+            "type": "object",
+            "properties": {
+                "confirmedAt": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.KitchenItemResponse"
+                    }
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "salesSlotId": {
+                    "type": "string"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OptionGroupRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "options",
+                "selectionType"
+            ],
+            "properties": {
+                "displayOrder": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "maxSelect": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "minSelect": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OptionRequest"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "selectionType": {
+                    "type": "string",
+                    "enum": [
+                        "SINGLE",
+                        "MULTIPLE"
+                    ]
+                }
+            }
+        },
+        "handlers.OptionGroupResponse": {
+            "type": "object",
+            "properties": {
+                "displayOrder": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "maxSelect": {
+                    "type": "integer"
+                },
+                "minSelect": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OptionResponse"
+                    }
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "selectionType": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OptionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "displayOrder": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "priceDelta": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.OptionResponse": {
+            "type": "object",
+            "properties": {
+                "displayOrder": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "priceDelta": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.OrderDiscountResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "promotionId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OrderItemChangeResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "newQuantity": {
+                    "type": "integer"
+                },
+                "oldQuantity": {
+                    "type": "integer"
+                },
+                "orderItemId": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "unitPrice": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.OrderItemCreateInput": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "optionIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.OrderItemOptionResponse": {
+            "type": "object",
+            "properties": {
+                "groupName": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "optionId": {
+                    "type": "string"
+                },
+                "priceDelta": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BundleComponentResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrderItemOptionResponse"
+                    }
+                },
+                "prepStartedAt": {
+                    "type": "string"
+                },
+                "prepStatus": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "readyAt": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "taxRate": {
+                    "type": "string"
+                },
+                "unitPrice": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "confirmedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "customerId": {
+                    "type": "string"
+                },
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrderDiscountResponse"
+                    }
+                },
+                "estimatedWaitMinutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrderItemResponse"
+                    }
+                },
+                "prepStartedAt": {
+                    "type": "string"
+                },
+                "readyAt": {
+                    "type": "string"
+                },
+                "salesSlotId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "integer"
+                },
+                "taxAmount": {
+                    "type": "integer"
+                },
+                "taxMode": {
+                    "type": "string"
+                },
+                "taxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.OrderTaxResponse"
+                    }
+                },
+                "totalAmount": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OrderTaxResponse": {
+            "type": "object",
+            "properties": {
+                "percent": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "taxAmount": {
+                    "type": "integer"
+                },
+                "taxableAmount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.OrderTicketResponse": {
+            "type": "object",
+            "properties": {
+                "changeAmount": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isDelivered": {
+                    "type": "boolean"
+                },
+                "isPaid": {
+                    "type": "boolean"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "paidAt": {
+                    "type": "string"
+                },
+                "paymentMethod": {
+                    "type": "string"
+                },
+                "tenderedAmount": {
+                    "type": "integer"
+                },
+                "ticketNumber": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "transactionId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PrepTimesResponse": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ProductPrepTimeResponse"
+                    }
+                },
+                "salesSlotId": {
+                    "type": "string"
+                },
+                "slotAverageSeconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PrintTicketRequest": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "receipt",
+                        "kitchen"
+                    ]
+                },
+                "reprint": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.ProductInventoryResponse": {
+            "type": "object",
+            "properties": {
+                "availableQuantity": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "initialQuantity": {
+                    "type": "integer"
+                },
+                "lowStock": {
+                    "type": "boolean"
+                },
+                "lowStockThreshold": {
+                    "type": "integer"
+                },
+                "makeableQuantity": {
+                    "type": "integer"
+                },
+                "maxPerCustomer": {
+                    "type": "integer"
+                },
+                "maxPerOrder": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "priceOverride": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "reservedQuantity": {
+                    "type": "integer"
+                },
+                "salesSlotId": {
+                    "type": "string"
+                },
+                "soldOut": {
+                    "type": "boolean"
+                },
+                "soldQuantity": {
+                    "type": "integer"
+                },
+                "unavailable": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ProductPrepTimeResponse": {
+            "type": "object",
+            "properties": {
+                "averageSeconds": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ProductPriceResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "effectiveFrom": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isScheduled": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ProductResponse": {
+            "type": "object",
+            "properties": {
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BundleComponentResponse"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dietaryTags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "imageUrl": {
+                    "type": "string"
+                },
+                "isBundle": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "taxPercent": {
+                    "type": "integer"
+                },
+                "taxRate": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.PromotionRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "buyQuantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "freeQuantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "default": true
+                },
+                "minAmount": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "salesSlotId": {
+                    "type": "string"
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "PERCENTAGE",
+                        "FIXED_AMOUNT",
+                        "BUY_X_GET_Y"
+                    ]
+                },
+                "usageLimit": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "value": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "handlers.PromotionResponse": {
+            "type": "object",
+            "properties": {
+                "buyQuantity": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "endsAt": {
+                    "type": "string"
+                },
+                "freeQuantity": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "minAmount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
                 "salesSlotId": {
                     "type": "string"
+                },
+                "startsAt": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "usageLimit": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.PromotionUsageResponse": {
+            "type": "object",
+            "properties": {
+                "discountAmount": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "promotionId": {
+                    "type": "string"
+                },
+                "uses": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.RecipeItemRequest": {
+            "type": "object",
+            "required": [
+                "ingredientId"
+            ],
+            "properties": {
+                "ingredientId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.RecipeItemResponse": {
+            "type": "object",
+            "properties": {
+                "ingredientId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SalesSlotResponse": {
+            "type": "object",
+            "properties": {
+                "almostFull": {
+                    "description": "AlmostFull is set when a fifth or less of the capacity is left, for\nshowing 残りわずか.",
+                    "type": "boolean"
+                },
+                "booth": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "itemCount": {
+                    "type": "integer"
+                },
+                "maxItems": {
+                    "type": "integer"
+                },
+                "maxOrders": {
+                    "type": "integer"
+                },
+                "orderCount": {
+                    "type": "integer"
+                },
+                "override": {
+                    "type": "string"
+                },
+                "remainingItems": {
+                    "type": "integer"
+                },
+                "remainingOrders": {
+                    "description": "RemainingOrders and RemainingItems are only set for limited slots.",
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.CreateOrderTicketRequest": {
+        "handlers.SalesSlotTransitionResponse": {
             "type": "object",
             "properties": {
-                "orderId": {
+                "cause": {
+                    "type": "string",
+                    "enum": [
+                        "SCHEDULED",
+                        "MANUAL",
+                        "OVERRIDE"
+                    ]
+                },
+                "createdAt": {
                     "type": "string"
                 },
-                "paymentMethod": {
-                    "$ref": "#/definitions/types.PaymentMethod"
+                "id": {
+                    "type": "string"
                 },
-                "ticketNumber": {
+                "isActive": {
+                    "type": "boolean"
+                },
+                "salesSlotId": {
                     "type": "string"
                 }
             }
         },
-        "handlers.CreateProductRequest": {
+        "handlers.SchedulePriceChangeRequest": {
             "type": "object",
+            "required": [
+                "effectiveFrom"
+            ],
             "properties": {
-                "name": {
+                "effectiveFrom": {
                     "type": "string"
                 },
                 "price": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 }
             }
         },
-        "handlers.CreateSalesSlotRequest": {
+        "handlers.SetAvailabilityRequest": {
             "type": "object",
+            "required": [
+                "available"
+            ],
             "properties": {
-                "endTime": {
+                "available": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.SetIngredientStockRequest": {
+            "type": "object",
+            "properties": {
+                "booth": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.SetLowStockThresholdRequest": {
+            "type": "object",
+            "properties": {
+                "threshold": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "handlers.SetPurchaseLimitsRequest": {
+            "type": "object",
+            "properties": {
+                "maxPerCustomer": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "maxPerOrder": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.SetRecipeRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.RecipeItemRequest"
+                    }
+                }
+            }
+        },
+        "handlers.SetSlotCapacityRequest": {
+            "type": "object",
+            "properties": {
+                "maxItems": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "maxOrders": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.SetSlotOverrideRequest": {
+            "type": "object",
+            "required": [
+                "override"
+            ],
+            "properties": {
+                "override": {
+                    "description": "Override pins the slot open or closed; AUTO lets the schedule open and\nclose it.",
+                    "type": "string",
+                    "enum": [
+                        "AUTO",
+                        "PINNED_OPEN",
+                        "PINNED_CLOSED"
+                    ],
+                    "example": "PINNED_OPEN"
+                }
+            }
+        },
+        "handlers.SetSlotPriceRequest": {
+            "type": "object",
+            "properties": {
+                "price": {
+                    "description": "Price clears the slot's override when null.",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "handlers.SlotBreakRequest": {
+            "type": "object",
+            "required": [
+                "end",
+                "start"
+            ],
+            "properties": {
+                "end": {
                     "type": "string"
                 },
-                "startTime": {
+                "start": {
                     "type": "string"
                 }
             }
         },
-        "handlers.ErrorResponse": {
+        "handlers.SlotConflictResponse": {
             "type": "object",
             "properties": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SalesSlotResponse"
+                    }
+                },
                 "message": {
                     "type": "string"
                 }
             }
         },
-        "handlers.OrderItemCreateInput": {
+        "handlers.SlotTemplateProductRequest": {
             "type": "object",
+            "required": [
+                "productId"
+            ],
             "properties": {
+                "initialQuantity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "productId": {
                     "type": "string"
-                },
-                "quantity": {
-                    "type": "integer"
                 }
             }
         },
-        "handlers.OrderItemResponse": {
+        "handlers.StockLedgerResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "consistent": {
+                    "type": "boolean"
                 },
-                "price": {
+                "inventory": {
+                    "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                },
+                "ledgerInitialQuantity": {
                     "type": "integer"
                 },
-                "productId": {
-                    "type": "string"
+                "ledgerReservedQuantity": {
+                    "type": "integer"
                 },
-                "quantity": {
+                "ledgerSoldQuantity": {
                     "type": "integer"
+                },
+                "movements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.StockMovementResponse"
+                    }
                 }
             }
         },
-        "handlers.OrderResponse": {
+        "handlers.StockMovementResponse": {
             "type": "object",
             "properties": {
                 "createdAt": {
@@ -977,37 +4921,68 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.OrderItemResponse"
-                    }
+                "orderId": {
+                    "type": "string"
                 },
-                "salesSlotId": {
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
                 },
-                "status": {
+                "transferSlotId": {
                     "type": "string"
                 },
-                "totalAmount": {
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.StockTransferResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "$ref": "#/definitions/handlers.ProductInventoryResponse"
+                },
+                "quantity": {
                     "type": "integer"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "to": {
+                    "$ref": "#/definitions/handlers.ProductInventoryResponse"
                 }
             }
         },
-        "handlers.OrderTicketResponse": {
+        "handlers.TaxSummaryResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "netAmount": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "percent": {
+                    "type": "integer"
+                },
+                "rate": {
                     "type": "string"
                 },
-                "id": {
+                "salesAmount": {
+                    "type": "integer"
+                },
+                "taxAmount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.TicketWaitResponse": {
+            "type": "object",
+            "properties": {
+                "estimatedReadyAt": {
                     "type": "string"
                 },
-                "isDelivered": {
-                    "type": "boolean"
+                "estimatedWaitMinutes": {
+                    "type": "integer"
                 },
                 "isPaid": {
                     "type": "boolean"
@@ -1015,111 +4990,191 @@ const docTemplate = `{
                 "orderId": {
                     "type": "string"
                 },
-                "paymentMethod": {
+                "salesSlotId": {
                     "type": "string"
                 },
-                "ticketNumber": {
-                    "type": "string"
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "PREPARING",
+                        "READY"
+                    ]
                 },
-                "transactionId": {
+                "ticketId": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "ticketNumber": {
                     "type": "string"
                 }
             }
         },
-        "handlers.ProductInventoryResponse": {
+        "handlers.TransferStockRequest": {
             "type": "object",
+            "required": [
+                "quantity",
+                "reason",
+                "toSalesSlotId"
+            ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
                 },
-                "initialQuantity": {
-                    "type": "integer"
+                "reason": {
+                    "type": "string",
+                    "maxLength": 200
                 },
-                "productId": {
+                "toSalesSlotId": {
                     "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "displayOrder": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "reservedQuantity": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "maxLength": 50
                 },
-                "salesSlotId": {
-                    "type": "string"
+                "station": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "handlers.UpdateOrderItemRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.UpdatePaymentStatusRequest": {
+            "type": "object",
+            "properties": {
+                "isPaid": {
+                    "type": "boolean"
                 },
-                "soldQuantity": {
-                    "type": "integer"
+                "tenderedAmount": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "updatedAt": {
-                    "type": "string"
+                "transactionId": {
+                    "type": "string",
+                    "maxLength": 100
                 }
             }
         },
-        "handlers.ProductResponse": {
+        "handlers.UpdateProductRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
+                "allergens": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "id": {
+                "categoryId": {
                     "type": "string"
                 },
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BundleComponentRequest"
+                    }
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "dietaryTags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "name": {
-                    "type": "string"
+                    "type": "string",
+                    "maxLength": 100
                 },
                 "price": {
-                    "type": "integer"
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "updatedAt": {
-                    "type": "string"
+                "taxRate": {
+                    "type": "string",
+                    "enum": [
+                        "STANDARD",
+                        "REDUCED"
+                    ],
+                    "example": "REDUCED"
                 }
             }
         },
-        "handlers.SalesSlotResponse": {
+        "handlers.UpdateSalesSlotRequest": {
             "type": "object",
+            "required": [
+                "endTime",
+                "startTime"
+            ],
             "properties": {
-                "createdAt": {
-                    "type": "string"
+                "booth": {
+                    "type": "string",
+                    "maxLength": 50
                 },
                 "endTime": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
-                },
-                "isActive": {
-                    "type": "boolean"
-                },
                 "startTime": {
                     "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
                 }
             }
         },
-        "handlers.UpdatePaymentStatusRequest": {
+        "handlers.ValidationErrorResponse": {
             "type": "object",
             "properties": {
-                "isPaid": {
-                    "type": "boolean"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FieldError"
+                    }
                 },
-                "transactionId": {
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "handlers.UpdateProductRequest": {
+        "handlers.VerifyTicketRequest": {
             "type": "object",
+            "required": [
+                "token"
+            ],
             "properties": {
-                "name": {
+                "token": {
                     "type": "string"
+                }
+            }
+        },
+        "handlers.VerifyTicketResponse": {
+            "type": "object",
+            "properties": {
+                "order": {
+                    "$ref": "#/definitions/handlers.OrderResponse"
                 },
-                "price": {
-                    "type": "integer"
+                "ticket": {
+                    "$ref": "#/definitions/handlers.OrderTicketResponse"
                 }
             }
         },
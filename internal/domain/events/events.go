@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+const (
+	SlotOpened = "sales_slot.opened"
+	SlotClosed = "sales_slot.closed"
+	// StockLow and StockSoldOut are published when a product's available
+	// stock in a slot falls to its low-stock threshold or to zero. Their
+	// payload is the inventory.
+	StockLow     = "inventory.low_stock"
+	StockSoldOut = "inventory.sold_out"
+	// OrderConfirmed is published when an order is confirmed and its items
+	// join the kitchen queue. Its payload is the order.
+	OrderConfirmed = "order.confirmed"
+	// PrepStatusChanged is published when the kitchen bumps items of an
+	// order. Its payload is the bumped items.
+	PrepStatusChanged = "order.prep_status_changed"
+)
+
+// Event reports a change to an entity. Payload is the changed entity or a
+// record of the change.
+type Event struct {
+	Type       string
+	EntityID   types.ID
+	OccurredAt time.Time
+	Payload    interface{}
+}
+
+// Publisher delivers events to whoever listens. Publishing must not block
+// the caller for long and failures are not reported back.
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+type Handler func(ctx context.Context, event Event)
+
+// Subscriber passes published events to handlers until they unsubscribe.
+type Subscriber interface {
+	Subscribe(handler Handler) (unsubscribe func())
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BundleComponent is one product contained in a set menu. Quantity is the
+// number of units of the component in a single bundle.
+type BundleComponent struct {
+	ID        types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	BundleID  types.ID `gorm:"type:uuid;index"`
+	ProductID types.ID `gorm:"type:uuid"`
+	Quantity  int
+
+	Product *Product `gorm:"foreignKey:ProductID"`
+}
+
+func (bc *BundleComponent) BeforeCreate(tx *gorm.DB) error {
+	if bc.ID == "" {
+		bc.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Category groups products on the menu. Station names the kitchen station,
+// e.g. grill or drinks, that prepares its products; empty means none.
+type Category struct {
+	ID           types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name         string
+	DisplayOrder int `gorm:"default:0"`
+	Station      string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+func (c *Category) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
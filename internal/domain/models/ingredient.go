@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Ingredient is something products are made from, such as noodles or cups,
+// counted in Unit (e.g. g, 個, 枚).
+type Ingredient struct {
+	ID        types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name      string   `gorm:"not null"`
+	Unit      string   `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+func (i *Ingredient) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
+
+// RecipeItem is the amount of an ingredient, in its unit, that one of the
+// product takes to make.
+type RecipeItem struct {
+	ID           types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ProductID    types.ID `gorm:"type:uuid;index"`
+	IngredientID types.ID `gorm:"type:uuid"`
+	Quantity     float64
+
+	Ingredient *Ingredient `gorm:"foreignKey:IngredientID"`
+}
+
+func (ri *RecipeItem) BeforeCreate(tx *gorm.DB) error {
+	if ri.ID == "" {
+		ri.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
+
+// IngredientStock is the amount of an ingredient a booth has on hand. It
+// goes below zero when more is used than was counted in.
+type IngredientStock struct {
+	ID           types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Booth        string   `gorm:"not null;uniqueIndex:idx_ingredient_stock_booth"`
+	IngredientID types.ID `gorm:"type:uuid;uniqueIndex:idx_ingredient_stock_booth"`
+	Quantity     float64
+	UpdatedAt    time.Time
+
+	Ingredient *Ingredient `gorm:"foreignKey:IngredientID"`
+}
+
+func (is *IngredientStock) BeforeCreate(tx *gorm.DB) error {
+	if is.ID == "" {
+		is.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
@@ -11,15 +11,23 @@ import (
 type Order struct {
 	ID          types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
 	SalesSlotID types.ID `gorm:"type:uuid"`
+	CustomerID  string   `gorm:"index"`
 	Status      types.OrderStatus
 	TotalAmount int
+	TaxMode     types.TaxMode
+	ConfirmedAt *time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	// EstimatedWait is how long the order is expected to take to be ready
+	// when it is confirmed now. It is only set when the order is created.
+	EstimatedWait *time.Duration `gorm:"-"`
 
-	SalesSlot *SalesSlot   `gorm:"foreignKey:SalesSlotID"`
-	Items     []OrderItem  `gorm:"foreignKey:OrderID"`
-	Ticket    *OrderTicket `gorm:"foreignKey:OrderID"`
+	SalesSlot *SalesSlot      `gorm:"foreignKey:SalesSlotID"`
+	Items     []OrderItem     `gorm:"foreignKey:OrderID"`
+	Discounts []OrderDiscount `gorm:"foreignKey:OrderID"`
+	Taxes     []OrderTax      `gorm:"foreignKey:OrderID"`
+	Ticket    *OrderTicket    `gorm:"foreignKey:OrderID"`
 }
 
 func (o *Order) BeforeCreate(tx *gorm.DB) error {
@@ -32,10 +40,85 @@ func (o *Order) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
-func (o *Order) CalculateTotalAmount() {
+// PrepStartedAt returns when the kitchen started the first of the order's
+// items, or nil before it has.
+func (o *Order) PrepStartedAt() *time.Time {
+	var started *time.Time
+	for _, item := range o.Items {
+		if item.PrepStartedAt != nil && (started == nil || item.PrepStartedAt.Before(*started)) {
+			started = item.PrepStartedAt
+		}
+	}
+	return started
+}
+
+// ReadyAt returns when the last of the order's items became ready, or nil
+// while any is not.
+func (o *Order) ReadyAt() *time.Time {
+	var ready *time.Time
+	for _, item := range o.Items {
+		if item.PrepStatus != types.READY || item.ReadyAt == nil {
+			return nil
+		}
+		if ready == nil || item.ReadyAt.After(*ready) {
+			ready = item.ReadyAt
+		}
+	}
+	return ready
+}
+
+// GetSubtotal returns the sum of the items before discounts.
+func (o *Order) GetSubtotal() int {
 	total := 0
 	for _, item := range o.Items {
 		total += item.GetSubtotal()
 	}
+	return total
+}
+
+func (o *Order) GetDiscountAmount() int {
+	total := 0
+	for _, d := range o.Discounts {
+		total += d.Amount
+	}
+	return total
+}
+
+func (o *Order) GetTaxAmount() int {
+	total := 0
+	for _, t := range o.Taxes {
+		total += t.TaxAmount
+	}
+	return total
+}
+
+// CalculateTotalAmount sets the amount to charge from the items, discounts
+// and, when prices exclude tax, the taxes.
+func (o *Order) CalculateTotalAmount() {
+	total := o.GetSubtotal() - o.GetDiscountAmount()
+	if total < 0 {
+		total = 0
+	}
+	if o.TaxMode == types.TAX_EXCLUSIVE {
+		total += o.GetTaxAmount()
+	}
 	o.TotalAmount = total
 }
+
+// OrderTax is the consumption tax for one rate on an order. TaxableAmount is
+// the discounted amount sold at the rate, including the tax when the order's
+// prices are tax-inclusive.
+type OrderTax struct {
+	ID            types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrderID       types.ID `gorm:"type:uuid;index"`
+	Rate          types.TaxRate
+	TaxableAmount int
+	TaxAmount     int
+}
+
+func (t *OrderTax) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
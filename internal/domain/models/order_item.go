@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -9,21 +11,110 @@ import (
 type OrderItem struct {
 	ID        types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
 	OrderID   types.ID `gorm:"type:uuid"`
-	ProductID types.ID `gorm:"type:uuid"`
+	ProductID types.ID `gorm:"type:uuid;index:idx_order_items_product_ready"`
 	Quantity  int
 	Price     int
+	TaxRate   types.TaxRate
+	// PrepStatus only matters once the order is confirmed.
+	PrepStatus    types.PrepStatus `gorm:"default:1"`
+	PrepStartedAt *time.Time
+	ReadyAt       *time.Time `gorm:"index:idx_order_items_product_ready"`
 
-	Order   *Order   `gorm:"foreignKey:OrderID"`
-	Product *Product `gorm:"foreignKey:ProductID"`
+	Order      *Order               `gorm:"foreignKey:OrderID"`
+	Product    *Product             `gorm:"foreignKey:ProductID"`
+	Options    []OrderItemOption    `gorm:"foreignKey:OrderItemID"`
+	Components []OrderItemComponent `gorm:"foreignKey:OrderItemID"`
 }
 
 func (oi *OrderItem) BeforeCreate(tx *gorm.DB) error {
 	if oi.ID == "" {
 		oi.ID = types.ID(uuid.New().String())
 	}
+	if oi.PrepStatus == 0 {
+		oi.PrepStatus = types.QUEUED
+	}
 	return nil
 }
 
+// GetUnitPrice returns the product price plus the price of every selected option.
+func (oi *OrderItem) GetUnitPrice() int {
+	price := oi.Price
+	for _, opt := range oi.Options {
+		price += opt.PriceDelta
+	}
+	return price
+}
+
 func (oi *OrderItem) GetSubtotal() int {
-	return oi.Price * oi.Quantity
+	return oi.GetUnitPrice() * oi.Quantity
+}
+
+// InventoryQuantities returns how many units of each product the item takes
+// from the slot inventory. Bundles take their components instead of themselves.
+func (oi *OrderItem) InventoryQuantities() map[types.ID]int {
+	if len(oi.Components) == 0 {
+		return map[types.ID]int{oi.ProductID: oi.Quantity}
+	}
+	quantities := make(map[types.ID]int, len(oi.Components))
+	for _, c := range oi.Components {
+		quantities[c.ProductID] += c.Quantity * oi.Quantity
+	}
+	return quantities
+}
+
+// OrderItemOption records an option chosen for an order item. The group name,
+// option name and price are copied so that later menu edits do not change
+// past orders.
+type OrderItemOption struct {
+	ID          types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrderItemID types.ID `gorm:"type:uuid;index"`
+	OptionID    types.ID `gorm:"type:uuid"`
+	GroupName   string
+	Name        string
+	PriceDelta  int
+}
+
+func (o *OrderItemOption) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
+
+// OrderItemComponent records the composition of a bundle at the time it was
+// ordered. Quantity is per unit of the order item.
+type OrderItemComponent struct {
+	ID          types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrderItemID types.ID `gorm:"type:uuid;index"`
+	ProductID   types.ID `gorm:"type:uuid"`
+	Quantity    int
+
+	Product *Product `gorm:"foreignKey:ProductID"`
+}
+
+func (c *OrderItemComponent) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
+
+// OrderItemChange records a change to the quantity of an item on a reserved
+// order. NewQuantity is 0 when the item was removed.
+type OrderItemChange struct {
+	ID          types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrderID     types.ID `gorm:"type:uuid;index"`
+	OrderItemID types.ID `gorm:"type:uuid"`
+	ProductID   types.ID `gorm:"type:uuid"`
+	UnitPrice   int
+	OldQuantity int
+	NewQuantity int
+	CreatedAt   time.Time
+}
+
+func (c *OrderItemChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = types.ID(uuid.New().String())
+	}
+	return nil
 }
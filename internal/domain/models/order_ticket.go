@@ -9,16 +9,21 @@ import (
 )
 
 type OrderTicket struct {
-	ID            types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	TicketNumber  string   `gorm:"unique"`
-	OrderID       types.ID `gorm:"type:uuid;unique"`
-	PaymentMethod types.PaymentMethod
-	TransactionID *string
-	IsPaid        bool `gorm:"default:false"`
-	IsDelivered   bool `gorm:"default:false"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	ID             types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	TicketNumber   string   `gorm:"unique"`
+	OrderID        types.ID `gorm:"type:uuid;unique"`
+	Token          string
+	PaymentMethod  types.PaymentMethod
+	TransactionID  *string
+	TenderedAmount *int
+	ChangeAmount   *int
+	IsPaid         bool `gorm:"default:false"`
+	IsDelivered    bool `gorm:"default:false"`
+	PaidAt         *time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
 
 	Order *Order `gorm:"foreignKey:OrderID"`
 }
@@ -9,17 +9,30 @@ import (
 )
 
 type Product struct {
-	ID        types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name      string
-	Price     int
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID          types.ID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	CategoryID  *types.ID `gorm:"type:uuid"`
+	Name        string
+	Description string
+	Price       int
+	ImagePath   string
+	Allergens   types.AllergenSet   `gorm:"default:0"`
+	DietaryTags types.DietaryTagSet `gorm:"default:0"`
+	IsBundle    bool
+	TaxRate     types.TaxRate
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	Category   *Category         `gorm:"foreignKey:CategoryID"`
+	Components []BundleComponent `gorm:"foreignKey:BundleID"`
 }
 
 func (p *Product) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == "" {
 		p.ID = types.ID(uuid.New().String())
 	}
+	if p.TaxRate == 0 {
+		p.TaxRate = types.STANDARD_RATE
+	}
 	return nil
 }
@@ -15,9 +15,22 @@ type ProductInventory struct {
 	InitialQuantity  int
 	ReservedQuantity int `gorm:"default:0"`
 	SoldQuantity     int `gorm:"default:0"`
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	Price            *int
+	MaxPerOrder      *int
+	MaxPerCustomer   *int
+	// LowStockThreshold is the available quantity at which staff are alerted.
+	LowStockThreshold *int
+	// Unavailable stops the product from being ordered in the slot while
+	// stock remains, e.g. when the kitchen runs out of an ingredient.
+	Unavailable bool `gorm:"default:false"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// MakeableQuantity is how many more of the product the booth's
+	// ingredients make, or nil when the product has no recipe. It is not
+	// stored.
+	MakeableQuantity *int `gorm:"-"`
 
 	SalesSlot *SalesSlot `gorm:"foreignKey:SalesSlotID"`
 	Product   *Product   `gorm:"foreignKey:ProductID"`
@@ -30,6 +43,26 @@ func (pi *ProductInventory) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// GetPrice returns the price override for the slot, or basePrice when none is
+// set.
+func (pi *ProductInventory) GetPrice(basePrice int) int {
+	if pi.Price != nil {
+		return *pi.Price
+	}
+	return basePrice
+}
+
 func (pi *ProductInventory) GetAvailableQuantity() int {
 	return pi.InitialQuantity - pi.ReservedQuantity - pi.SoldQuantity
 }
+
+func (pi *ProductInventory) IsSoldOut() bool {
+	return pi.GetAvailableQuantity() <= 0
+}
+
+// IsLowStock reports whether stock remains but no more than the low-stock
+// threshold.
+func (pi *ProductInventory) IsLowStock() bool {
+	available := pi.GetAvailableQuantity()
+	return pi.LowStockThreshold != nil && available > 0 && available <= *pi.LowStockThreshold
+}
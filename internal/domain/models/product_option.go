@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductOptionGroup is a set of choices offered with a product, such as
+// toppings or "with / without ice".
+type ProductOptionGroup struct {
+	ID            types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ProductID     types.ID `gorm:"type:uuid;index"`
+	Name          string
+	SelectionType types.SelectionType
+	Required      bool
+	MinSelect     int
+	MaxSelect     int
+	DisplayOrder  int
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeletedAt     gorm.DeletedAt `gorm:"index"`
+
+	Options []ProductOption `gorm:"foreignKey:GroupID"`
+}
+
+func (g *ProductOptionGroup) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == "" {
+		g.ID = types.ID(uuid.New().String())
+	}
+	if g.SelectionType == 0 {
+		g.SelectionType = types.SINGLE
+	}
+	return nil
+}
+
+// SelectionRange returns the minimum and maximum number of options that may
+// be chosen from the group. A maximum of 0 means there is no upper limit.
+func (g *ProductOptionGroup) SelectionRange() (int, int) {
+	min, max := g.MinSelect, g.MaxSelect
+	if g.Required && min < 1 {
+		min = 1
+	}
+	if g.SelectionType == types.SINGLE {
+		max = 1
+	}
+	return min, max
+}
+
+type ProductOption struct {
+	ID           types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	GroupID      types.ID `gorm:"type:uuid;index"`
+	Name         string
+	PriceDelta   int
+	DisplayOrder int
+}
+
+func (o *ProductOption) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductPrice is one entry in a product's price timeline. The price applies
+// from EffectiveFrom until the next entry. Entries in the future are scheduled
+// price changes.
+type ProductPrice struct {
+	ID            types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ProductID     types.ID `gorm:"type:uuid;index"`
+	Price         int
+	EffectiveFrom time.Time `gorm:"index"`
+	CreatedAt     time.Time
+}
+
+func (pp *ProductPrice) BeforeCreate(tx *gorm.DB) error {
+	if pp.ID == "" {
+		pp.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
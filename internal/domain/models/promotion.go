@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Promotion is a discount rule. Promotions without a code apply automatically;
+// coupon promotions apply only when their code is given with the order.
+//
+// Value is a percentage for PERCENTAGE and an amount in yen for FIXED_AMOUNT.
+// BUY_X_GET_Y gives FreeQuantity of every BuyQuantity+FreeQuantity units of
+// ProductID for free.
+type Promotion struct {
+	ID           types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name         string
+	Type         types.PromotionType
+	Value        int
+	BuyQuantity  int
+	FreeQuantity int
+	ProductID    *types.ID `gorm:"type:uuid"`
+	SalesSlotID  *types.ID `gorm:"type:uuid"`
+	Code         *string   `gorm:"uniqueIndex"`
+	MinAmount    int
+	UsageLimit   *int
+	StartsAt     *time.Time
+	EndsAt       *time.Time
+	IsActive     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+func (p *Promotion) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
+
+// IsAvailable reports whether the promotion can apply to an order in the given
+// sales slot at the given time. Usage limits are checked separately.
+func (p *Promotion) IsAvailable(salesSlotID types.ID, at time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.StartsAt != nil && at.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !at.Before(*p.EndsAt) {
+		return false
+	}
+	return p.SalesSlotID == nil || *p.SalesSlotID == salesSlotID
+}
+
+// OrderDiscount is a discount applied to an order, kept as its own line so the
+// order still shows what was charged before discounts.
+type OrderDiscount struct {
+	ID          types.ID  `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	OrderID     types.ID  `gorm:"type:uuid;index"`
+	PromotionID types.ID  `gorm:"type:uuid;index"`
+	ProductID   *types.ID `gorm:"type:uuid"`
+	Name        string
+	Code        *string
+	Amount      int
+}
+
+func (d *OrderDiscount) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
@@ -9,13 +9,26 @@ import (
 )
 
 type SalesSlot struct {
-	ID        types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ID types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	// Booth is where the slot sells, for events with several stalls. Only
+	// one slot per booth is active at a time.
+	Booth     string `gorm:"index"`
 	StartTime time.Time
 	EndTime   time.Time
 	IsActive  bool
+	Override  types.SlotOverride
+	// MaxOrders and MaxItems limit how many orders, and how many items
+	// across them, the kitchen takes in the slot. Nil means no limit.
+	MaxOrders *int
+	MaxItems  *int
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// OrderCount and ItemCount are what has been ordered in the slot so far,
+	// not counting cancelled orders. They are not stored.
+	OrderCount int `gorm:"-"`
+	ItemCount  int `gorm:"-"`
 }
 
 func (s *SalesSlot) BeforeCreate(tx *gorm.DB) error {
@@ -24,3 +37,60 @@ func (s *SalesSlot) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// Overlaps reports whether the slot shares any time with the given range.
+// Slots that only touch at an end do not overlap.
+func (s *SalesSlot) Overlaps(start, end time.Time) bool {
+	return s.StartTime.Before(end) && start.Before(s.EndTime)
+}
+
+// RemainingOrders returns how many more orders the slot takes, or nil when
+// orders are not limited.
+func (s *SalesSlot) RemainingOrders() *int {
+	return remaining(s.MaxOrders, s.OrderCount)
+}
+
+// RemainingItems returns how many more items the slot takes, or nil when
+// items are not limited.
+func (s *SalesSlot) RemainingItems() *int {
+	return remaining(s.MaxItems, s.ItemCount)
+}
+
+// IsAlmostFull reports whether a fifth or less of the slot's orders or items
+// is left.
+func (s *SalesSlot) IsAlmostFull() bool {
+	if r := s.RemainingOrders(); r != nil && *r*5 <= *s.MaxOrders {
+		return true
+	}
+	if r := s.RemainingItems(); r != nil && *r*5 <= *s.MaxItems {
+		return true
+	}
+	return false
+}
+
+func remaining(limit *int, used int) *int {
+	if limit == nil {
+		return nil
+	}
+	left := *limit - used
+	if left < 0 {
+		left = 0
+	}
+	return &left
+}
+
+// SalesSlotTransition records a sales slot being opened or closed.
+type SalesSlotTransition struct {
+	ID          types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	SalesSlotID types.ID `gorm:"type:uuid;index"`
+	IsActive    bool
+	Cause       types.SlotTransitionCause
+	CreatedAt   time.Time
+}
+
+func (t *SalesSlotTransition) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockMovement is an entry in the stock ledger of a product in a sales
+// slot. Quantity is the signed change to the count its type moves, so waste
+// and releases are negative. The counts on ProductInventory are the sums of
+// the slot's movements.
+type StockMovement struct {
+	ID          types.ID `gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	InventoryID types.ID `gorm:"type:uuid;index"`
+	SalesSlotID types.ID `gorm:"type:uuid"`
+	ProductID   types.ID `gorm:"type:uuid"`
+	Type        types.StockMovementType
+	Quantity    int
+	Reason      string
+	// OrderID is set for movements made by orders.
+	OrderID *types.ID `gorm:"type:uuid"`
+	// TransferSlotID is the slot stock was transferred to or from.
+	TransferSlotID *types.ID `gorm:"type:uuid"`
+	CreatedAt      time.Time
+}
+
+func (m *StockMovement) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = types.ID(uuid.New().String())
+	}
+	return nil
+}
+
+// Deltas returns the changes the movement makes to the initial, reserved and
+// sold quantities.
+func (m *StockMovement) Deltas() (initial, reserved, sold int) {
+	switch m.Type {
+	case types.RESERVATION, types.RELEASE:
+		return 0, m.Quantity, 0
+	case types.SALE:
+		return 0, 0, m.Quantity
+	default:
+		return m.Quantity, 0, 0
+	}
+}
@@ -0,0 +1,159 @@
+package receipt
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type Kind string
+
+const (
+	KindReceipt     Kind = "receipt"
+	KindKitchenSlip Kind = "kitchen"
+)
+
+type Format string
+
+const (
+	FormatESCPOS Format = "escpos"
+	FormatPDF    Format = "pdf"
+)
+
+type Item struct {
+	Name       string
+	Quantity   int
+	UnitPrice  int
+	Subtotal   int
+	Options    []ItemOption
+	Components []ItemComponent
+	// ReducedRate marks items taxed at the reduced rate, which receipts
+	// must flag.
+	ReducedRate bool
+}
+
+// ItemComponent is a product contained in a bundle. Quantity is the total
+// for the line, not per bundle.
+type ItemComponent struct {
+	Name     string
+	Quantity int
+}
+
+type ItemOption struct {
+	Name       string
+	PriceDelta int
+}
+
+// Document is the printer-independent content of a receipt or kitchen slip.
+type Document struct {
+	Kind           Kind
+	TicketNumber   string
+	IssuedAt       time.Time
+	Items          []Item
+	Subtotal       int
+	Discounts      []Discount
+	TaxExclusive   bool
+	Taxes          []Tax
+	TotalAmount    int
+	PaymentMethod  string
+	TransactionID  *string
+	TenderedAmount *int
+	ChangeAmount   *int
+	Reprint        bool
+}
+
+type Discount struct {
+	Name   string
+	Amount int
+}
+
+// Tax is the consumption tax for one rate. TaxableAmount includes the tax
+// unless the document is TaxExclusive.
+type Tax struct {
+	Percent       int
+	TaxableAmount int
+	TaxAmount     int
+}
+
+type Renderer interface {
+	Render(doc *Document, format Format) ([]byte, error)
+}
+
+type Printer interface {
+	Print(ctx context.Context, data []byte) error
+}
+
+func NewDocument(kind Kind, order *models.Order, ticket *models.OrderTicket) *Document {
+	doc := &Document{
+		Kind:         kind,
+		TicketNumber: ticket.TicketNumber,
+		IssuedAt:     order.CreatedAt,
+	}
+
+	for _, item := range order.Items {
+		name := string(item.ProductID)
+		if item.Product != nil {
+			name = item.Product.Name
+		}
+		var options []ItemOption
+		for _, opt := range item.Options {
+			options = append(options, ItemOption{Name: opt.Name, PriceDelta: opt.PriceDelta})
+		}
+		var components []ItemComponent
+		for _, c := range item.Components {
+			componentName := string(c.ProductID)
+			if c.Product != nil {
+				componentName = c.Product.Name
+			}
+			components = append(components, ItemComponent{Name: componentName, Quantity: c.Quantity * item.Quantity})
+		}
+		doc.Items = append(doc.Items, Item{
+			Name:        name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.GetUnitPrice(),
+			Subtotal:    item.GetSubtotal(),
+			Options:     options,
+			Components:  components,
+			ReducedRate: item.TaxRate == types.REDUCED_RATE,
+		})
+	}
+
+	if kind == KindKitchenSlip {
+		return doc
+	}
+
+	doc.Subtotal = order.GetSubtotal()
+	for _, d := range order.Discounts {
+		doc.Discounts = append(doc.Discounts, Discount{Name: d.Name, Amount: d.Amount})
+	}
+	doc.TaxExclusive = order.TaxMode == types.TAX_EXCLUSIVE
+	for _, t := range order.Taxes {
+		doc.Taxes = append(doc.Taxes, Tax{Percent: t.Rate.Percent(), TaxableAmount: t.TaxableAmount, TaxAmount: t.TaxAmount})
+	}
+	doc.TotalAmount = order.TotalAmount
+	doc.PaymentMethod = ticket.PaymentMethod.String()
+	doc.TransactionID = ticket.TransactionID
+	doc.TenderedAmount = ticket.TenderedAmount
+	doc.ChangeAmount = ticket.ChangeAmount
+	return doc
+}
+
+func ParseKind(s string) (Kind, bool) {
+	switch Kind(s) {
+	case KindReceipt, KindKitchenSlip:
+		return Kind(s), true
+	default:
+		return "", false
+	}
+}
+
+func ParseFormat(s string) (Format, bool) {
+	switch Format(s) {
+	case FormatESCPOS, FormatPDF:
+		return Format(s), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type IngredientRepository interface {
+	Repository[models.Ingredient]
+	// FindRecipes returns the recipe items of the products with their
+	// ingredients.
+	FindRecipes(ctx context.Context, productIDs []types.ID) ([]models.RecipeItem, error)
+	// ReplaceRecipe replaces the product's recipe with items.
+	ReplaceRecipe(ctx context.Context, productID types.ID, items []models.RecipeItem) error
+	FindStock(ctx context.Context, booth string) ([]models.IngredientStock, error)
+	SetStock(ctx context.Context, booth string, ingredientID types.ID, quantity float64) error
+}
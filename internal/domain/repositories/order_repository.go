@@ -2,16 +2,93 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 )
 
+// TaxSummary totals the consumption tax collected at one rate on confirmed
+// orders. SalesAmount includes the tax regardless of the orders' tax mode.
+type TaxSummary struct {
+	Rate        types.TaxRate
+	Orders      int
+	SalesAmount int
+	TaxAmount   int
+}
+
+// SlotUsage counts the orders in a sales slot that were not cancelled and the
+// items on them.
+type SlotUsage struct {
+	SalesSlotID types.ID
+	Orders      int
+	Items       int
+}
+
+// PrepTimes are rolling averages of the time from starting to prepare an
+// order item to it being ready. Products without ready items and, before any
+// item in it is ready, Slot are missing or zero.
+type PrepTimes struct {
+	ByProduct map[types.ID]time.Duration
+	Slot      time.Duration
+}
+
 type OrderRepository interface {
 	Repository[models.Order]
 	FindBySalesSlotID(ctx context.Context, salesSlotID types.ID) ([]models.Order, error)
 	FindByStatus(ctx context.Context, status types.OrderStatus) ([]models.Order, error)
+	FindByCustomer(ctx context.Context, salesSlotID types.ID, customerID string) ([]models.Order, error)
+	// FindReservedItemsByBooth returns the items, with their components, of
+	// the reserved orders in the booth's slots.
+	FindReservedItemsByBooth(ctx context.Context, booth string) ([]models.OrderItem, error)
+	// UpdateStatus sets the order's status and, when it is CONFIRMED, the
+	// confirmation time.
 	UpdateStatus(ctx context.Context, id types.ID, status types.OrderStatus) error
-	AddItems(ctx context.Context, orderID types.ID, items []models.OrderItem) error
-	CreateWithItems(ctx context.Context, order *models.Order, items []models.OrderItem) error
+	// UpdateReservedStatus sets the status of the order, and its
+	// confirmation time when it is CONFIRMED, if it is still RESERVED. The
+	// stock movements are applied and the ingredients, keyed by ingredient
+	// ID, taken from the booth's stock in the same transaction.
+	// ErrOrderStatusChanged is returned when the order is no longer
+	// reserved.
+	UpdateReservedStatus(ctx context.Context, id types.ID, status types.OrderStatus, movements []models.StockMovement, booth string, ingredients map[types.ID]float64) error
+	// AddItems sets the quantities of the updated items, inserts the added
+	// ones, applies the stock movements and saves the order's total,
+	// discounts and taxes in one transaction. ErrSlotCapacityExceeded is
+	// returned when the slot's items would go over its capacity and
+	// ErrOrderStatusChanged when the order is no longer reserved.
+	AddItems(ctx context.Context, order *models.Order, updated, added []models.OrderItem, movements []models.StockMovement) error
+	// CreateWithItems inserts the order with its items and applies the stock
+	// movements, recorded against the order, in one transaction.
+	// ErrSlotCapacityExceeded is returned when the slot's orders or items
+	// would go over its capacity and ErrPromotionUsedUp when one of the
+	// order's discounts would go over its promotion's usage limit.
+	CreateWithItems(ctx context.Context, order *models.Order, items []models.OrderItem, movements []models.StockMovement) error
+	SummarizeTaxes(ctx context.Context, from, to *time.Time) ([]TaxSummary, error)
+	CountBySalesSlots(ctx context.Context, salesSlotIDs []types.ID) ([]SlotUsage, error)
+	// ChangeItemQuantity applies change to its item, deleting the item when
+	// the new quantity is 0, applies the stock movements, saves the order's
+	// total, discounts and taxes and records the change in one transaction.
+	// ErrOrderStatusChanged is returned when the order is no longer
+	// reserved.
+	// ErrSlotCapacityExceeded is returned when a raised quantity would take
+	// the slot's items over its capacity.
+	ChangeItemQuantity(ctx context.Context, order *models.Order, change *models.OrderItemChange, movements []models.StockMovement) error
+	FindItemChanges(ctx context.Context, orderID types.ID) ([]models.OrderItemChange, error)
+	// FindKitchenQueue returns the confirmed orders in the slots with items
+	// that are not ready yet, earliest confirmed first, with their items'
+	// products, categories, options and components.
+	FindKitchenQueue(ctx context.Context, salesSlotIDs []types.ID) ([]models.Order, error)
+	// FindUndelivered returns the confirmed orders in the slots whose ticket
+	// is not delivered yet, earliest confirmed first, with their items and
+	// ticket.
+	FindUndelivered(ctx context.Context, salesSlotIDs []types.ID) ([]models.Order, error)
+	FindItemByID(ctx context.Context, id types.ID) (*models.OrderItem, error)
+	// UpdatePrepStatus sets the items' status and the time they were started
+	// or became ready.
+	UpdatePrepStatus(ctx context.Context, itemIDs []types.ID, status types.PrepStatus) error
+	// FindPrepTimes returns the average preparation time of the most recent
+	// ready items in the slot, at most window of them for each product and
+	// for the slot. Products the slot has not prepared yet get the average
+	// of their most recent items in any slot.
+	FindPrepTimes(ctx context.Context, salesSlotID types.ID, window int) (*PrepTimes, error)
 }
@@ -11,6 +11,8 @@ type OrderTicketRepository interface {
 	Repository[models.OrderTicket]
 	FindByTicketNumber(ctx context.Context, ticketNumber string) (*models.OrderTicket, error)
 	FindByOrderID(ctx context.Context, orderID types.ID) (*models.OrderTicket, error)
-	UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string) error
+	// UpdatePaymentStatus and UpdateDeliveryStatus also set or clear the time
+	// the ticket was paid or delivered.
+	UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string, tenderedAmount, changeAmount *int) error
 	UpdateDeliveryStatus(ctx context.Context, id types.ID, isDelivered bool) error
 }
@@ -12,5 +12,17 @@ type ProductInventoryRepository interface {
 	FindBySalesSlotID(ctx context.Context, salesSlotID types.ID) ([]models.ProductInventory, error)
 	FindByProductID(ctx context.Context, productID types.ID) ([]models.ProductInventory, error)
 	FindBySalesSlotAndProduct(ctx context.Context, salesSlotID, productID types.ID) (*models.ProductInventory, error)
-	UpdateQuantities(ctx context.Context, id types.ID, reserved, sold int) error
+	// ApplyMovements adds each movement's deltas to the stock of its slot and
+	// product and records it in the ledger, all in one transaction.
+	ApplyMovements(ctx context.Context, movements []models.StockMovement) error
+	FindMovements(ctx context.Context, inventoryID types.ID) ([]models.StockMovement, error)
+	// Reconcile locks the inventory and reads it with its ledger, then saves
+	// the quantities reconcile leaves on the inventory and records the
+	// movements it returns, all in one transaction. The movements start the
+	// ledger of stock from before it was kept.
+	Reconcile(ctx context.Context, inventoryID types.ID, reconcile func(inventory *models.ProductInventory, movements []models.StockMovement) []models.StockMovement) error
+	UpdatePrice(ctx context.Context, id types.ID, price *int) error
+	UpdateLimits(ctx context.Context, id types.ID, maxPerOrder, maxPerCustomer *int) error
+	UpdateLowStockThreshold(ctx context.Context, id types.ID, threshold *int) error
+	UpdateAvailability(ctx context.Context, id types.ID, unavailable bool) error
 }
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type ProductOptionGroupRepository interface {
+	Repository[models.ProductOptionGroup]
+	FindByProductID(ctx context.Context, productID types.ID) ([]models.ProductOptionGroup, error)
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type ProductPriceRepository interface {
+	Create(ctx context.Context, price *models.ProductPrice) error
+	FindByID(ctx context.Context, id types.ID) (*models.ProductPrice, error)
+	// FindByProductID returns the product's timeline ordered by EffectiveFrom.
+	FindByProductID(ctx context.Context, productID types.ID) ([]models.ProductPrice, error)
+	// FindEffective returns the latest entry that is in effect at the given time.
+	FindEffective(ctx context.Context, productID types.ID, at time.Time) (*models.ProductPrice, error)
+	// FindEffectiveAll returns the entry in effect at the given time for every product that has one.
+	FindEffectiveAll(ctx context.Context, at time.Time) ([]models.ProductPrice, error)
+	Delete(ctx context.Context, id types.ID) error
+}
@@ -4,9 +4,16 @@ import (
 	"context"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 )
 
+type ProductFilter struct {
+	CategoryID       *types.ID
+	ExcludeAllergens types.AllergenSet
+}
+
 type ProductRepository interface {
 	Repository[models.Product]
 	FindByName(ctx context.Context, name string) (*models.Product, error)
+	FindByFilter(ctx context.Context, filter ProductFilter) ([]models.Product, error)
 }
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// PromotionUsage summarises the discounts a promotion has given on orders
+// that were not cancelled.
+type PromotionUsage struct {
+	PromotionID    types.ID
+	Name           string
+	Uses           int
+	DiscountAmount int
+}
+
+type PromotionRepository interface {
+	Repository[models.Promotion]
+	FindActive(ctx context.Context) ([]models.Promotion, error)
+	FindByCode(ctx context.Context, code string) (*models.Promotion, error)
+	CountUsage(ctx context.Context, promotionID types.ID) (int, error)
+	SummarizeUsage(ctx context.Context) ([]PromotionUsage, error)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 )
@@ -23,6 +24,30 @@ func (e *RepositoryError) Error() string {
 	return e.Operation + ": " + e.Err.Error()
 }
 
+func (e *RepositoryError) Unwrap() error {
+	return e.Err
+}
+
+// ErrStockShortage is returned when a stock movement would leave less stock
+// than has been reserved and sold.
+var ErrStockShortage = errors.New("stock is short of reserved and sold quantities")
+
+// ErrSlotCapacityExceeded is returned when an order would take a sales slot
+// over its order or item capacity.
+var ErrSlotCapacityExceeded = errors.New("sales slot capacity exceeded")
+
+// ErrOrderStatusChanged is returned when an order was confirmed, cancelled
+// or paid for after it was read.
+var ErrOrderStatusChanged = errors.New("order status changed")
+
+// ErrPromotionUsedUp is returned when an order would take a promotion over
+// its usage limit.
+var ErrPromotionUsedUp = errors.New("promotion usage limit reached")
+
+// ErrBoothSlotActive is returned when a sales slot would open while another
+// slot in its booth is active.
+var ErrBoothSlotActive = errors.New("another sales slot in the booth is active")
+
 type ErrNotFound struct {
 	Entity string
 	ID     types.ID
@@ -12,6 +12,34 @@ type SalesSlotRepository interface {
 	Repository[models.SalesSlot]
 	FindActive(ctx context.Context) ([]models.SalesSlot, error)
 	FindByTimeRange(ctx context.Context, start, end time.Time) ([]models.SalesSlot, error)
+	// FindOverlapping returns the slots that share any time with the range.
+	FindOverlapping(ctx context.Context, start, end time.Time) ([]models.SalesSlot, error)
+	// FindNext returns the first slot of the booth starting at or after the
+	// given time.
+	FindNext(ctx context.Context, booth string, after time.Time) (*models.SalesSlot, error)
 	ActivateSlot(ctx context.Context, id types.ID) error
 	DeactivateSlot(ctx context.Context, id types.ID) error
+	// UpdateTimes sets the slot's booth, start time and end time.
+	UpdateTimes(ctx context.Context, id types.ID, booth string, start, end time.Time) error
+	// DeleteWithOrders cancels the slot's reserved, unpaid orders with the
+	// given IDs and deletes their tickets, applies the stock movements
+	// releasing their stock, and deletes the slot and its stock in one
+	// transaction. ErrOrderStatusChanged is returned when any of the orders
+	// is no longer reserved and unpaid, or the slot has other orders that
+	// are not cancelled.
+	DeleteWithOrders(ctx context.Context, id types.ID, orderIDs []types.ID, movements []models.StockMovement) error
+	UpdateCapacity(ctx context.Context, id types.ID, maxOrders, maxItems *int) error
+	UpdateOverride(ctx context.Context, id types.ID, override types.SlotOverride) error
+	// FindOutOfSchedule returns the slots following their schedule whose
+	// state is wrong: a slot should be active when it starts no later than
+	// openBy and ends after closedAfter.
+	FindOutOfSchedule(ctx context.Context, openBy, closedAfter time.Time) ([]models.SalesSlot, error)
+	// Transition sets the slot's active state to the transition's and records
+	// the transition in one transaction. It returns false without recording
+	// anything when the slot already was in that state, so that only one of
+	// several concurrent callers makes the transition. ErrBoothSlotActive is
+	// returned when opening the slot while another slot in its booth is
+	// active.
+	Transition(ctx context.Context, transition *models.SalesSlotTransition) (bool, error)
+	FindTransitions(ctx context.Context, salesSlotID types.ID) ([]models.SalesSlotTransition, error)
 }
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+)
+
+type CategoryService interface {
+	CreateCategory(ctx context.Context, name string, displayOrder int, station string) (*models.Category, error)
+	GetCategory(ctx context.Context, id types.ID) (*models.Category, error)
+	GetAllCategories(ctx context.Context) ([]models.Category, error)
+	UpdateCategory(ctx context.Context, id types.ID, name string, displayOrder int, station string) (*models.Category, error)
+	DeleteCategory(ctx context.Context, id types.ID) error
+}
+
+type categoryService struct {
+	repo repositories.CategoryRepository
+}
+
+func NewCategoryService(repo repositories.CategoryRepository) CategoryService {
+	return &categoryService{repo: repo}
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, name string, displayOrder int, station string) (*models.Category, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrInvalidCategory
+	}
+
+	category := &models.Category{
+		ID:           types.ID(uuid.New().String()),
+		Name:         name,
+		DisplayOrder: displayOrder,
+		Station:      strings.TrimSpace(station),
+	}
+
+	if err := s.repo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) GetCategory(ctx context.Context, id types.ID) (*models.Category, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *categoryService) GetAllCategories(ctx context.Context) ([]models.Category, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *categoryService) UpdateCategory(ctx context.Context, id types.ID, name string, displayOrder int, station string) (*models.Category, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrInvalidCategory
+	}
+
+	category, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	category.Name = name
+	category.DisplayOrder = displayOrder
+	category.Station = strings.TrimSpace(station)
+
+	if err := s.repo.Update(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *categoryService) DeleteCategory(ctx context.Context, id types.ID) error {
+	return s.repo.Delete(ctx, id)
+}
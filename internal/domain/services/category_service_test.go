@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCategoryService_CreateAndUpdateCategory(t *testing.T) {
+	repo := newMockCategoryRepository()
+	service := NewCategoryService(repo)
+	ctx := context.Background()
+
+	category, err := service.CreateCategory(ctx, "Food", 1, "")
+	if err != nil {
+		t.Fatalf("CreateCategory failed: %v", err)
+	}
+
+	if category.ID == "" {
+		t.Error("Expected category ID to be set")
+	}
+
+	updated, err := service.UpdateCategory(ctx, category.ID, "Drinks", 2, " drinks ")
+	if err != nil {
+		t.Fatalf("UpdateCategory failed: %v", err)
+	}
+
+	if updated.Name != "Drinks" || updated.DisplayOrder != 2 || updated.Station != "drinks" {
+		t.Errorf("Expected Drinks with display order 2 at the drinks station, got %s with %d at %q", updated.Name, updated.DisplayOrder, updated.Station)
+	}
+}
+
+func TestCategoryService_DeleteCategory(t *testing.T) {
+	repo := newMockCategoryRepository()
+	service := NewCategoryService(repo)
+	ctx := context.Background()
+
+	category, _ := service.CreateCategory(ctx, "Food", 1, "")
+
+	if err := service.DeleteCategory(ctx, category.ID); err != nil {
+		t.Fatalf("DeleteCategory failed: %v", err)
+	}
+
+	if _, err := service.GetCategory(ctx, category.ID); err == nil {
+		t.Error("Expected error when getting deleted category")
+	}
+}
+
+func TestCategoryService_RejectsEmptyName(t *testing.T) {
+	service := NewCategoryService(newMockCategoryRepository())
+
+	if _, err := service.CreateCategory(context.Background(), "", 1, ""); err != ErrInvalidCategory {
+		t.Errorf("Expected ErrInvalidCategory, got %v", err)
+	}
+}
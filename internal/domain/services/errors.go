@@ -1,5 +1,11 @@
 package services
 
+import (
+	"fmt"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+)
+
 type ServiceError struct {
 	Message string
 }
@@ -9,10 +15,78 @@ func (e *ServiceError) Error() string {
 }
 
 var (
-	ErrInsufficientInventory = &ServiceError{Message: "商品の在庫が不足しています"}
-	ErrInvalidOrderStatus    = &ServiceError{Message: "注文のステータスが無効です"}
-	ErrPaymentRequired       = &ServiceError{Message: "支払いが必要です"}
-	ErrDeliveryNotAllowed    = &ServiceError{Message: "商品の受け渡しができません"}
-	ErrDuplicateInventory    = &ServiceError{Message: "指定された販売枠に既に商品が登録されています"}
-	ErrInvalidTimeRange      = &ServiceError{Message: "無効な時間範囲です"}
+	ErrInsufficientInventory  = &ServiceError{Message: "商品の在庫が不足しています"}
+	ErrInvalidOrderStatus     = &ServiceError{Message: "注文のステータスが無効です"}
+	ErrPaymentRequired        = &ServiceError{Message: "支払いが必要です"}
+	ErrDeliveryNotAllowed     = &ServiceError{Message: "商品の受け渡しができません"}
+	ErrDuplicateInventory     = &ServiceError{Message: "指定された販売枠に既に商品が登録されています"}
+	ErrInvalidTimeRange       = &ServiceError{Message: "無効な時間範囲です"}
+	ErrInsufficientPayment    = &ServiceError{Message: "預かり金額が不足しています"}
+	ErrTenderedNotAllowed     = &ServiceError{Message: "現金以外の支払いでは預かり金額を指定できません"}
+	ErrPrinterNotConfigured   = &ServiceError{Message: "プリンターが設定されていません"}
+	ErrInvalidTicketToken     = &ServiceError{Message: "チケットの署名が無効です"}
+	ErrAlreadyDelivered       = &ServiceError{Message: "既に引き渡し済みです"}
+	ErrUnsupportedImage       = &ServiceError{Message: "対応していない画像形式です"}
+	ErrInvalidOptionGroup     = &ServiceError{Message: "オプショングループの設定が無効です"}
+	ErrInvalidOptionSelection = &ServiceError{Message: "オプションの選択が無効です"}
+	ErrInvalidBundle          = &ServiceError{Message: "セット商品の構成が無効です"}
+	ErrBundleInventory        = &ServiceError{Message: "セット商品には在庫を登録できません"}
+	ErrInvalidPromotion       = &ServiceError{Message: "プロモーションの設定が無効です"}
+	ErrInvalidCoupon          = &ServiceError{Message: "クーポンコードが無効です"}
+	ErrCouponUsedUp           = &ServiceError{Message: "クーポンの利用上限に達しています"}
+	ErrInvalidPriceChange     = &ServiceError{Message: "価格変更の指定が無効です"}
+	ErrInvalidSlotPrice       = &ServiceError{Message: "販売枠の価格が無効です"}
+	ErrInvalidQuantity        = &ServiceError{Message: "数量が無効です"}
+	ErrOrderItemNotFound      = &ServiceError{Message: "注文に指定された商品がありません"}
+	ErrEmptyOrder             = &ServiceError{Message: "注文の最後の商品は削除できません。注文をキャンセルしてください"}
+	ErrNoOrderItems           = &ServiceError{Message: "注文する商品を指定してください"}
+	ErrInvalidProduct         = &ServiceError{Message: "商品名と0円以上の価格を指定してください"}
+	ErrInvalidCategory        = &ServiceError{Message: "カテゴリー名を指定してください"}
+	ErrInvalidPurchaseLimit   = &ServiceError{Message: "購入数の上限は1以上を指定してください"}
+	ErrSlotFull               = &ServiceError{Message: "この販売枠は注文の受付上限に達しました"}
+	ErrSlotItemsFull          = &ServiceError{Message: "この販売枠で受け付けられる商品数を超えています"}
+	ErrInvalidCapacity        = &ServiceError{Message: "販売枠の受付上限は1以上を指定してください"}
+	ErrCustomerRequired       = &ServiceError{Message: "お一人様あたりの購入数に上限がある商品です。電話番号などの購入者情報を指定してください"}
+	ErrInvalidSlotPattern     = &ServiceError{Message: "販売枠の生成パターンが無効です"}
+	ErrTooManySlots           = &ServiceError{Message: "一度に生成できる販売枠は500件までです"}
+	ErrInvalidStockAdjustment = &ServiceError{Message: "在庫調整の種類・数量・理由を正しく指定してください"}
+	ErrInvalidStockTransfer   = &ServiceError{Message: "在庫の移動先・数量・理由を正しく指定してください"}
+	ErrInvalidStockThreshold  = &ServiceError{Message: "在庫警告のしきい値は0以上を指定してください"}
+	ErrProductUnavailable     = &ServiceError{Message: "現在販売を停止している商品が含まれています"}
+	ErrInvalidIngredient      = &ServiceError{Message: "材料名と単位を指定してください"}
+	ErrInvalidRecipe          = &ServiceError{Message: "レシピの材料と0より大きい分量を指定してください。セット商品にはレシピを登録できません"}
+	ErrInvalidIngredientStock = &ServiceError{Message: "材料の在庫は0以上を指定してください"}
+	ErrSlotHasOrders          = &ServiceError{Message: "注文がある販売枠の時間変更・削除はできません。注文を取り消して行う場合は強制を指定してください"}
+	ErrSlotHasSales           = &ServiceError{Message: "確定済みまたは支払済みの注文がある販売枠は削除できません"}
+	ErrOrderNotConfirmed      = &ServiceError{Message: "確定していない注文は調理できません"}
+	ErrAlreadyPrepared        = &ServiceError{Message: "すでに調理が完了しています"}
 )
+
+// SlotConflictError reports the sales slots that keep a slot from being
+// created or opened.
+type SlotConflictError struct {
+	Message   string
+	Conflicts []models.SalesSlot
+}
+
+func (e *SlotConflictError) Error() string {
+	return e.Message
+}
+
+// PurchaseLimitError reports an order that goes over a product's purchase
+// limit in its sales slot.
+type PurchaseLimitError struct {
+	ProductName string
+	Limit       int
+	// Purchased is what the customer has already ordered in the slot. It is
+	// only set for per-customer limits.
+	Purchased   int
+	PerCustomer bool
+}
+
+func (e *PurchaseLimitError) Error() string {
+	if e.PerCustomer {
+		return fmt.Sprintf("「%s」はお一人様%d個までです（注文済み%d個）", e.ProductName, e.Limit, e.Purchased)
+	}
+	return fmt.Sprintf("「%s」は1回の注文につき%d個までです", e.ProductName, e.Limit)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+)
+
+type IngredientService interface {
+	CreateIngredient(ctx context.Context, name, unit string) (*models.Ingredient, error)
+	GetIngredient(ctx context.Context, id types.ID) (*models.Ingredient, error)
+	GetAllIngredients(ctx context.Context) ([]models.Ingredient, error)
+	UpdateIngredient(ctx context.Context, id types.ID, name, unit string) (*models.Ingredient, error)
+	DeleteIngredient(ctx context.Context, id types.ID) error
+	GetRecipe(ctx context.Context, productID types.ID) ([]models.RecipeItem, error)
+	SetRecipe(ctx context.Context, productID types.ID, items []RecipeItemInput) ([]models.RecipeItem, error)
+	GetStock(ctx context.Context, booth string) ([]models.IngredientStock, error)
+	SetStock(ctx context.Context, booth string, ingredientID types.ID, quantity float64) ([]models.IngredientStock, error)
+}
+
+type RecipeItemInput struct {
+	IngredientID types.ID
+	Quantity     float64
+}
+
+type ingredientService struct {
+	repo     repositories.IngredientRepository
+	prodRepo repositories.ProductRepository
+}
+
+func NewIngredientService(repo repositories.IngredientRepository, prodRepo repositories.ProductRepository) IngredientService {
+	return &ingredientService{repo: repo, prodRepo: prodRepo}
+}
+
+func (s *ingredientService) CreateIngredient(ctx context.Context, name, unit string) (*models.Ingredient, error) {
+	name, unit = strings.TrimSpace(name), strings.TrimSpace(unit)
+	if name == "" || unit == "" {
+		return nil, ErrInvalidIngredient
+	}
+
+	ingredient := &models.Ingredient{
+		ID:   types.ID(uuid.New().String()),
+		Name: name,
+		Unit: unit,
+	}
+	if err := s.repo.Create(ctx, ingredient); err != nil {
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+func (s *ingredientService) GetIngredient(ctx context.Context, id types.ID) (*models.Ingredient, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *ingredientService) GetAllIngredients(ctx context.Context) ([]models.Ingredient, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *ingredientService) UpdateIngredient(ctx context.Context, id types.ID, name, unit string) (*models.Ingredient, error) {
+	name, unit = strings.TrimSpace(name), strings.TrimSpace(unit)
+	if name == "" || unit == "" {
+		return nil, ErrInvalidIngredient
+	}
+
+	ingredient, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	ingredient.Name = name
+	ingredient.Unit = unit
+	if err := s.repo.Update(ctx, ingredient); err != nil {
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+func (s *ingredientService) DeleteIngredient(ctx context.Context, id types.ID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *ingredientService) GetRecipe(ctx context.Context, productID types.ID) ([]models.RecipeItem, error) {
+	if _, err := s.prodRepo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRecipes(ctx, []types.ID{productID})
+}
+
+// SetRecipe replaces the product's recipe. An empty recipe removes it. Set
+// menus have none of their own; their components' recipes are used.
+func (s *ingredientService) SetRecipe(ctx context.Context, productID types.ID, inputs []RecipeItemInput) ([]models.RecipeItem, error) {
+	product, err := s.prodRepo.FindByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.IsBundle && len(inputs) > 0 {
+		return nil, ErrInvalidRecipe
+	}
+
+	items := make([]models.RecipeItem, 0, len(inputs))
+	seen := make(map[types.ID]bool, len(inputs))
+	for _, input := range inputs {
+		if input.Quantity <= 0 || seen[input.IngredientID] {
+			return nil, ErrInvalidRecipe
+		}
+		seen[input.IngredientID] = true
+		if _, err := s.repo.FindByID(ctx, input.IngredientID); err != nil {
+			return nil, err
+		}
+		items = append(items, models.RecipeItem{IngredientID: input.IngredientID, Quantity: input.Quantity})
+	}
+
+	if err := s.repo.ReplaceRecipe(ctx, productID, items); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRecipes(ctx, []types.ID{productID})
+}
+
+func (s *ingredientService) GetStock(ctx context.Context, booth string) ([]models.IngredientStock, error) {
+	return s.repo.FindStock(ctx, booth)
+}
+
+// SetStock sets the amount of the ingredient the booth has on hand, e.g.
+// after counting it, and returns the booth's stock.
+func (s *ingredientService) SetStock(ctx context.Context, booth string, ingredientID types.ID, quantity float64) ([]models.IngredientStock, error) {
+	if quantity < 0 {
+		return nil, ErrInvalidIngredientStock
+	}
+	if _, err := s.repo.FindByID(ctx, ingredientID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetStock(ctx, booth, ingredientID, quantity); err != nil {
+		return nil, err
+	}
+	return s.repo.FindStock(ctx, booth)
+}
+
+// ingredientAmounts sums, by ingredient, what the recipes take to make the
+// quantities of each product.
+func ingredientAmounts(recipes []models.RecipeItem, quantities map[types.ID]int) map[types.ID]float64 {
+	amounts := make(map[types.ID]float64)
+	for _, item := range recipes {
+		if quantity := quantities[item.ProductID]; quantity > 0 {
+			amounts[item.IngredientID] += item.Quantity * float64(quantity)
+		}
+	}
+	return amounts
+}
+
+// makeableQuantities returns how many of each product with a recipe the
+// stock makes, on its own, keyed by product ID, once the reserved amounts
+// are used.
+func makeableQuantities(recipes []models.RecipeItem, stock []models.IngredientStock, reserved map[types.ID]float64) map[types.ID]int {
+	onHand := make(map[types.ID]float64, len(stock))
+	for _, s := range stock {
+		onHand[s.IngredientID] = s.Quantity
+	}
+	for id, amount := range reserved {
+		onHand[id] -= amount
+	}
+
+	makeable := make(map[types.ID]int)
+	for _, item := range recipes {
+		n := 0
+		if onHand[item.IngredientID] > 0 {
+			// The epsilon keeps e.g. 0.3 / 0.1 from rounding down to 2.
+			n = int(math.Floor(onHand[item.IngredientID]/item.Quantity + 1e-9))
+		}
+		if current, exists := makeable[item.ProductID]; !exists || n < current {
+			makeable[item.ProductID] = n
+		}
+	}
+	return makeable
+}
+
+// ingredientsUsed returns the booth whose stock the order's items are made
+// from and the ingredients they take, keyed by ingredient ID.
+func (s *orderService) ingredientsUsed(ctx context.Context, order *models.Order) (string, map[types.ID]float64, error) {
+	quantities := inventoryQuantities(order.Items)
+	recipes, err := s.ingredientRepo.FindRecipes(ctx, productIDsOf(quantities))
+	if err != nil || len(recipes) == 0 {
+		return "", nil, err
+	}
+
+	slot, err := s.slotRepo.FindByID(ctx, order.SalesSlotID)
+	if err != nil {
+		return "", nil, err
+	}
+	return slot.Booth, ingredientAmounts(recipes, quantities), nil
+}
+
+// reservedIngredients returns the ingredients, keyed by ingredient ID, that
+// the booth's reserved orders will take from its stock once they are
+// confirmed.
+func (s *salesSlotService) reservedIngredients(ctx context.Context, booth string) (map[types.ID]float64, error) {
+	items, err := s.orderRepo.FindReservedItemsByBooth(ctx, booth)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities := inventoryQuantities(items)
+	if len(quantities) == 0 {
+		return nil, nil
+	}
+
+	recipes, err := s.ingredientRepo.FindRecipes(ctx, productIDsOf(quantities))
+	if err != nil {
+		return nil, err
+	}
+	return ingredientAmounts(recipes, quantities), nil
+}
+
+func productIDsOf(quantities map[types.ID]int) []types.ID {
+	productIDs := make([]types.ID, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	return productIDs
+}
+
+// setMakeableQuantities sets how many more of each product the ingredients
+// of the slot's booth make, after what its reserved orders need.
+func (s *salesSlotService) setMakeableQuantities(ctx context.Context, slotID types.ID, inventories []models.ProductInventory) error {
+	if len(inventories) == 0 {
+		return nil
+	}
+	productIDs := make([]types.ID, len(inventories))
+	for i, inventory := range inventories {
+		productIDs[i] = inventory.ProductID
+	}
+	recipes, err := s.ingredientRepo.FindRecipes(ctx, productIDs)
+	if err != nil || len(recipes) == 0 {
+		return err
+	}
+
+	slot, err := s.slotRepo.FindByID(ctx, slotID)
+	if err != nil {
+		return err
+	}
+	stock, err := s.ingredientRepo.FindStock(ctx, slot.Booth)
+	if err != nil {
+		return err
+	}
+	reserved, err := s.reservedIngredients(ctx, slot.Booth)
+	if err != nil {
+		return err
+	}
+	makeable := makeableQuantities(recipes, stock, reserved)
+	for i := range inventories {
+		if n, exists := makeable[inventories[i].ProductID]; exists {
+			inventories[i].MakeableQuantity = &n
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+)
+
+type mockIngredientRepository struct {
+	ingredients map[types.ID]*models.Ingredient
+	recipes     map[types.ID][]models.RecipeItem
+	// stock is keyed by booth, then ingredient.
+	stock map[string]map[types.ID]float64
+}
+
+func newMockIngredientRepository() *mockIngredientRepository {
+	return &mockIngredientRepository{
+		ingredients: make(map[types.ID]*models.Ingredient),
+		recipes:     make(map[types.ID][]models.RecipeItem),
+		stock:       make(map[string]map[types.ID]float64),
+	}
+}
+
+func (r *mockIngredientRepository) Create(ctx context.Context, ingredient *models.Ingredient) error {
+	r.ingredients[ingredient.ID] = ingredient
+	return nil
+}
+
+func (r *mockIngredientRepository) FindByID(ctx context.Context, id types.ID) (*models.Ingredient, error) {
+	ingredient, exists := r.ingredients[id]
+	if !exists {
+		return nil, repositories.NewErrNotFound("Ingredient", id)
+	}
+	return ingredient, nil
+}
+
+func (r *mockIngredientRepository) FindAll(ctx context.Context) ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+	for _, ingredient := range r.ingredients {
+		ingredients = append(ingredients, *ingredient)
+	}
+	return ingredients, nil
+}
+
+func (r *mockIngredientRepository) Update(ctx context.Context, ingredient *models.Ingredient) error {
+	r.ingredients[ingredient.ID] = ingredient
+	return nil
+}
+
+func (r *mockIngredientRepository) Delete(ctx context.Context, id types.ID) error {
+	if _, exists := r.ingredients[id]; !exists {
+		return repositories.NewErrNotFound("Ingredient", id)
+	}
+	delete(r.ingredients, id)
+	return nil
+}
+
+func (r *mockIngredientRepository) FindRecipes(ctx context.Context, productIDs []types.ID) ([]models.RecipeItem, error) {
+	var items []models.RecipeItem
+	for _, productID := range productIDs {
+		items = append(items, r.recipes[productID]...)
+	}
+	return items, nil
+}
+
+func (r *mockIngredientRepository) ReplaceRecipe(ctx context.Context, productID types.ID, items []models.RecipeItem) error {
+	for i := range items {
+		items[i].ID = types.ID(uuid.New().String())
+		items[i].ProductID = productID
+		items[i].Ingredient = r.ingredients[items[i].IngredientID]
+	}
+	r.recipes[productID] = items
+	return nil
+}
+
+func (r *mockIngredientRepository) FindStock(ctx context.Context, booth string) ([]models.IngredientStock, error) {
+	var stock []models.IngredientStock
+	for id, quantity := range r.stock[booth] {
+		stock = append(stock, models.IngredientStock{Booth: booth, IngredientID: id, Quantity: quantity, Ingredient: r.ingredients[id]})
+	}
+	return stock, nil
+}
+
+func (r *mockIngredientRepository) SetStock(ctx context.Context, booth string, ingredientID types.ID, quantity float64) error {
+	if r.stock[booth] == nil {
+		r.stock[booth] = make(map[types.ID]float64)
+	}
+	r.stock[booth][ingredientID] = quantity
+	return nil
+}
+
+func (r *mockIngredientRepository) consume(booth string, amounts map[types.ID]float64) {
+	if r.stock[booth] == nil {
+		r.stock[booth] = make(map[types.ID]float64)
+	}
+	for id, amount := range amounts {
+		r.stock[booth][id] -= amount
+	}
+}
+
+func TestIngredientService_SetRecipe(t *testing.T) {
+	repo := newMockIngredientRepository()
+	prodRepo := newMockProductRepository()
+	service := NewIngredientService(repo, prodRepo)
+	ctx := context.Background()
+
+	prodRepo.Create(ctx, &models.Product{ID: "prod1", Name: "焼きそば", Price: 400})
+	prodRepo.Create(ctx, &models.Product{ID: "set1", Name: "焼きそばセット", Price: 600, IsBundle: true})
+
+	if _, err := service.CreateIngredient(ctx, "麺", " "); err != ErrInvalidIngredient {
+		t.Errorf("Expected ErrInvalidIngredient, got %v", err)
+	}
+	noodles, err := service.CreateIngredient(ctx, " 麺 ", "玉")
+	if err != nil {
+		t.Fatalf("CreateIngredient failed: %v", err)
+	}
+	if noodles.Name != "麺" {
+		t.Errorf("Expected the name to be trimmed, got %q", noodles.Name)
+	}
+
+	tests := []struct {
+		name      string
+		productID types.ID
+		items     []RecipeItemInput
+		wantErr   error
+	}{
+		{name: "valid", productID: "prod1", items: []RecipeItemInput{{IngredientID: noodles.ID, Quantity: 1}}},
+		{name: "zero quantity", productID: "prod1", items: []RecipeItemInput{{IngredientID: noodles.ID, Quantity: 0}}, wantErr: ErrInvalidRecipe},
+		{name: "duplicate ingredient", productID: "prod1", items: []RecipeItemInput{{IngredientID: noodles.ID, Quantity: 1}, {IngredientID: noodles.ID, Quantity: 1}}, wantErr: ErrInvalidRecipe},
+		{name: "bundle", productID: "set1", items: []RecipeItemInput{{IngredientID: noodles.ID, Quantity: 1}}, wantErr: ErrInvalidRecipe},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SetRecipe(ctx, tt.productID, tt.items)
+			if err != tt.wantErr {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := service.SetRecipe(ctx, "prod1", []RecipeItemInput{{IngredientID: "missing", Quantity: 1}}); err == nil {
+		t.Error("Expected an unknown ingredient to be rejected")
+	}
+	if _, err := service.SetStock(ctx, "A", noodles.ID, -1); err != ErrInvalidIngredientStock {
+		t.Errorf("Expected ErrInvalidIngredientStock, got %v", err)
+	}
+}
+
+func TestOrderService_ConsumeIngredients(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	orderRepo := newMockOrderRepository()
+	ingredientRepo := newMockIngredientRepository()
+	orderRepo.inventories = invRepo
+	orderRepo.ingredients = ingredientRepo
+	orderRepo.slots = slotRepo
+	ingredientService := NewIngredientService(ingredientRepo, prodRepo)
+	slotService := NewSalesSlotService(slotRepo, invRepo, prodRepo, orderRepo, ingredientRepo, SlotSchedule{}, nil)
+	orderService := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), ingredientRepo, DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot1", Booth: "A", IsActive: true})
+	prodRepo.Create(ctx, &models.Product{ID: "prod1", Name: "焼きそば", Price: 400})
+	prodRepo.Create(ctx, &models.Product{ID: "prod2", Name: "ラムネ", Price: 150})
+	slotService.AddProductToSlot(ctx, "slot1", "prod1", 50, nil)
+	slotService.AddProductToSlot(ctx, "slot1", "prod2", 50, nil)
+
+	noodles, _ := ingredientService.CreateIngredient(ctx, "麺", "玉")
+	cabbage, _ := ingredientService.CreateIngredient(ctx, "キャベツ", "g")
+	if _, err := ingredientService.SetRecipe(ctx, "prod1", []RecipeItemInput{
+		{IngredientID: noodles.ID, Quantity: 1},
+		{IngredientID: cabbage.ID, Quantity: 50},
+	}); err != nil {
+		t.Fatalf("SetRecipe failed: %v", err)
+	}
+	ingredientService.SetStock(ctx, "A", noodles.ID, 10)
+	ingredientService.SetStock(ctx, "A", cabbage.ID, 300)
+
+	makeable := func() map[types.ID]*int {
+		t.Helper()
+		inventories, err := slotService.GetSlotInventories(ctx, "slot1")
+		if err != nil {
+			t.Fatalf("GetSlotInventories failed: %v", err)
+		}
+		result := make(map[types.ID]*int)
+		for _, inventory := range inventories {
+			result[inventory.ProductID] = inventory.MakeableQuantity
+		}
+		return result
+	}
+	if m := makeable(); m["prod1"] == nil || *m["prod1"] != 6 || m["prod2"] != nil {
+		t.Errorf("Expected 6 yakisoba to be makeable and no figure for ramune, got %v", m)
+	}
+
+	order, err := orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod1", Quantity: 2}, {ProductID: "prod2", Quantity: 1}}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if ingredientRepo.stock["A"][noodles.ID] != 10 {
+		t.Error("Expected reserving not to use ingredients")
+	}
+	if m := makeable(); *m["prod1"] != 4 {
+		t.Errorf("Expected 4 yakisoba to be makeable after the reserved ones, got %d", *m["prod1"])
+	}
+	if err := orderService.UpdateOrderStatus(ctx, order.ID, types.CONFIRMED); err != nil {
+		t.Fatalf("UpdateOrderStatus failed: %v", err)
+	}
+	if ingredientRepo.stock["A"][noodles.ID] != 8 || ingredientRepo.stock["A"][cabbage.ID] != 200 {
+		t.Errorf("Expected 2 noodles and 100g cabbage to be used, got %v", ingredientRepo.stock["A"])
+	}
+	if m := makeable(); *m["prod1"] != 4 {
+		t.Errorf("Expected 4 yakisoba to be makeable, got %d", *m["prod1"])
+	}
+
+	// A reserved order in another booth's slot uses that booth's stock
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot2", Booth: "B", IsActive: true})
+	slotService.AddProductToSlot(ctx, "slot2", "prod1", 50, nil)
+	if _, err := orderService.CreateOrder(ctx, "slot2", "", []OrderItemInput{{ProductID: "prod1", Quantity: 3}}, nil); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if m := makeable(); *m["prod1"] != 4 {
+		t.Errorf("Expected another booth's order to leave 4 yakisoba makeable, got %d", *m["prod1"])
+	}
+
+	if err := orderService.UpdateOrderStatus(ctx, order.ID, types.CONFIRMED); err != ErrInvalidOrderStatus {
+		t.Errorf("Expected confirming twice to fail with ErrInvalidOrderStatus, got %v", err)
+	}
+	if ingredientRepo.stock["A"][noodles.ID] != 8 {
+		t.Errorf("Expected no more noodles to be used, got %v", ingredientRepo.stock["A"][noodles.ID])
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// KitchenService is the kitchen's view of confirmed orders. Items are routed
+// to a station by their product's category; an empty station means every
+// station.
+type KitchenService interface {
+	// GetQueue returns the orders of the active slots, optionally only the
+	// booth's, that have items to prepare, earliest confirmed first. Each
+	// order only has the items routed to the station.
+	GetQueue(ctx context.Context, booth, station string) ([]models.Order, error)
+	// BumpItem moves the item to its next preparation status.
+	BumpItem(ctx context.Context, itemID types.ID) (*models.OrderItem, error)
+	// BumpOrder moves the order's least advanced items routed to the
+	// station to their next preparation status and returns the order with
+	// the station's items.
+	BumpOrder(ctx context.Context, orderID types.ID, station string) (*models.Order, error)
+	// Watch returns the events that change the kitchen queue until ctx is
+	// done. Events are dropped while the receiver is behind. Only events
+	// published in this process are seen, as the event bus is in memory.
+	Watch(ctx context.Context) <-chan events.Event
+}
+
+type kitchenService struct {
+	orderRepo  repositories.OrderRepository
+	slotRepo   repositories.SalesSlotRepository
+	publisher  events.Publisher
+	subscriber events.Subscriber
+}
+
+func NewKitchenService(
+	orderRepo repositories.OrderRepository,
+	slotRepo repositories.SalesSlotRepository,
+	publisher events.Publisher,
+	subscriber events.Subscriber,
+) KitchenService {
+	return &kitchenService{
+		orderRepo:  orderRepo,
+		slotRepo:   slotRepo,
+		publisher:  publisher,
+		subscriber: subscriber,
+	}
+}
+
+// itemStation returns the station the item is prepared at.
+func itemStation(item *models.OrderItem) string {
+	if item.Product == nil || item.Product.Category == nil {
+		return ""
+	}
+	return item.Product.Category.Station
+}
+
+func stationItems(items []models.OrderItem, station string) []models.OrderItem {
+	if station == "" {
+		return items
+	}
+	var result []models.OrderItem
+	for _, item := range items {
+		if itemStation(&item) == station {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func (s *kitchenService) GetQueue(ctx context.Context, booth, station string) ([]models.Order, error) {
+	slots, err := s.slotRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var slotIDs []types.ID
+	for _, slot := range slots {
+		if booth == "" || slot.Booth == booth {
+			slotIDs = append(slotIDs, slot.ID)
+		}
+	}
+
+	orders, err := s.orderRepo.FindKitchenQueue(ctx, slotIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := make([]models.Order, 0, len(orders))
+	for _, order := range orders {
+		order.Items = stationItems(order.Items, station)
+		for _, item := range order.Items {
+			if item.PrepStatus != types.READY {
+				queue = append(queue, order)
+				break
+			}
+		}
+	}
+	return queue, nil
+}
+
+func (s *kitchenService) BumpItem(ctx context.Context, itemID types.ID) (*models.OrderItem, error) {
+	item, err := s.orderRepo.FindItemByID(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.orderRepo.FindByID(ctx, item.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != types.CONFIRMED {
+		return nil, ErrOrderNotConfirmed
+	}
+	if item.PrepStatus == types.READY {
+		return nil, ErrAlreadyPrepared
+	}
+
+	item.PrepStatus = item.PrepStatus.Next()
+	if err := s.orderRepo.UpdatePrepStatus(ctx, []types.ID{item.ID}, item.PrepStatus); err != nil {
+		return nil, err
+	}
+	s.publishBump(ctx, order.ID, []models.OrderItem{*item})
+	return item, nil
+}
+
+func (s *kitchenService) BumpOrder(ctx context.Context, orderID types.ID, station string) (*models.Order, error) {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != types.CONFIRMED {
+		return nil, ErrOrderNotConfirmed
+	}
+
+	order.Items = stationItems(order.Items, station)
+
+	least := types.READY
+	for _, item := range order.Items {
+		if item.PrepStatus < least {
+			least = item.PrepStatus
+		}
+	}
+	if least == types.READY {
+		return nil, ErrAlreadyPrepared
+	}
+
+	var ids []types.ID
+	var bumped []models.OrderItem
+	for i := range order.Items {
+		if order.Items[i].PrepStatus == least {
+			order.Items[i].PrepStatus = least.Next()
+			ids = append(ids, order.Items[i].ID)
+			bumped = append(bumped, order.Items[i])
+		}
+	}
+	if err := s.orderRepo.UpdatePrepStatus(ctx, ids, least.Next()); err != nil {
+		return nil, err
+	}
+	s.publishBump(ctx, order.ID, bumped)
+	return order, nil
+}
+
+func (s *kitchenService) publishBump(ctx context.Context, orderID types.ID, items []models.OrderItem) {
+	if s.publisher == nil {
+		return
+	}
+	s.publisher.Publish(ctx, events.Event{
+		Type:       events.PrepStatusChanged,
+		EntityID:   orderID,
+		OccurredAt: time.Now(),
+		Payload:    items,
+	})
+}
+
+func (s *kitchenService) Watch(ctx context.Context) <-chan events.Event {
+	ch := make(chan events.Event, 16)
+	if s.subscriber == nil {
+		go func() {
+			<-ctx.Done()
+			close(ch)
+		}()
+		return ch
+	}
+
+	var mu sync.Mutex
+	closed := false
+	unsubscribe := s.subscriber.Subscribe(func(ctx context.Context, event events.Event) {
+		if event.Type != events.OrderConfirmed && event.Type != events.PrepStatusChanged {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- event:
+		default:
+		}
+	})
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+		mu.Lock()
+		defer mu.Unlock()
+		closed = true
+		close(ch)
+	}()
+	return ch
+}
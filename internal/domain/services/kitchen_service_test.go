@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// mockBus is a synchronous publisher and subscriber.
+type mockBus struct {
+	mu       sync.Mutex
+	handlers map[int]events.Handler
+	next     int
+}
+
+func (b *mockBus) Subscribe(handler events.Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.handlers == nil {
+		b.handlers = make(map[int]events.Handler)
+	}
+	id := b.next
+	b.next++
+	b.handlers[id] = handler
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+func (b *mockBus) Publish(ctx context.Context, event events.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, handler := range b.handlers {
+		handler(ctx, event)
+	}
+}
+
+func TestKitchenService_Queue(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	publisher := &recordingPublisher{}
+	service := NewKitchenService(orderRepo, slotRepo, publisher, nil)
+	ctx := context.Background()
+
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot1", Booth: "A", IsActive: true})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot2", Booth: "B", IsActive: true})
+	grill := &models.Product{ID: "yakisoba", Category: &models.Category{Station: "grill"}}
+	drinks := &models.Product{ID: "ramune", Category: &models.Category{Station: "drinks"}}
+
+	now := time.Now()
+	later := now.Add(time.Minute)
+	orderRepo.Create(ctx, &models.Order{ID: "late", SalesSlotID: "slot1", Status: types.CONFIRMED, ConfirmedAt: &later, Items: []models.OrderItem{
+		{ID: "late-grill", OrderID: "late", ProductID: grill.ID, Product: grill, Quantity: 2, PrepStatus: types.QUEUED},
+		{ID: "late-drinks", OrderID: "late", ProductID: drinks.ID, Product: drinks, Quantity: 1, PrepStatus: types.QUEUED},
+	}})
+	orderRepo.Create(ctx, &models.Order{ID: "early", SalesSlotID: "slot1", Status: types.CONFIRMED, ConfirmedAt: &now, Items: []models.OrderItem{
+		{ID: "early-grill", OrderID: "early", ProductID: grill.ID, Product: grill, Quantity: 1, PrepStatus: types.QUEUED},
+	}})
+	orderRepo.Create(ctx, &models.Order{ID: "reserved", SalesSlotID: "slot1", Status: types.RESERVED, Items: []models.OrderItem{
+		{ID: "reserved-grill", OrderID: "reserved", ProductID: grill.ID, Product: grill, Quantity: 1, PrepStatus: types.QUEUED},
+	}})
+	orderRepo.Create(ctx, &models.Order{ID: "other-booth", SalesSlotID: "slot2", Status: types.CONFIRMED, ConfirmedAt: &now, Items: []models.OrderItem{
+		{ID: "other-grill", OrderID: "other-booth", ProductID: grill.ID, Product: grill, Quantity: 1, PrepStatus: types.QUEUED},
+	}})
+
+	queue, err := service.GetQueue(ctx, "A", "")
+	if err != nil {
+		t.Fatalf("GetQueue failed: %v", err)
+	}
+	if len(queue) != 2 || queue[0].ID != "early" || queue[1].ID != "late" {
+		t.Fatalf("Expected the confirmed orders of booth A, earliest first, got %+v", queue)
+	}
+	queue, _ = service.GetQueue(ctx, "A", "drinks")
+	if len(queue) != 1 || len(queue[0].Items) != 1 || queue[0].Items[0].ID != "late-drinks" {
+		t.Fatalf("Expected only the drinks of the late order, got %+v", queue)
+	}
+
+	for _, want := range []types.PrepStatus{types.PREPARING, types.READY} {
+		item, err := service.BumpItem(ctx, "late-drinks")
+		if err != nil || item.PrepStatus != want {
+			t.Fatalf("Expected the item to be %s, got %+v, %v", want, item, err)
+		}
+	}
+	if _, err := service.BumpItem(ctx, "late-drinks"); err != ErrAlreadyPrepared {
+		t.Errorf("Expected ErrAlreadyPrepared, got %v", err)
+	}
+	if queue, _ := service.GetQueue(ctx, "A", "drinks"); len(queue) != 0 {
+		t.Errorf("Expected the drinks station to be done, got %+v", queue)
+	}
+
+	order, err := service.BumpOrder(ctx, "late", "grill")
+	if err != nil {
+		t.Fatalf("BumpOrder failed: %v", err)
+	}
+	if len(order.Items) != 1 || order.Items[0].PrepStatus != types.PREPARING {
+		t.Errorf("Expected the grill item to be preparing, got %+v", order.Items)
+	}
+	if _, err := service.BumpOrder(ctx, "reserved", ""); err != ErrOrderNotConfirmed {
+		t.Errorf("Expected ErrOrderNotConfirmed, got %v", err)
+	}
+	if len(publisher.events) != 3 || publisher.events[2].Type != events.PrepStatusChanged || publisher.events[2].EntityID != "late" {
+		t.Errorf("Expected three prep status changes, got %+v", publisher.events)
+	}
+}
+
+func TestKitchenService_Watch(t *testing.T) {
+	bus := &mockBus{}
+	service := NewKitchenService(newMockOrderRepository(), newMockSalesSlotRepository(), bus, bus)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	updates := service.Watch(ctx)
+	bus.Publish(ctx, events.Event{Type: events.StockLow, EntityID: "inventory"})
+	bus.Publish(ctx, events.Event{Type: events.OrderConfirmed, EntityID: "order1"})
+	if event := <-updates; event.EntityID != "order1" {
+		t.Errorf("Expected only the confirmed order, got %+v", event)
+	}
+
+	cancel()
+	if _, open := <-updates; open {
+		t.Error("Expected the updates to end with the context")
+	}
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	if len(bus.handlers) != 0 {
+		t.Error("Expected the watcher to unsubscribe")
+	}
+}
@@ -2,32 +2,51 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 )
 
 type OrderService interface {
-	CreateOrder(ctx context.Context, salesSlotID types.ID, items []OrderItemInput) (*models.Order, error)
+	CreateOrder(ctx context.Context, salesSlotID types.ID, customerID string, items []OrderItemInput, couponCodes []string) (*models.Order, error)
 	GetOrder(ctx context.Context, id types.ID) (*models.Order, error)
 	GetAllOrders(ctx context.Context) ([]models.Order, error)
 	GetOrdersByStatus(ctx context.Context, status types.OrderStatus) ([]models.Order, error)
 	UpdateOrderStatus(ctx context.Context, id types.ID, status types.OrderStatus) error
 	CancelOrder(ctx context.Context, id types.ID) error
 	AddOrderItems(ctx context.Context, orderID types.ID, items []OrderItemInput) error
+	UpdateOrderItem(ctx context.Context, orderID, itemID types.ID, quantity int) error
+	RemoveOrderItem(ctx context.Context, orderID, itemID types.ID) error
+	GetOrderItemChanges(ctx context.Context, orderID types.ID) ([]models.OrderItemChange, error)
+	GetTaxReport(ctx context.Context, from, to *time.Time) ([]repositories.TaxSummary, error)
 }
 
 type OrderItemInput struct {
 	ProductID types.ID
 	Quantity  int
+	OptionIDs []types.ID
 }
 
 type orderService struct {
-	orderRepo   repositories.OrderRepository
-	slotRepo    repositories.SalesSlotRepository
-	invRepo     repositories.ProductInventoryRepository
-	productRepo repositories.ProductRepository
+	orderRepo      repositories.OrderRepository
+	slotRepo       repositories.SalesSlotRepository
+	invRepo        repositories.ProductInventoryRepository
+	productRepo    repositories.ProductRepository
+	optionRepo     repositories.ProductOptionGroupRepository
+	promoRepo      repositories.PromotionRepository
+	priceRepo      repositories.ProductPriceRepository
+	ingredientRepo repositories.IngredientRepository
+	taxPolicy      TaxPolicy
+	publisher      events.Publisher
+	alerts         stockAlerts
+	wait           waitEstimator
 }
 
 func NewOrderService(
@@ -35,16 +54,322 @@ func NewOrderService(
 	slotRepo repositories.SalesSlotRepository,
 	invRepo repositories.ProductInventoryRepository,
 	productRepo repositories.ProductRepository,
+	optionRepo repositories.ProductOptionGroupRepository,
+	promoRepo repositories.PromotionRepository,
+	priceRepo repositories.ProductPriceRepository,
+	ingredientRepo repositories.IngredientRepository,
+	taxPolicy TaxPolicy,
+	waitPolicy WaitPolicy,
+	publisher events.Publisher,
 ) OrderService {
 	return &orderService{
-		orderRepo:   orderRepo,
-		slotRepo:    slotRepo,
-		invRepo:     invRepo,
-		productRepo: productRepo,
+		orderRepo:      orderRepo,
+		slotRepo:       slotRepo,
+		invRepo:        invRepo,
+		productRepo:    productRepo,
+		optionRepo:     optionRepo,
+		promoRepo:      promoRepo,
+		priceRepo:      priceRepo,
+		ingredientRepo: ingredientRepo,
+		taxPolicy:      taxPolicy,
+		publisher:      publisher,
+		alerts:         stockAlerts{invRepo: invRepo, publisher: publisher},
+		wait:           waitEstimator{orderRepo: orderRepo, policy: waitPolicy},
 	}
 }
 
-func (s *orderService) CreateOrder(ctx context.Context, salesSlotID types.ID, items []OrderItemInput) (*models.Order, error) {
+func (s *orderService) newOrderItem(ctx context.Context, salesSlotID types.ID, product *models.Product, item OrderItemInput) (models.OrderItem, error) {
+	groups, err := s.optionRepo.FindByProductID(ctx, product.ID)
+	if err != nil {
+		return models.OrderItem{}, err
+	}
+
+	options, err := resolveOptions(groups, item.OptionIDs)
+	if err != nil {
+		return models.OrderItem{}, err
+	}
+
+	price, err := s.slotPrice(ctx, salesSlotID, product)
+	if err != nil {
+		return models.OrderItem{}, err
+	}
+
+	var components []models.OrderItemComponent
+	for _, c := range product.Components {
+		components = append(components, models.OrderItemComponent{
+			ProductID: c.ProductID,
+			Quantity:  c.Quantity,
+		})
+	}
+
+	return models.OrderItem{
+		ProductID:  item.ProductID,
+		Quantity:   item.Quantity,
+		Price:      price,
+		TaxRate:    product.TaxRate,
+		Options:    options,
+		Components: components,
+	}, nil
+}
+
+// slotPrice returns the product's price in the slot: the slot's override when
+// one is set, otherwise the price currently in effect for the product.
+func (s *orderService) slotPrice(ctx context.Context, salesSlotID types.ID, product *models.Product) (int, error) {
+	price, err := effectivePrice(ctx, s.priceRepo, product, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, salesSlotID, product.ID)
+	if err != nil {
+		// Bundles have no inventory of their own; missing stock for other
+		// products is reported by checkInventory.
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return price, nil
+		}
+		return 0, err
+	}
+	return inventory.GetPrice(price), nil
+}
+
+func validateItemInputs(items []OrderItemInput) error {
+	if len(items) == 0 {
+		return ErrNoOrderItems
+	}
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return ErrInvalidQuantity
+		}
+	}
+	return nil
+}
+
+// lineKey identifies order lines that can be merged into one: the same
+// product with the same options at the same price.
+func lineKey(item models.OrderItem) string {
+	optionIDs := make([]string, len(item.Options))
+	for i, opt := range item.Options {
+		optionIDs[i] = string(opt.OptionID)
+	}
+	sort.Strings(optionIDs)
+	return fmt.Sprintf("%s|%s|%d|%d", item.ProductID, strings.Join(optionIDs, ","), item.GetUnitPrice(), item.TaxRate)
+}
+
+// mergeOrderItems combines items with the same line key, keeping the order in
+// which each line first appears.
+func mergeOrderItems(items []models.OrderItem) []models.OrderItem {
+	var merged []models.OrderItem
+	index := make(map[string]int)
+	for _, item := range items {
+		key := lineKey(item)
+		if i, exists := index[key]; exists {
+			merged[i].Quantity += item.Quantity
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// inventoryQuantities sums the stock the items take per product, so that a
+// bundle and a single product sharing a component are checked together.
+func inventoryQuantities(items []models.OrderItem) map[types.ID]int {
+	quantities := make(map[types.ID]int)
+	for _, item := range items {
+		for productID, quantity := range item.InventoryQuantities() {
+			quantities[productID] += quantity
+		}
+	}
+	return quantities
+}
+
+func (s *orderService) checkInventory(ctx context.Context, salesSlotID types.ID, items []models.OrderItem) error {
+	for productID, quantity := range inventoryQuantities(items) {
+		inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, salesSlotID, productID)
+		if err != nil {
+			return err
+		}
+		if inventory.Unavailable {
+			return ErrProductUnavailable
+		}
+		if inventory.GetAvailableQuantity() < quantity {
+			return ErrInsufficientInventory
+		}
+	}
+	return nil
+}
+
+// checkPurchaseLimits checks the stock that items, the order's items after the
+// change being made, take against the slot's per-order and per-customer
+// limits. The customer's other orders in the slot count towards the
+// per-customer limit unless they were cancelled.
+func (s *orderService) checkPurchaseLimits(ctx context.Context, order *models.Order, items []models.OrderItem) error {
+	var purchased map[types.ID]int
+	for productID, quantity := range inventoryQuantities(items) {
+		inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, order.SalesSlotID, productID)
+		if err != nil {
+			return err
+		}
+		name := string(productID)
+		if inventory.Product != nil {
+			name = inventory.Product.Name
+		}
+
+		if inventory.MaxPerOrder != nil && quantity > *inventory.MaxPerOrder {
+			return &PurchaseLimitError{ProductName: name, Limit: *inventory.MaxPerOrder}
+		}
+
+		if inventory.MaxPerCustomer == nil {
+			continue
+		}
+		if order.CustomerID == "" {
+			return ErrCustomerRequired
+		}
+		if purchased == nil {
+			if purchased, err = s.customerQuantities(ctx, order); err != nil {
+				return err
+			}
+		}
+		if purchased[productID]+quantity > *inventory.MaxPerCustomer {
+			return &PurchaseLimitError{
+				ProductName: name,
+				Limit:       *inventory.MaxPerCustomer,
+				Purchased:   purchased[productID],
+				PerCustomer: true,
+			}
+		}
+	}
+	return nil
+}
+
+// customerQuantities sums the stock taken by the customer's other orders in
+// the order's slot.
+func (s *orderService) customerQuantities(ctx context.Context, order *models.Order) (map[types.ID]int, error) {
+	orders, err := s.orderRepo.FindByCustomer(ctx, order.SalesSlotID, order.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []models.OrderItem
+	for _, o := range orders {
+		if o.ID == order.ID || o.Status == types.CANCELLED {
+			continue
+		}
+		items = append(items, o.Items...)
+	}
+	return inventoryQuantities(items), nil
+}
+
+// checkCapacity checks that the slot has room for the given number of new
+// orders and items on top of what has been ordered in it.
+func (s *orderService) checkCapacity(ctx context.Context, slot *models.SalesSlot, orders, items int) error {
+	if slot.MaxOrders == nil && slot.MaxItems == nil {
+		return nil
+	}
+
+	usages, err := s.orderRepo.CountBySalesSlots(ctx, []types.ID{slot.ID})
+	if err != nil {
+		return err
+	}
+	var usage repositories.SlotUsage
+	if len(usages) > 0 {
+		usage = usages[0]
+	}
+
+	if orders > 0 && slot.MaxOrders != nil && usage.Orders+orders > *slot.MaxOrders {
+		return ErrSlotFull
+	}
+	if slot.MaxItems != nil && usage.Items+items > *slot.MaxItems {
+		return ErrSlotItemsFull
+	}
+	return nil
+}
+
+// checkSlotItems checks that the slot has room for the given number of new
+// items on existing orders.
+func (s *orderService) checkSlotItems(ctx context.Context, salesSlotID types.ID, items int) error {
+	slot, err := s.slotRepo.FindByID(ctx, salesSlotID)
+	if err != nil {
+		return err
+	}
+	return s.checkCapacity(ctx, slot, 0, items)
+}
+
+func itemCount(items []models.OrderItem) int {
+	count := 0
+	for _, item := range items {
+		count += item.Quantity
+	}
+	return count
+}
+
+// adjustInventory moves the stock taken by items on the order: its reserved
+// stock by reservedSign and its sold stock by soldSign.
+func (s *orderService) adjustInventory(ctx context.Context, order *models.Order, items []models.OrderItem, reservedSign, soldSign int, reason string) error {
+	movements := orderMovements(order, items, reservedSign, soldSign, reason)
+	return orderWriteError(s.alerts.track(ctx, movements, func() error {
+		return s.invRepo.ApplyMovements(ctx, movements)
+	}))
+}
+
+// orderWriteError reports a stock movement the ledger refused for lack of
+// stock as ErrInsufficientInventory, items the slot no longer had room for
+// as ErrSlotItemsFull, and an order confirmed or cancelled meanwhile as
+// ErrInvalidOrderStatus.
+func orderWriteError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrOrderStatusChanged):
+		return ErrInvalidOrderStatus
+	case errors.Is(err, repositories.ErrStockShortage):
+		return ErrInsufficientInventory
+	case errors.Is(err, repositories.ErrSlotCapacityExceeded):
+		return ErrSlotItemsFull
+	}
+	return err
+}
+
+// orderMovements returns the stock ledger entries that change the reserved
+// stock taken by items on the order by reservedSign and the sold stock by
+// soldSign.
+func orderMovements(order *models.Order, items []models.OrderItem, reservedSign, soldSign int, reason string) []models.StockMovement {
+	quantities := inventoryQuantities(items)
+	productIDs := make([]types.ID, 0, len(quantities))
+	for productID := range quantities {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	orderID := order.ID
+	var movements []models.StockMovement
+	for _, productID := range productIDs {
+		movement := models.StockMovement{
+			SalesSlotID: order.SalesSlotID,
+			ProductID:   productID,
+			Reason:      reason,
+			OrderID:     &orderID,
+		}
+		if q := reservedSign * quantities[productID]; q != 0 {
+			movement.Type, movement.Quantity = types.RESERVATION, q
+			if q < 0 {
+				movement.Type = types.RELEASE
+			}
+			movements = append(movements, movement)
+		}
+		if q := soldSign * quantities[productID]; q != 0 {
+			movement.Type, movement.Quantity = types.SALE, q
+			movements = append(movements, movement)
+		}
+	}
+	return movements
+}
+
+func (s *orderService) CreateOrder(ctx context.Context, salesSlotID types.ID, customerID string, items []OrderItemInput, couponCodes []string) (*models.Order, error) {
+	if err := validateItemInputs(items); err != nil {
+		return nil, err
+	}
+
 	slot, err := s.slotRepo.FindByID(ctx, salesSlotID)
 	if err != nil {
 		return nil, err
@@ -54,51 +379,65 @@ func (s *orderService) CreateOrder(ctx context.Context, salesSlotID types.ID, it
 	}
 
 	var orderItems []models.OrderItem
-	totalAmount := 0
-
 	for _, item := range items {
 		product, err := s.productRepo.FindByID(ctx, item.ProductID)
 		if err != nil {
 			return nil, err
 		}
 
-		inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, salesSlotID, item.ProductID)
+		orderItem, err := s.newOrderItem(ctx, salesSlotID, product, item)
 		if err != nil {
 			return nil, err
 		}
 
-		if inventory.GetAvailableQuantity() < item.Quantity {
-			return nil, ErrInsufficientInventory
-		}
-
-		orderItems = append(orderItems, models.OrderItem{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
-		})
+		orderItems = append(orderItems, orderItem)
+	}
+	orderItems = mergeOrderItems(orderItems)
 
-		totalAmount += product.Price * item.Quantity
+	if err := s.checkInventory(ctx, salesSlotID, orderItems); err != nil {
+		return nil, err
+	}
+	if err := s.checkCapacity(ctx, slot, 1, itemCount(orderItems)); err != nil {
+		return nil, err
 	}
 
 	order := &models.Order{
 		SalesSlotID: salesSlotID,
+		CustomerID:  strings.TrimSpace(customerID),
 		Status:      types.RESERVED,
-		TotalAmount: totalAmount,
+	}
+	if err := s.checkPurchaseLimits(ctx, order, orderItems); err != nil {
+		return nil, err
 	}
 
-	err = s.orderRepo.CreateWithItems(ctx, order, orderItems)
+	order.Discounts, err = applyPromotions(ctx, s.promoRepo, salesSlotID, orderItems, couponCodes, time.Now())
 	if err != nil {
 		return nil, err
 	}
-
-	for _, item := range orderItems {
-		inventory, _ := s.invRepo.FindBySalesSlotAndProduct(ctx, salesSlotID, item.ProductID)
-		err = s.invRepo.UpdateQuantities(ctx, inventory.ID, inventory.ReservedQuantity+item.Quantity, inventory.SoldQuantity)
-		if err != nil {
-			return nil, err
-		}
+	s.taxPolicy.apply(order, orderItems)
+
+	// The slot's capacity is checked again as the order is written, in case
+	// other orders took it in the meantime.
+	movements := orderMovements(order, orderItems, 1, 0, "注文")
+	err = s.alerts.track(ctx, movements, func() error {
+		return s.orderRepo.CreateWithItems(ctx, order, orderItems, movements)
+	})
+	if errors.Is(err, repositories.ErrSlotCapacityExceeded) {
+		return nil, ErrSlotFull
+	}
+	// Coupons are counted against their limit again as the order is written.
+	if errors.Is(err, repositories.ErrPromotionUsedUp) {
+		return nil, ErrCouponUsedUp
+	}
+	if err != nil {
+		return nil, orderWriteError(err)
 	}
 
+	// The order is already placed, so failing to estimate only leaves the
+	// estimate out.
+	if wait, err := s.wait.estimateNew(ctx, salesSlotID, orderItems); err == nil {
+		order.EstimatedWait = &wait
+	}
 	return order, nil
 }
 
@@ -133,29 +472,41 @@ func (s *orderService) UpdateOrderStatus(ctx context.Context, id types.ID, statu
 		return ErrInvalidOrderStatus
 	}
 
+	// The status only changes if no one else confirmed or cancelled the
+	// order first, together with its stock and ingredients.
+	var movements []models.StockMovement
+	var booth string
+	var ingredients map[types.ID]float64
 	if status == types.CONFIRMED {
-		for _, item := range order.Items {
-			inventory, _ := s.invRepo.FindBySalesSlotAndProduct(ctx, order.SalesSlotID, item.ProductID)
-			err = s.invRepo.UpdateQuantities(ctx, inventory.ID,
-				inventory.ReservedQuantity-item.Quantity,
-				inventory.SoldQuantity+item.Quantity)
-			if err != nil {
-				return err
-			}
-		}
-	} else if status == types.CANCELLED {
-		for _, item := range order.Items {
-			inventory, _ := s.invRepo.FindBySalesSlotAndProduct(ctx, order.SalesSlotID, item.ProductID)
-			err = s.invRepo.UpdateQuantities(ctx, inventory.ID,
-				inventory.ReservedQuantity-item.Quantity,
-				inventory.SoldQuantity)
-			if err != nil {
-				return err
-			}
+		movements = orderMovements(order, order.Items, -1, 1, "注文の確定")
+		booth, ingredients, err = s.ingredientsUsed(ctx, order)
+		if err != nil {
+			return err
 		}
+	} else {
+		movements = orderMovements(order, order.Items, -1, 0, "注文の取消")
 	}
-
-	return s.orderRepo.UpdateStatus(ctx, id, status)
+	err = s.alerts.track(ctx, movements, func() error {
+		return s.orderRepo.UpdateReservedStatus(ctx, id, status, movements, booth, ingredients)
+	})
+	if errors.Is(err, repositories.ErrOrderStatusChanged) {
+		return ErrInvalidOrderStatus
+	}
+	if err != nil {
+		return orderWriteError(err)
+	}
+	if status == types.CONFIRMED && s.publisher != nil {
+		now := time.Now()
+		order.Status = status
+		order.ConfirmedAt = &now
+		s.publisher.Publish(ctx, events.Event{
+			Type:       events.OrderConfirmed,
+			EntityID:   order.ID,
+			OccurredAt: now,
+			Payload:    order,
+		})
+	}
+	return nil
 }
 
 func (s *orderService) CancelOrder(ctx context.Context, id types.ID) error {
@@ -163,6 +514,10 @@ func (s *orderService) CancelOrder(ctx context.Context, id types.ID) error {
 }
 
 func (s *orderService) AddOrderItems(ctx context.Context, orderID types.ID, items []OrderItemInput) error {
+	if err := validateItemInputs(items); err != nil {
+		return err
+	}
+
 	order, err := s.orderRepo.FindByID(ctx, orderID)
 	if err != nil {
 		return err
@@ -173,48 +528,163 @@ func (s *orderService) AddOrderItems(ctx context.Context, orderID types.ID, item
 	}
 
 	var orderItems []models.OrderItem
-	additionalAmount := 0
-
 	for _, item := range items {
 		product, err := s.productRepo.FindByID(ctx, item.ProductID)
 		if err != nil {
 			return err
 		}
 
-		inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, order.SalesSlotID, item.ProductID)
+		orderItem, err := s.newOrderItem(ctx, order.SalesSlotID, product, item)
 		if err != nil {
 			return err
 		}
+		orderItem.OrderID = orderID
 
-		if inventory.GetAvailableQuantity() < item.Quantity {
-			return ErrInsufficientInventory
+		orderItems = append(orderItems, orderItem)
+	}
+	orderItems = mergeOrderItems(orderItems)
+
+	if err := s.checkInventory(ctx, order.SalesSlotID, orderItems); err != nil {
+		return err
+	}
+	if err := s.checkSlotItems(ctx, order.SalesSlotID, itemCount(orderItems)); err != nil {
+		return err
+	}
+
+	// Lines matching an existing one add to its quantity instead of
+	// becoming a new line.
+	allItems := append([]models.OrderItem{}, order.Items...)
+	existing := make(map[string]int)
+	for i, item := range allItems {
+		existing[lineKey(item)] = i
+	}
+	var newItems []models.OrderItem
+	var updated []int
+	for _, item := range orderItems {
+		if i, exists := existing[lineKey(item)]; exists {
+			allItems[i].Quantity += item.Quantity
+			updated = append(updated, i)
+			continue
 		}
+		newItems = append(newItems, item)
+	}
+	allItems = append(allItems, newItems...)
+	if err := s.checkPurchaseLimits(ctx, order, allItems); err != nil {
+		return err
+	}
+	order.Discounts, err = reapplyDiscounts(ctx, s.promoRepo, order.Discounts, allItems)
+	if err != nil {
+		return err
+	}
+	s.taxPolicy.apply(order, allItems)
 
-		orderItems = append(orderItems, models.OrderItem{
-			OrderID:   orderID,
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     product.Price,
-		})
+	updatedItems := make([]models.OrderItem, 0, len(updated))
+	for _, i := range updated {
+		updatedItems = append(updatedItems, allItems[i])
+	}
+	movements := orderMovements(order, orderItems, 1, 0, "注文への追加")
+	return orderWriteError(s.alerts.track(ctx, movements, func() error {
+		return s.orderRepo.AddItems(ctx, order, updatedItems, newItems, movements)
+	}))
+}
 
-		additionalAmount += product.Price * item.Quantity
+func (s *orderService) UpdateOrderItem(ctx context.Context, orderID, itemID types.ID, quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
 	}
+	return s.changeItemQuantity(ctx, orderID, itemID, quantity)
+}
 
-	err = s.orderRepo.AddItems(ctx, orderID, orderItems)
+func (s *orderService) RemoveOrderItem(ctx context.Context, orderID, itemID types.ID) error {
+	return s.changeItemQuantity(ctx, orderID, itemID, 0)
+}
+
+// changeItemQuantity sets the quantity of an item on a reserved order, or
+// removes the item when quantity is 0. Reserved stock moves by the
+// difference and the order's discounts are worked out again for its new
+// items.
+func (s *orderService) changeItemQuantity(ctx context.Context, orderID, itemID types.ID, quantity int) error {
+	order, err := s.orderRepo.FindByID(ctx, orderID)
 	if err != nil {
 		return err
 	}
+	if order.Status != types.RESERVED {
+		return ErrInvalidOrderStatus
+	}
 
-	for _, item := range orderItems {
-		inventory, _ := s.invRepo.FindBySalesSlotAndProduct(ctx, order.SalesSlotID, item.ProductID)
-		err = s.invRepo.UpdateQuantities(ctx, inventory.ID,
-			inventory.ReservedQuantity+item.Quantity,
-			inventory.SoldQuantity)
-		if err != nil {
+	index := -1
+	for i, item := range order.Items {
+		if item.ID == itemID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return ErrOrderItemNotFound
+	}
+	item := order.Items[index]
+	if quantity == 0 && len(order.Items) == 1 {
+		return ErrEmptyOrder
+	}
+	if quantity == item.Quantity {
+		return nil
+	}
+
+	delta := item
+	delta.Quantity = quantity - item.Quantity
+
+	var items []models.OrderItem
+	for i, it := range order.Items {
+		if i == index {
+			if quantity == 0 {
+				continue
+			}
+			it.Quantity = quantity
+		}
+		items = append(items, it)
+	}
+
+	if delta.Quantity > 0 {
+		if err := s.checkInventory(ctx, order.SalesSlotID, []models.OrderItem{delta}); err != nil {
+			return err
+		}
+		if err := s.checkPurchaseLimits(ctx, order, items); err != nil {
+			return err
+		}
+		if err := s.checkSlotItems(ctx, order.SalesSlotID, delta.Quantity); err != nil {
 			return err
 		}
 	}
 
-	order.TotalAmount += additionalAmount
-	return s.orderRepo.Update(ctx, order)
+	change := &models.OrderItemChange{
+		OrderID:     orderID,
+		OrderItemID: itemID,
+		ProductID:   item.ProductID,
+		UnitPrice:   item.GetUnitPrice(),
+		OldQuantity: item.Quantity,
+		NewQuantity: quantity,
+	}
+
+	order.Discounts, err = reapplyDiscounts(ctx, s.promoRepo, order.Discounts, items)
+	if err != nil {
+		return err
+	}
+	s.taxPolicy.apply(order, items)
+	order.Items = items
+
+	movements := orderMovements(order, []models.OrderItem{delta}, 1, 0, "注文数の変更")
+	return orderWriteError(s.alerts.track(ctx, movements, func() error {
+		return s.orderRepo.ChangeItemQuantity(ctx, order, change, movements)
+	}))
+}
+
+func (s *orderService) GetOrderItemChanges(ctx context.Context, orderID types.ID) ([]models.OrderItemChange, error) {
+	if _, err := s.orderRepo.FindByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.orderRepo.FindItemChanges(ctx, orderID)
+}
+
+func (s *orderService) GetTaxReport(ctx context.Context, from, to *time.Time) ([]repositories.TaxSummary, error) {
+	return s.orderRepo.SummarizeTaxes(ctx, from, to)
 }
@@ -2,7 +2,12 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
@@ -10,7 +15,21 @@ import (
 )
 
 type mockOrderRepository struct {
-	orders map[types.ID]*models.Order
+	orders  map[types.ID]*models.Order
+	changes []models.OrderItemChange
+	// inventories receives the stock movements made by CreateWithItems,
+	// ChangeItemQuantity, AddItems and UpdateReservedStatus.
+	inventories *mockInventoryRepository
+	// ingredients receives the ingredients used by UpdateReservedStatus.
+	ingredients *mockIngredientRepository
+	// statusChanged marks orders confirmed or cancelled by another request
+	// after they were read, so item changes to them are refused.
+	statusChanged map[types.ID]bool
+	// slots holds the capacity CreateWithItems checks new orders against
+	// and the booths FindReservedItemsByBooth looks in.
+	slots *mockSalesSlotRepository
+	// promotions holds the usage CreateWithItems checks discounts against.
+	promotions *mockPromotionRepository
 }
 
 func newMockOrderRepository() *mockOrderRepository {
@@ -75,36 +94,324 @@ func (r *mockOrderRepository) FindByStatus(ctx context.Context, status types.Ord
 	return orders, nil
 }
 
+func (r *mockOrderRepository) FindByCustomer(ctx context.Context, salesSlotID types.ID, customerID string) ([]models.Order, error) {
+	var orders []models.Order
+	for _, o := range r.orders {
+		if o.SalesSlotID == salesSlotID && o.CustomerID == customerID {
+			orders = append(orders, *o)
+		}
+	}
+	return orders, nil
+}
+
+func (r *mockOrderRepository) FindReservedItemsByBooth(ctx context.Context, booth string) ([]models.OrderItem, error) {
+	if r.slots == nil {
+		return nil, nil
+	}
+	var items []models.OrderItem
+	for _, o := range r.orders {
+		if o.Status != types.RESERVED {
+			continue
+		}
+		slot, err := r.slots.FindByID(ctx, o.SalesSlotID)
+		if err == nil && slot.Booth == booth {
+			items = append(items, o.Items...)
+		}
+	}
+	return items, nil
+}
+
+func (r *mockOrderRepository) CountBySalesSlots(ctx context.Context, salesSlotIDs []types.ID) ([]repositories.SlotUsage, error) {
+	var usages []repositories.SlotUsage
+	for _, id := range salesSlotIDs {
+		usage := repositories.SlotUsage{SalesSlotID: id}
+		for _, o := range r.orders {
+			if o.SalesSlotID != id || o.Status == types.CANCELLED {
+				continue
+			}
+			usage.Orders++
+			for _, item := range o.Items {
+				usage.Items += item.Quantity
+			}
+		}
+		if usage.Orders > 0 {
+			usages = append(usages, usage)
+		}
+	}
+	return usages, nil
+}
+
 func (r *mockOrderRepository) UpdateStatus(ctx context.Context, id types.ID, status types.OrderStatus) error {
 	order, exists := r.orders[id]
 	if !exists {
 		return repositories.NewErrNotFound("Order", id)
 	}
 	order.Status = status
+	if status == types.CONFIRMED {
+		now := time.Now()
+		order.ConfirmedAt = &now
+	}
 	return nil
 }
 
-func (r *mockOrderRepository) AddItems(ctx context.Context, orderID types.ID, items []models.OrderItem) error {
-	order, exists := r.orders[orderID]
+func (r *mockOrderRepository) UpdateReservedStatus(ctx context.Context, id types.ID, status types.OrderStatus, movements []models.StockMovement, booth string, ingredients map[types.ID]float64) error {
+	order, exists := r.orders[id]
+	if !exists || order.Status != types.RESERVED {
+		return repositories.ErrOrderStatusChanged
+	}
+	if r.inventories != nil {
+		if err := r.inventories.ApplyMovements(ctx, movements); err != nil {
+			return err
+		}
+	}
+	if r.ingredients != nil {
+		r.ingredients.consume(booth, ingredients)
+	}
+	order.Status = status
+	if status == types.CONFIRMED {
+		now := time.Now()
+		order.ConfirmedAt = &now
+	}
+	return nil
+}
+
+func (r *mockOrderRepository) AddItems(ctx context.Context, order *models.Order, updated, added []models.OrderItem, movements []models.StockMovement) error {
+	stored, exists := r.orders[order.ID]
 	if !exists {
-		return repositories.NewErrNotFound("Order", orderID)
+		return repositories.NewErrNotFound("Order", order.ID)
+	}
+	if r.statusChanged[order.ID] {
+		return repositories.ErrOrderStatusChanged
+	}
+	if r.inventories != nil {
+		if err := r.inventories.ApplyMovements(ctx, movements); err != nil {
+			return err
+		}
+	}
+	items := append([]models.OrderItem{}, stored.Items...)
+	for _, item := range updated {
+		for i := range items {
+			if items[i].ID == item.ID {
+				items[i].Quantity = item.Quantity
+			}
+		}
+	}
+	order.Items = append(items, added...)
+	r.orders[order.ID] = order
+	return nil
+}
+
+func (r *mockOrderRepository) SummarizeTaxes(ctx context.Context, from, to *time.Time) ([]repositories.TaxSummary, error) {
+	totals := make(map[types.TaxRate]*repositories.TaxSummary)
+	for _, o := range r.orders {
+		if o.Status != types.CONFIRMED {
+			continue
+		}
+		for _, t := range o.Taxes {
+			summary, exists := totals[t.Rate]
+			if !exists {
+				summary = &repositories.TaxSummary{Rate: t.Rate}
+				totals[t.Rate] = summary
+			}
+			summary.Orders++
+			summary.SalesAmount += t.TaxableAmount
+			if o.TaxMode == types.TAX_EXCLUSIVE {
+				summary.SalesAmount += t.TaxAmount
+			}
+			summary.TaxAmount += t.TaxAmount
+		}
+	}
+
+	var summaries []repositories.TaxSummary
+	for _, summary := range totals {
+		summaries = append(summaries, *summary)
 	}
-	order.Items = append(order.Items, items...)
+	return summaries, nil
+}
+
+func (r *mockOrderRepository) ChangeItemQuantity(ctx context.Context, order *models.Order, change *models.OrderItemChange, movements []models.StockMovement) error {
+	if r.statusChanged[order.ID] {
+		return repositories.ErrOrderStatusChanged
+	}
+	if r.inventories != nil {
+		if err := r.inventories.ApplyMovements(ctx, movements); err != nil {
+			return err
+		}
+	}
+	r.orders[order.ID] = order
+	r.changes = append(r.changes, *change)
 	return nil
 }
 
-func (r *mockOrderRepository) CreateWithItems(ctx context.Context, order *models.Order, items []models.OrderItem) error {
+func (r *mockOrderRepository) FindItemChanges(ctx context.Context, orderID types.ID) ([]models.OrderItemChange, error) {
+	var changes []models.OrderItemChange
+	for _, c := range r.changes {
+		if c.OrderID == orderID {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+func (r *mockOrderRepository) CreateWithItems(ctx context.Context, order *models.Order, items []models.OrderItem, movements []models.StockMovement) error {
+	if r.slots != nil {
+		slot, err := r.slots.FindByID(ctx, order.SalesSlotID)
+		if err != nil {
+			return err
+		}
+		usages, _ := r.CountBySalesSlots(ctx, []types.ID{slot.ID})
+		if slot.MaxOrders != nil && len(usages) > 0 && usages[0].Orders >= *slot.MaxOrders {
+			return repositories.ErrSlotCapacityExceeded
+		}
+	}
+	if r.promotions != nil {
+		for _, d := range order.Discounts {
+			p, exists := r.promotions.promotions[d.PromotionID]
+			if exists && p.UsageLimit != nil && r.promotions.usage[p.ID] >= *p.UsageLimit {
+				return repositories.ErrPromotionUsedUp
+			}
+		}
+	}
+	if order.ID == "" {
+		order.ID = types.ID(fmt.Sprintf("order%d", len(r.orders)+1))
+	}
+	if r.inventories != nil {
+		for i := range movements {
+			movements[i].OrderID = &order.ID
+		}
+		if err := r.inventories.ApplyMovements(ctx, movements); err != nil {
+			return err
+		}
+	}
 	order.Items = items
 	r.orders[order.ID] = order
 	return nil
 }
 
+func (r *mockOrderRepository) FindKitchenQueue(ctx context.Context, salesSlotIDs []types.ID) ([]models.Order, error) {
+	var orders []models.Order
+	for _, o := range r.orders {
+		if o.Status != types.CONFIRMED || !slices.Contains(salesSlotIDs, o.SalesSlotID) {
+			continue
+		}
+		for _, item := range o.Items {
+			if item.PrepStatus != types.READY {
+				orders = append(orders, *o)
+				break
+			}
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ConfirmedAt.Before(*orders[j].ConfirmedAt) })
+	return orders, nil
+}
+
+func (r *mockOrderRepository) FindItemByID(ctx context.Context, id types.ID) (*models.OrderItem, error) {
+	for _, order := range r.orders {
+		for i := range order.Items {
+			if order.Items[i].ID == id {
+				item := order.Items[i]
+				return &item, nil
+			}
+		}
+	}
+	return nil, repositories.NewErrNotFound("OrderItem", id)
+}
+
+func (r *mockOrderRepository) UpdatePrepStatus(ctx context.Context, itemIDs []types.ID, status types.PrepStatus) error {
+	for _, order := range r.orders {
+		for i := range order.Items {
+			if slices.Contains(itemIDs, order.Items[i].ID) {
+				now := time.Now()
+				order.Items[i].PrepStatus = status
+				switch status {
+				case types.PREPARING:
+					order.Items[i].PrepStartedAt = &now
+				case types.READY:
+					order.Items[i].ReadyAt = &now
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *mockOrderRepository) FindUndelivered(ctx context.Context, salesSlotIDs []types.ID) ([]models.Order, error) {
+	var orders []models.Order
+	for _, o := range r.orders {
+		if o.Status == types.CONFIRMED && slices.Contains(salesSlotIDs, o.SalesSlotID) && o.Ticket != nil && !o.Ticket.IsDelivered {
+			orders = append(orders, *o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool { return orders[i].ConfirmedAt.Before(*orders[j].ConfirmedAt) })
+	return orders, nil
+}
+
+func (r *mockOrderRepository) FindPrepTimes(ctx context.Context, salesSlotID types.ID, window int) (*repositories.PrepTimes, error) {
+	type prepared struct {
+		productID types.ID
+		inSlot    bool
+		readyAt   time.Time
+		took      time.Duration
+	}
+	var items []prepared
+	for _, o := range r.orders {
+		for _, item := range o.Items {
+			if item.PrepStartedAt != nil && item.ReadyAt != nil {
+				items = append(items, prepared{item.ProductID, o.SalesSlotID == salesSlotID, *item.ReadyAt, item.ReadyAt.Sub(*item.PrepStartedAt)})
+			}
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].readyAt.After(items[j].readyAt) })
+
+	// Products the slot has prepared only use its items; others use every
+	// slot's.
+	type average struct {
+		total time.Duration
+		count int
+	}
+	inSlot := make(map[types.ID]*average)
+	anySlot := make(map[types.ID]*average)
+	add := func(averages map[types.ID]*average, item prepared) {
+		a := averages[item.productID]
+		if a == nil {
+			a = &average{}
+			averages[item.productID] = a
+		}
+		if a.count < window {
+			a.total += item.took
+			a.count++
+		}
+	}
+	times := &repositories.PrepTimes{ByProduct: make(map[types.ID]time.Duration)}
+	slotCount := 0
+	for _, item := range items {
+		add(anySlot, item)
+		if item.inSlot {
+			add(inSlot, item)
+			if slotCount < window {
+				times.Slot += item.took
+				slotCount++
+			}
+		}
+	}
+	for id, a := range anySlot {
+		if s, exists := inSlot[id]; exists {
+			a = s
+		}
+		times.ByProduct[id] = a.total / time.Duration(a.count)
+	}
+	if slotCount > 0 {
+		times.Slot /= time.Duration(slotCount)
+	}
+	return times, nil
+}
+
 func TestOrderService_CreateOrder(t *testing.T) {
 	orderRepo := newMockOrderRepository()
 	slotRepo := newMockSalesSlotRepository()
 	invRepo := newMockInventoryRepository()
 	prodRepo := newMockProductRepository()
-	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo)
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
 	ctx := context.Background()
 
 	// Create test data
@@ -137,7 +444,7 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		},
 	}
 
-	order, err := service.CreateOrder(ctx, slot.ID, items)
+	order, err := service.CreateOrder(ctx, slot.ID, "", items, nil)
 	if err != nil {
 		t.Errorf("CreateOrder failed: %v", err)
 	}
@@ -160,7 +467,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 	slotRepo := newMockSalesSlotRepository()
 	invRepo := newMockInventoryRepository()
 	prodRepo := newMockProductRepository()
-	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo)
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
 	ctx := context.Background()
 
 	// Create test data
@@ -192,7 +499,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		},
 	}
 
-	order, _ := service.CreateOrder(ctx, slot.ID, items)
+	order, _ := service.CreateOrder(ctx, slot.ID, "", items, nil)
 
 	// Test order cancellation
 	err := service.CancelOrder(ctx, order.ID)
@@ -205,3 +512,763 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		t.Errorf("Expected order status %v, got %v", types.CANCELLED, order.Status)
 	}
 }
+
+func TestOrderService_BundleReservesComponents(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	orderRepo.inventories = invRepo
+	prodRepo := newMockProductRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	yakisoba := &models.Product{ID: types.ID("yakisoba"), Name: "Yakisoba", Price: 400}
+	drink := &models.Product{ID: types.ID("drink"), Name: "Drink", Price: 150}
+	bundle := &models.Product{
+		ID:       types.ID("set"),
+		Name:     "Yakisoba set",
+		Price:    500,
+		IsBundle: true,
+		Components: []models.BundleComponent{
+			{BundleID: "set", ProductID: yakisoba.ID, Quantity: 1},
+			{BundleID: "set", ProductID: drink.ID, Quantity: 2},
+		},
+	}
+	prodRepo.Create(ctx, yakisoba)
+	prodRepo.Create(ctx, drink)
+	prodRepo.Create(ctx, bundle)
+
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv-yakisoba"), SalesSlotID: slot.ID, ProductID: yakisoba.ID, InitialQuantity: 5})
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv-drink"), SalesSlotID: slot.ID, ProductID: drink.ID, InitialQuantity: 5})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: bundle.ID, Quantity: 2},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	if order.TotalAmount != 1000 {
+		t.Errorf("Expected total amount 1000, got %d", order.TotalAmount)
+	}
+
+	yakisobaInv, _ := invRepo.FindBySalesSlotAndProduct(ctx, slot.ID, yakisoba.ID)
+	drinkInv, _ := invRepo.FindBySalesSlotAndProduct(ctx, slot.ID, drink.ID)
+	if yakisobaInv.ReservedQuantity != 2 || drinkInv.ReservedQuantity != 4 {
+		t.Errorf("Expected reserved 2 yakisoba and 4 drinks, got %d and %d", yakisobaInv.ReservedQuantity, drinkInv.ReservedQuantity)
+	}
+
+	// A single drink and a set together need 3 drinks but only 1 is left
+	_, err = service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: drink.ID, Quantity: 1},
+		{ProductID: bundle.ID, Quantity: 1},
+	}, nil)
+	if err != ErrInsufficientInventory {
+		t.Errorf("Expected ErrInsufficientInventory, got %v", err)
+	}
+
+	if err := service.UpdateOrderStatus(ctx, order.ID, types.CONFIRMED); err != nil {
+		t.Fatalf("UpdateOrderStatus failed: %v", err)
+	}
+
+	drinkInv, _ = invRepo.FindBySalesSlotAndProduct(ctx, slot.ID, drink.ID)
+	if drinkInv.ReservedQuantity != 0 || drinkInv.SoldQuantity != 4 {
+		t.Errorf("Expected 4 drinks sold, got reserved %d sold %d", drinkInv.ReservedQuantity, drinkInv.SoldQuantity)
+	}
+}
+
+func TestOrderService_CreateOrderWithCoupon(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	promoRepo := newMockPromotionRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), promoRepo, newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	product := &models.Product{ID: types.ID("prod1"), Name: "Test Product", Price: 1000}
+	prodRepo.Create(ctx, product)
+
+	invRepo.Create(ctx, &models.ProductInventory{
+		ID:              types.ID("inv1"),
+		SalesSlotID:     slot.ID,
+		ProductID:       product.ID,
+		InitialQuantity: 10,
+	})
+
+	code := "TEACHER"
+	promoRepo.Create(ctx, &models.Promotion{
+		ID:       types.ID("teacher"),
+		Name:     "Teacher discount",
+		Type:     types.FIXED_AMOUNT,
+		Value:    300,
+		Code:     &code,
+		IsActive: true,
+	})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: product.ID, Quantity: 2},
+	}, []string{"teacher"})
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	if order.TotalAmount != 1700 {
+		t.Errorf("Expected total amount 1700, got %d", order.TotalAmount)
+	}
+
+	if len(order.Discounts) != 1 || order.Discounts[0].Amount != 300 {
+		t.Errorf("Expected one discount of 300, got %v", order.Discounts)
+	}
+}
+
+func TestOrderService_CreateOrderUsesEffectivePrice(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	priceRepo := newMockProductPriceRepository()
+	service := NewOrderService(newMockOrderRepository(), slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), priceRepo, newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	product := &models.Product{ID: types.ID("prod1"), Name: "Test Product", Price: 1000}
+	prodRepo.Create(ctx, product)
+
+	invRepo.Create(ctx, &models.ProductInventory{
+		ID:              types.ID("inv1"),
+		SalesSlotID:     slot.ID,
+		ProductID:       product.ID,
+		InitialQuantity: 10,
+	})
+
+	// A change that has come into effect but not yet been applied to the product
+	priceRepo.Create(ctx, &models.ProductPrice{
+		ID:            types.ID("price1"),
+		ProductID:     product.ID,
+		Price:         800,
+		EffectiveFrom: time.Now().Add(-time.Minute),
+	})
+	priceRepo.Create(ctx, &models.ProductPrice{
+		ID:            types.ID("price2"),
+		ProductID:     product.ID,
+		Price:         600,
+		EffectiveFrom: time.Now().Add(time.Hour),
+	})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: product.ID, Quantity: 2},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	if order.TotalAmount != 1600 {
+		t.Errorf("Expected total amount 1600, got %d", order.TotalAmount)
+	}
+}
+
+func TestOrderService_SlotPriceOverride(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	service := NewOrderService(newMockOrderRepository(), slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	yakisoba := &models.Product{ID: types.ID("yakisoba"), Name: "Yakisoba", Price: 400}
+	drink := &models.Product{ID: types.ID("drink"), Name: "Drink", Price: 150}
+	prodRepo.Create(ctx, yakisoba)
+	prodRepo.Create(ctx, drink)
+
+	slotPrice := 300
+	invRepo.Create(ctx, &models.ProductInventory{
+		ID:              types.ID("inv1"),
+		SalesSlotID:     slot.ID,
+		ProductID:       yakisoba.ID,
+		InitialQuantity: 10,
+		Price:           &slotPrice,
+	})
+	invRepo.Create(ctx, &models.ProductInventory{
+		ID:              types.ID("inv2"),
+		SalesSlotID:     slot.ID,
+		ProductID:       drink.ID,
+		InitialQuantity: 10,
+	})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: yakisoba.ID, Quantity: 2},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if order.TotalAmount != 600 {
+		t.Errorf("Expected total amount 600, got %d", order.TotalAmount)
+	}
+
+	err = service.AddOrderItems(ctx, order.ID, []OrderItemInput{
+		{ProductID: yakisoba.ID, Quantity: 1},
+		{ProductID: drink.ID, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("AddOrderItems failed: %v", err)
+	}
+
+	updated, _ := service.GetOrder(ctx, order.ID)
+	if updated.TotalAmount != 1050 {
+		t.Errorf("Expected total amount 1050, got %d", updated.TotalAmount)
+	}
+}
+
+func TestOrderService_ChangeItemQuantity(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	orderRepo.inventories = invRepo
+	prodRepo := newMockProductRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	yakisoba := &models.Product{ID: types.ID("yakisoba"), Name: "Yakisoba", Price: 400}
+	drink := &models.Product{ID: types.ID("drink"), Name: "Drink", Price: 150}
+	prodRepo.Create(ctx, yakisoba)
+	prodRepo.Create(ctx, drink)
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: yakisoba.ID, InitialQuantity: 5})
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv2"), SalesSlotID: slot.ID, ProductID: drink.ID, InitialQuantity: 5})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: yakisoba.ID, Quantity: 2},
+		{ProductID: drink.ID, Quantity: 1},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	for i := range order.Items {
+		order.Items[i].ID = order.Items[i].ProductID
+	}
+
+	if err := service.UpdateOrderItem(ctx, order.ID, yakisoba.ID, 0); err != ErrInvalidQuantity {
+		t.Errorf("Expected ErrInvalidQuantity, got %v", err)
+	}
+	if err := service.UpdateOrderItem(ctx, order.ID, yakisoba.ID, 6); err != ErrInsufficientInventory {
+		t.Errorf("Expected ErrInsufficientInventory, got %v", err)
+	}
+
+	if err := service.UpdateOrderItem(ctx, order.ID, yakisoba.ID, 4); err != nil {
+		t.Fatalf("UpdateOrderItem failed: %v", err)
+	}
+	inv, _ := invRepo.FindBySalesSlotAndProduct(ctx, slot.ID, yakisoba.ID)
+	if inv.ReservedQuantity != 4 {
+		t.Errorf("Expected 4 yakisoba reserved, got %d", inv.ReservedQuantity)
+	}
+	updated, _ := service.GetOrder(ctx, order.ID)
+	if updated.TotalAmount != 1750 {
+		t.Errorf("Expected total amount 1750, got %d", updated.TotalAmount)
+	}
+
+	if err := service.RemoveOrderItem(ctx, order.ID, drink.ID); err != nil {
+		t.Fatalf("RemoveOrderItem failed: %v", err)
+	}
+	inv, _ = invRepo.FindBySalesSlotAndProduct(ctx, slot.ID, drink.ID)
+	if inv.ReservedQuantity != 0 {
+		t.Errorf("Expected no drinks reserved, got %d", inv.ReservedQuantity)
+	}
+	updated, _ = service.GetOrder(ctx, order.ID)
+	if len(updated.Items) != 1 || updated.TotalAmount != 1600 {
+		t.Errorf("Expected 1 item totalling 1600, got %d items totalling %d", len(updated.Items), updated.TotalAmount)
+	}
+
+	if err := service.RemoveOrderItem(ctx, order.ID, yakisoba.ID); err != ErrEmptyOrder {
+		t.Errorf("Expected ErrEmptyOrder, got %v", err)
+	}
+	if err := service.RemoveOrderItem(ctx, order.ID, drink.ID); err != ErrOrderItemNotFound {
+		t.Errorf("Expected ErrOrderItemNotFound, got %v", err)
+	}
+
+	changes, _ := service.GetOrderItemChanges(ctx, order.ID)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(changes))
+	}
+	if changes[0].OldQuantity != 2 || changes[0].NewQuantity != 4 || changes[1].NewQuantity != 0 {
+		t.Errorf("Unexpected change history: %+v", changes)
+	}
+
+	// The order is confirmed by another request after it was read.
+	orderRepo.statusChanged = map[types.ID]bool{order.ID: true}
+	if err := service.UpdateOrderItem(ctx, order.ID, yakisoba.ID, 3); err != ErrInvalidOrderStatus {
+		t.Errorf("Expected ErrInvalidOrderStatus, got %v", err)
+	}
+	inv, _ = invRepo.FindBySalesSlotAndProduct(ctx, slot.ID, yakisoba.ID)
+	if inv.ReservedQuantity != 4 {
+		t.Errorf("Expected 4 yakisoba still reserved, got %d", inv.ReservedQuantity)
+	}
+}
+
+func TestOrderService_ChangeItemQuantityReappliesDiscounts(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	orderRepo.inventories = invRepo
+	prodRepo := newMockProductRepository()
+	promoRepo := newMockPromotionRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), promoRepo, newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	yakisoba := &models.Product{ID: types.ID("yakisoba"), Name: "Yakisoba", Price: 400}
+	prodRepo.Create(ctx, yakisoba)
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: yakisoba.ID, InitialQuantity: 10})
+
+	promoRepo.Create(ctx, &models.Promotion{
+		ID:           types.ID("buy2get1"),
+		Name:         "Buy 2 get 1",
+		Type:         types.BUY_X_GET_Y,
+		BuyQuantity:  2,
+		FreeQuantity: 1,
+		ProductID:    &yakisoba.ID,
+		IsActive:     true,
+	})
+	promoRepo.Create(ctx, &models.Promotion{
+		ID:        types.ID("bulk"),
+		Name:      "Bulk discount",
+		Type:      types.FIXED_AMOUNT,
+		Value:     300,
+		MinAmount: 1000,
+		IsActive:  true,
+	})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: yakisoba.ID, Quantity: 4},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	order.Items[0].ID = yakisoba.ID
+	// 1600 less one free yakisoba and the bulk discount.
+	if len(order.Discounts) != 2 || order.TotalAmount != 900 {
+		t.Fatalf("Expected 2 discounts totalling 900, got %d discounts totalling %d", len(order.Discounts), order.TotalAmount)
+	}
+
+	// 3 yakisoba still earn one free and 1200 still meets the minimum.
+	if err := service.UpdateOrderItem(ctx, order.ID, yakisoba.ID, 3); err != nil {
+		t.Fatalf("UpdateOrderItem failed: %v", err)
+	}
+	updated, _ := service.GetOrder(ctx, order.ID)
+	if len(updated.Discounts) != 2 || updated.TotalAmount != 500 {
+		t.Errorf("Expected 2 discounts totalling 500, got %d discounts totalling %d", len(updated.Discounts), updated.TotalAmount)
+	}
+
+	// 2 yakisoba earn nothing free and 800 is under the minimum.
+	if err := service.UpdateOrderItem(ctx, order.ID, yakisoba.ID, 2); err != nil {
+		t.Fatalf("UpdateOrderItem failed: %v", err)
+	}
+	updated, _ = service.GetOrder(ctx, order.ID)
+	if len(updated.Discounts) != 0 || updated.TotalAmount != 800 {
+		t.Errorf("Expected no discounts and a total of 800, got %d discounts totalling %d", len(updated.Discounts), updated.TotalAmount)
+	}
+}
+
+func TestOrderService_AddOrderItemsReappliesDiscounts(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	orderRepo.inventories = invRepo
+	prodRepo := newMockProductRepository()
+	promoRepo := newMockPromotionRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), promoRepo, newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	yakisoba := &models.Product{ID: types.ID("yakisoba"), Name: "Yakisoba", Price: 400}
+	prodRepo.Create(ctx, yakisoba)
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: yakisoba.ID, InitialQuantity: 10})
+
+	promoRepo.Create(ctx, &models.Promotion{
+		ID:       types.ID("tenoff"),
+		Name:     "10% off",
+		Type:     types.PERCENTAGE,
+		Value:    10,
+		IsActive: true,
+	})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: yakisoba.ID, Quantity: 2},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if len(order.Discounts) != 1 || order.TotalAmount != 720 {
+		t.Fatalf("Expected 1 discount totalling 720, got %d discounts totalling %d", len(order.Discounts), order.TotalAmount)
+	}
+
+	if err := service.AddOrderItems(ctx, order.ID, []OrderItemInput{{ProductID: yakisoba.ID, Quantity: 3}}); err != nil {
+		t.Fatalf("AddOrderItems failed: %v", err)
+	}
+	updated, _ := service.GetOrder(ctx, order.ID)
+	if len(updated.Discounts) != 1 || updated.Discounts[0].Amount != 200 || updated.TotalAmount != 1800 {
+		t.Errorf("Expected a 200 discount and a total of 1800, got %+v totalling %d", updated.Discounts, updated.TotalAmount)
+	}
+}
+
+func TestOrderService_CouponUsedUpMeanwhile(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	orderRepo.inventories = invRepo
+	prodRepo := newMockProductRepository()
+	promoRepo := newMockPromotionRepository()
+	// The promotion is locked and counted again as the order is written; by
+	// then another order has used the coupon.
+	lockedPromos := newMockPromotionRepository()
+	orderRepo.promotions = lockedPromos
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), promoRepo, newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	yakisoba := &models.Product{ID: types.ID("yakisoba"), Name: "Yakisoba", Price: 400}
+	prodRepo.Create(ctx, yakisoba)
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: yakisoba.ID, InitialQuantity: 10})
+
+	code, limit := "STAFF", 1
+	staff := &models.Promotion{
+		ID:         types.ID("staff"),
+		Name:       "Staff discount",
+		Type:       types.PERCENTAGE,
+		Value:      10,
+		Code:       &code,
+		UsageLimit: &limit,
+		IsActive:   true,
+	}
+	promoRepo.Create(ctx, staff)
+	lockedPromos.Create(ctx, staff)
+	lockedPromos.usage[staff.ID] = 1
+
+	_, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{{ProductID: yakisoba.ID, Quantity: 1}}, []string{code})
+	if err != ErrCouponUsedUp {
+		t.Fatalf("Expected ErrCouponUsedUp, got %v", err)
+	}
+	if len(orderRepo.orders) != 0 || len(invRepo.movements) != 0 {
+		t.Errorf("Expected no order or stock movements, got %d orders and %d movements", len(orderRepo.orders), len(invRepo.movements))
+	}
+}
+
+func TestOrderService_MergesDuplicateLines(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	orderRepo.inventories = invRepo
+	prodRepo := newMockProductRepository()
+	optionRepo := newMockOptionGroupRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, optionRepo, newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	product := &models.Product{ID: types.ID("prod1"), Name: "Yakisoba", Price: 400}
+	prodRepo.Create(ctx, product)
+	optionRepo.Create(ctx, &models.ProductOptionGroup{
+		ID:            types.ID("size"),
+		ProductID:     product.ID,
+		Name:          "Size",
+		SelectionType: types.SINGLE,
+		Options: []models.ProductOption{
+			{ID: types.ID("large"), GroupID: types.ID("size"), Name: "Large", PriceDelta: 100},
+		},
+	})
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: product.ID, InitialQuantity: 5})
+
+	// Separately each line fits, together they oversell
+	_, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: product.ID, Quantity: 3},
+		{ProductID: product.ID, Quantity: 3},
+	}, nil)
+	if err != ErrInsufficientInventory {
+		t.Errorf("Expected ErrInsufficientInventory, got %v", err)
+	}
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: product.ID, Quantity: 1},
+		{ProductID: product.ID, Quantity: 1, OptionIDs: []types.ID{"large"}},
+		{ProductID: product.ID, Quantity: 1},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if len(order.Items) != 2 || order.Items[0].Quantity != 2 {
+		t.Fatalf("Expected 2 lines with the plain one merged, got %+v", order.Items)
+	}
+	for i := range order.Items {
+		order.Items[i].ID = types.ID(fmt.Sprintf("item%d", i))
+	}
+
+	err = service.AddOrderItems(ctx, order.ID, []OrderItemInput{
+		{ProductID: product.ID, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("AddOrderItems failed: %v", err)
+	}
+
+	updated, _ := service.GetOrder(ctx, order.ID)
+	if len(updated.Items) != 2 || updated.Items[0].Quantity != 3 {
+		t.Errorf("Expected the added item merged into the first line, got %+v", updated.Items)
+	}
+	if updated.TotalAmount != 1700 {
+		t.Errorf("Expected total amount 1700, got %d", updated.TotalAmount)
+	}
+	inv, _ := invRepo.FindBySalesSlotAndProduct(ctx, slot.ID, product.ID)
+	if inv.ReservedQuantity != 4 {
+		t.Errorf("Expected 4 reserved, got %d", inv.ReservedQuantity)
+	}
+}
+
+func TestOrderService_AddOrderItemsRollsBack(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	// The ledger the order is written against has sold drinks since invRepo
+	// was read, as another till would have.
+	ledger := newMockInventoryRepository()
+	orderRepo.inventories = ledger
+	prodRepo := newMockProductRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	yakisoba := &models.Product{ID: types.ID("prod1"), Name: "Yakisoba", Price: 400}
+	drink := &models.Product{ID: types.ID("prod2"), Name: "Drink", Price: 150}
+	prodRepo.Create(ctx, yakisoba)
+	prodRepo.Create(ctx, drink)
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: yakisoba.ID, InitialQuantity: 10, ReservedQuantity: 1})
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv2"), SalesSlotID: slot.ID, ProductID: drink.ID, InitialQuantity: 10})
+	ledger.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: yakisoba.ID, InitialQuantity: 10, ReservedQuantity: 1})
+	ledger.Create(ctx, &models.ProductInventory{ID: types.ID("inv2"), SalesSlotID: slot.ID, ProductID: drink.ID, InitialQuantity: 10, SoldQuantity: 9})
+
+	orderRepo.Create(ctx, &models.Order{
+		ID:          types.ID("order1"),
+		SalesSlotID: slot.ID,
+		Status:      types.RESERVED,
+		TotalAmount: 400,
+		Items:       []models.OrderItem{{ID: types.ID("item1"), OrderID: types.ID("order1"), ProductID: yakisoba.ID, Quantity: 1, Price: 400}},
+	})
+
+	// The yakisoba is reserved before the drinks are found to be short.
+	err := service.AddOrderItems(ctx, types.ID("order1"), []OrderItemInput{
+		{ProductID: yakisoba.ID, Quantity: 1},
+		{ProductID: drink.ID, Quantity: 2},
+	})
+	if err != ErrInsufficientInventory {
+		t.Fatalf("Expected ErrInsufficientInventory, got %v", err)
+	}
+
+	order, _ := orderRepo.FindByID(ctx, types.ID("order1"))
+	if len(order.Items) != 1 || order.Items[0].Quantity != 1 {
+		t.Errorf("Expected the order's items unchanged, got %+v", order.Items)
+	}
+	inv, _ := ledger.FindBySalesSlotAndProduct(ctx, slot.ID, yakisoba.ID)
+	if inv.ReservedQuantity != 1 || len(ledger.movements) != 0 {
+		t.Errorf("Expected no stock movements, got %d reserved and %d movements", inv.ReservedQuantity, len(ledger.movements))
+	}
+
+	// The order is cancelled by another request after it was read.
+	orderRepo.statusChanged = map[types.ID]bool{order.ID: true}
+	err = service.AddOrderItems(ctx, order.ID, []OrderItemInput{{ProductID: yakisoba.ID, Quantity: 1}})
+	if err != ErrInvalidOrderStatus {
+		t.Errorf("Expected ErrInvalidOrderStatus, got %v", err)
+	}
+	if len(ledger.movements) != 0 {
+		t.Errorf("Expected no stock movements, got %d", len(ledger.movements))
+	}
+}
+
+func TestOrderService_RejectsInvalidItems(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	service := NewOrderService(newMockOrderRepository(), slotRepo, newMockInventoryRepository(), newMockProductRepository(), newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	if _, err := service.CreateOrder(ctx, slot.ID, "", nil, nil); err != ErrNoOrderItems {
+		t.Errorf("Expected ErrNoOrderItems, got %v", err)
+	}
+
+	items := []OrderItemInput{{ProductID: types.ID("prod1"), Quantity: 0}}
+	if _, err := service.CreateOrder(ctx, slot.ID, "", items, nil); err != ErrInvalidQuantity {
+		t.Errorf("Expected ErrInvalidQuantity, got %v", err)
+	}
+}
+
+func TestOrderService_PurchaseLimits(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	product := &models.Product{ID: types.ID("crepe"), Name: "特製クレープ", Price: 500}
+	prodRepo.Create(ctx, product)
+	maxPerOrder, maxPerCustomer := 3, 4
+	invRepo.Create(ctx, &models.ProductInventory{
+		ID:              types.ID("inv1"),
+		SalesSlotID:     slot.ID,
+		ProductID:       product.ID,
+		InitialQuantity: 50,
+		Product:         product,
+		MaxPerOrder:     &maxPerOrder,
+		MaxPerCustomer:  &maxPerCustomer,
+	})
+
+	crepes := func(quantity int) []OrderItemInput {
+		return []OrderItemInput{{ProductID: product.ID, Quantity: quantity}}
+	}
+
+	if _, err := service.CreateOrder(ctx, slot.ID, "", crepes(1), nil); err != ErrCustomerRequired {
+		t.Errorf("Expected ErrCustomerRequired, got %v", err)
+	}
+
+	_, err := service.CreateOrder(ctx, slot.ID, "090-1234-5678", crepes(4), nil)
+	var limitErr *PurchaseLimitError
+	if !errors.As(err, &limitErr) || limitErr.PerCustomer || limitErr.Limit != 3 {
+		t.Fatalf("Expected per-order limit error, got %v", err)
+	}
+	if err.Error() != "「特製クレープ」は1回の注文につき3個までです" {
+		t.Errorf("Unexpected message: %s", err.Error())
+	}
+
+	first, err := service.CreateOrder(ctx, slot.ID, "090-1234-5678", crepes(3), nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	_, err = service.CreateOrder(ctx, slot.ID, " 090-1234-5678 ", crepes(2), nil)
+	if !errors.As(err, &limitErr) || !limitErr.PerCustomer || limitErr.Purchased != 3 {
+		t.Fatalf("Expected per-customer limit error, got %v", err)
+	}
+
+	// Another customer is not affected
+	if _, err := service.CreateOrder(ctx, slot.ID, "080-0000-0000", crepes(3), nil); err != nil {
+		t.Errorf("CreateOrder for another customer failed: %v", err)
+	}
+
+	// Cancelled orders no longer count
+	if err := service.CancelOrder(ctx, first.ID); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+	second, err := service.CreateOrder(ctx, slot.ID, "090-1234-5678", crepes(2), nil)
+	if err != nil {
+		t.Fatalf("CreateOrder after cancel failed: %v", err)
+	}
+
+	err = service.AddOrderItems(ctx, second.ID, crepes(2))
+	if !errors.As(err, &limitErr) || limitErr.PerCustomer {
+		t.Errorf("Expected per-order limit error when adding items, got %v", err)
+	}
+}
+
+func TestOrderService_SlotCapacity(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	maxOrders, maxItems := 2, 5
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true, MaxOrders: &maxOrders, MaxItems: &maxItems}
+	slotRepo.Create(ctx, slot)
+
+	product := &models.Product{ID: types.ID("prod1"), Name: "Yakisoba", Price: 400}
+	prodRepo.Create(ctx, product)
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: slot.ID, ProductID: product.ID, InitialQuantity: 100})
+
+	yakisoba := func(quantity int) []OrderItemInput {
+		return []OrderItemInput{{ProductID: product.ID, Quantity: quantity}}
+	}
+
+	if _, err := service.CreateOrder(ctx, slot.ID, "", yakisoba(6), nil); err != ErrSlotItemsFull {
+		t.Errorf("Expected ErrSlotItemsFull, got %v", err)
+	}
+
+	first, err := service.CreateOrder(ctx, slot.ID, "", yakisoba(2), nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if _, err := service.CreateOrder(ctx, slot.ID, "", yakisoba(2), nil); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	if _, err := service.CreateOrder(ctx, slot.ID, "", yakisoba(1), nil); err != ErrSlotFull {
+		t.Errorf("Expected ErrSlotFull, got %v", err)
+	}
+	if err := service.AddOrderItems(ctx, first.ID, yakisoba(2)); err != ErrSlotItemsFull {
+		t.Errorf("Expected ErrSlotItemsFull when adding items, got %v", err)
+	}
+
+	// Cancelling frees the order's place
+	if err := service.CancelOrder(ctx, first.ID); err != nil {
+		t.Fatalf("CancelOrder failed: %v", err)
+	}
+	if _, err := service.CreateOrder(ctx, slot.ID, "", yakisoba(3), nil); err != nil {
+		t.Errorf("CreateOrder after cancel failed: %v", err)
+	}
+}
+
+func TestOrderService_SlotCapacityTakenWhileOrdering(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	orderRepo.inventories = invRepo
+	// The slot is locked and read again as the order is written; by then
+	// its last place has been taken.
+	lockedSlots := newMockSalesSlotRepository()
+	orderRepo.slots = lockedSlots
+	prodRepo := newMockProductRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	maxOrders := 1
+	slotRepo.Create(ctx, &models.SalesSlot{ID: types.ID("slot1"), IsActive: true})
+	lockedSlots.Create(ctx, &models.SalesSlot{ID: types.ID("slot1"), IsActive: true, MaxOrders: &maxOrders})
+
+	product := &models.Product{ID: types.ID("prod1"), Name: "Yakisoba", Price: 400}
+	prodRepo.Create(ctx, product)
+	invRepo.Create(ctx, &models.ProductInventory{ID: types.ID("inv1"), SalesSlotID: "slot1", ProductID: product.ID, InitialQuantity: 10})
+
+	if _, err := service.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: product.ID, Quantity: 1}}, nil); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if _, err := service.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: product.ID, Quantity: 1}}, nil); err != ErrSlotFull {
+		t.Errorf("Expected ErrSlotFull, got %v", err)
+	}
+
+	orders, _ := orderRepo.FindAll(ctx)
+	inv, _ := invRepo.FindBySalesSlotAndProduct(ctx, "slot1", product.ID)
+	if len(orders) != 1 || inv.ReservedQuantity != 1 {
+		t.Errorf("Expected only the first order placed and reserved, got %d orders and %d reserved", len(orders), inv.ReservedQuantity)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
 )
 
 type OrderTicketService interface {
@@ -13,22 +14,27 @@ type OrderTicketService interface {
 	GetTicket(ctx context.Context, id types.ID) (*models.OrderTicket, error)
 	GetTicketByNumber(ctx context.Context, ticketNumber string) (*models.OrderTicket, error)
 	GetAllTickets(ctx context.Context) ([]models.OrderTicket, error)
-	UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string) error
+	UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string, tenderedAmount *int) error
 	UpdateDeliveryStatus(ctx context.Context, id types.ID, isDelivered bool) error
+	GetTicketToken(ctx context.Context, id types.ID) (string, error)
+	VerifyTicket(ctx context.Context, token string) (*models.OrderTicket, error)
 }
 
 type orderTicketService struct {
 	ticketRepo repositories.OrderTicketRepository
 	orderRepo  repositories.OrderRepository
+	signer     *TicketSigner
 }
 
 func NewOrderTicketService(
 	ticketRepo repositories.OrderTicketRepository,
 	orderRepo repositories.OrderRepository,
+	signer *TicketSigner,
 ) OrderTicketService {
 	return &orderTicketService{
 		ticketRepo: ticketRepo,
 		orderRepo:  orderRepo,
+		signer:     signer,
 	}
 }
 
@@ -53,12 +59,14 @@ func (s *orderTicketService) CreateTicket(ctx context.Context, orderID types.ID,
 	}
 
 	ticket := &models.OrderTicket{
+		ID:            types.ID(uuid.New().String()),
 		TicketNumber:  ticketNumber,
 		OrderID:       orderID,
 		PaymentMethod: paymentMethod,
 		IsPaid:        false,
 		IsDelivered:   false,
 	}
+	ticket.Token = s.signer.Sign(ticket)
 
 	if err := s.ticketRepo.Create(ctx, ticket); err != nil {
 		return nil, err
@@ -79,7 +87,7 @@ func (s *orderTicketService) GetAllTickets(ctx context.Context) ([]models.OrderT
 	return s.ticketRepo.FindAll(ctx)
 }
 
-func (s *orderTicketService) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string) error {
+func (s *orderTicketService) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string, tenderedAmount *int) error {
 	ticket, err := s.ticketRepo.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -89,7 +97,25 @@ func (s *orderTicketService) UpdatePaymentStatus(ctx context.Context, id types.I
 		return &ServiceError{Message: "既に支払い済みです"}
 	}
 
-	return s.ticketRepo.UpdatePaymentStatus(ctx, id, isPaid, transactionID)
+	if !isPaid || tenderedAmount == nil {
+		return s.ticketRepo.UpdatePaymentStatus(ctx, id, isPaid, transactionID, nil, nil)
+	}
+
+	if ticket.PaymentMethod != types.CASH {
+		return ErrTenderedNotAllowed
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, ticket.OrderID)
+	if err != nil {
+		return err
+	}
+
+	if *tenderedAmount < order.TotalAmount {
+		return ErrInsufficientPayment
+	}
+
+	change := *tenderedAmount - order.TotalAmount
+	return s.ticketRepo.UpdatePaymentStatus(ctx, id, isPaid, transactionID, tenderedAmount, &change)
 }
 
 func (s *orderTicketService) UpdateDeliveryStatus(ctx context.Context, id types.ID, isDelivered bool) error {
@@ -103,8 +129,49 @@ func (s *orderTicketService) UpdateDeliveryStatus(ctx context.Context, id types.
 	}
 
 	if isDelivered && ticket.IsDelivered {
-		return &ServiceError{Message: "既に引き渡し済みです"}
+		return ErrAlreadyDelivered
 	}
 
 	return s.ticketRepo.UpdateDeliveryStatus(ctx, id, isDelivered)
 }
+
+func (s *orderTicketService) GetTicketToken(ctx context.Context, id types.ID) (string, error) {
+	ticket, err := s.ticketRepo.FindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	// Tickets issued before signing was introduced have no stored token.
+	if ticket.Token == "" {
+		return s.signer.Sign(ticket), nil
+	}
+	return ticket.Token, nil
+}
+
+func (s *orderTicketService) VerifyTicket(ctx context.Context, token string) (*models.OrderTicket, error) {
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.ticketRepo.FindByID(ctx, claims.TicketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.TicketNumber != claims.TicketNumber || ticket.OrderID != claims.OrderID {
+		return nil, ErrInvalidTicketToken
+	}
+
+	if ticket.IsDelivered {
+		return nil, ErrAlreadyDelivered
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, ticket.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	ticket.Order = order
+
+	return ticket, nil
+}
@@ -73,13 +73,15 @@ func (r *mockOrderTicketRepository) FindByOrderID(ctx context.Context, orderID t
 	return nil, repositories.NewErrNotFound("OrderTicket", "")
 }
 
-func (r *mockOrderTicketRepository) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string) error {
+func (r *mockOrderTicketRepository) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string, tenderedAmount, changeAmount *int) error {
 	ticket, exists := r.tickets[id]
 	if !exists {
 		return repositories.NewErrNotFound("OrderTicket", id)
 	}
 	ticket.IsPaid = isPaid
 	ticket.TransactionID = transactionID
+	ticket.TenderedAmount = tenderedAmount
+	ticket.ChangeAmount = changeAmount
 	return nil
 }
 
@@ -95,7 +97,7 @@ func (r *mockOrderTicketRepository) UpdateDeliveryStatus(ctx context.Context, id
 func TestOrderTicketService_CreateTicket(t *testing.T) {
 	ticketRepo := newMockOrderTicketRepository()
 	orderRepo := newMockOrderRepository()
-	service := NewOrderTicketService(ticketRepo, orderRepo)
+	service := NewOrderTicketService(ticketRepo, orderRepo, NewTicketSigner([]byte("secret")))
 	ctx := context.Background()
 
 	// Create test data
@@ -127,7 +129,7 @@ func TestOrderTicketService_CreateTicket(t *testing.T) {
 func TestOrderTicketService_PaymentAndDelivery(t *testing.T) {
 	ticketRepo := newMockOrderTicketRepository()
 	orderRepo := newMockOrderRepository()
-	service := NewOrderTicketService(ticketRepo, orderRepo)
+	service := NewOrderTicketService(ticketRepo, orderRepo, NewTicketSigner([]byte("secret")))
 	ctx := context.Background()
 
 	// Create test data
@@ -141,7 +143,7 @@ func TestOrderTicketService_PaymentAndDelivery(t *testing.T) {
 
 	// Test payment status update
 	transactionID := "TRX123"
-	err := service.UpdatePaymentStatus(ctx, ticket.ID, true, &transactionID)
+	err := service.UpdatePaymentStatus(ctx, ticket.ID, true, &transactionID, nil)
 	if err != nil {
 		t.Errorf("UpdatePaymentStatus failed: %v", err)
 	}
@@ -170,7 +172,7 @@ func TestOrderTicketService_PaymentAndDelivery(t *testing.T) {
 func TestOrderTicketService_GetByNumber(t *testing.T) {
 	ticketRepo := newMockOrderTicketRepository()
 	orderRepo := newMockOrderRepository()
-	service := NewOrderTicketService(ticketRepo, orderRepo)
+	service := NewOrderTicketService(ticketRepo, orderRepo, NewTicketSigner([]byte("secret")))
 	ctx := context.Background()
 
 	order := &models.Order{
@@ -190,3 +192,114 @@ func TestOrderTicketService_GetByNumber(t *testing.T) {
 		t.Errorf("Expected ticket ID %v, got %v", created.ID, ticket.ID)
 	}
 }
+
+func TestOrderTicketService_CashPaymentChange(t *testing.T) {
+	ticketRepo := newMockOrderTicketRepository()
+	orderRepo := newMockOrderRepository()
+	service := NewOrderTicketService(ticketRepo, orderRepo, NewTicketSigner([]byte("secret")))
+	ctx := context.Background()
+
+	order := &models.Order{
+		ID:          types.ID("order1"),
+		Status:      types.CONFIRMED,
+		TotalAmount: 1200,
+	}
+	orderRepo.Create(ctx, order)
+
+	ticket, _ := service.CreateTicket(ctx, order.ID, "TICKET123", types.CASH)
+
+	// Test underpayment is rejected
+	tendered := 1000
+	err := service.UpdatePaymentStatus(ctx, ticket.ID, true, nil, &tendered)
+	if err != ErrInsufficientPayment {
+		t.Errorf("Expected ErrInsufficientPayment, got %v", err)
+	}
+
+	ticket, _ = service.GetTicket(ctx, ticket.ID)
+	if ticket.IsPaid {
+		t.Error("Expected ticket to remain unpaid")
+	}
+
+	// Test change calculation
+	tendered = 5000
+	err = service.UpdatePaymentStatus(ctx, ticket.ID, true, nil, &tendered)
+	if err != nil {
+		t.Errorf("UpdatePaymentStatus failed: %v", err)
+	}
+
+	ticket, _ = service.GetTicket(ctx, ticket.ID)
+	if !ticket.IsPaid {
+		t.Error("Expected ticket to be paid")
+	}
+
+	if ticket.TenderedAmount == nil || *ticket.TenderedAmount != 5000 {
+		t.Errorf("Expected tendered amount 5000, got %v", ticket.TenderedAmount)
+	}
+
+	if ticket.ChangeAmount == nil || *ticket.ChangeAmount != 3800 {
+		t.Errorf("Expected change amount 3800, got %v", ticket.ChangeAmount)
+	}
+}
+
+func TestOrderTicketService_TenderedAmountRequiresCash(t *testing.T) {
+	ticketRepo := newMockOrderTicketRepository()
+	orderRepo := newMockOrderRepository()
+	service := NewOrderTicketService(ticketRepo, orderRepo, NewTicketSigner([]byte("secret")))
+	ctx := context.Background()
+
+	order := &models.Order{
+		ID:          types.ID("order1"),
+		Status:      types.CONFIRMED,
+		TotalAmount: 1200,
+	}
+	orderRepo.Create(ctx, order)
+
+	ticket, _ := service.CreateTicket(ctx, order.ID, "TICKET123", types.PAYPAY)
+
+	tendered := 2000
+	err := service.UpdatePaymentStatus(ctx, ticket.ID, true, nil, &tendered)
+	if err != ErrTenderedNotAllowed {
+		t.Errorf("Expected ErrTenderedNotAllowed, got %v", err)
+	}
+}
+
+func TestOrderTicketService_VerifyTicket(t *testing.T) {
+	ticketRepo := newMockOrderTicketRepository()
+	orderRepo := newMockOrderRepository()
+	service := NewOrderTicketService(ticketRepo, orderRepo, NewTicketSigner([]byte("secret")))
+	ctx := context.Background()
+
+	order := &models.Order{
+		ID:          types.ID("order1"),
+		Status:      types.CONFIRMED,
+		TotalAmount: 1200,
+	}
+	orderRepo.Create(ctx, order)
+
+	ticket, _ := service.CreateTicket(ctx, order.ID, "TICKET123", types.CASH)
+	if ticket.Token == "" {
+		t.Fatal("Expected ticket to carry a signed token")
+	}
+
+	verified, err := service.VerifyTicket(ctx, ticket.Token)
+	if err != nil {
+		t.Fatalf("VerifyTicket failed: %v", err)
+	}
+
+	if verified.ID != ticket.ID || verified.Order == nil || verified.Order.ID != order.ID {
+		t.Errorf("Expected verified ticket %v with order %v", ticket.ID, order.ID)
+	}
+
+	// Test token signed with another secret is rejected
+	forged := NewTicketSigner([]byte("other")).Sign(ticket)
+	if _, err := service.VerifyTicket(ctx, forged); err != ErrInvalidTicketToken {
+		t.Errorf("Expected ErrInvalidTicketToken, got %v", err)
+	}
+
+	// Test delivered tickets are refused
+	service.UpdatePaymentStatus(ctx, ticket.ID, true, nil, nil)
+	service.UpdateDeliveryStatus(ctx, ticket.ID, true)
+	if _, err := service.VerifyTicket(ctx, ticket.Token); err != ErrAlreadyDelivered {
+		t.Errorf("Expected ErrAlreadyDelivered, got %v", err)
+	}
+}
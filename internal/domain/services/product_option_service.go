@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+)
+
+type ProductOptionService interface {
+	CreateOptionGroup(ctx context.Context, productID types.ID, input OptionGroupInput) (*models.ProductOptionGroup, error)
+	GetOptionGroup(ctx context.Context, id types.ID) (*models.ProductOptionGroup, error)
+	GetOptionGroups(ctx context.Context, productID types.ID) ([]models.ProductOptionGroup, error)
+	UpdateOptionGroup(ctx context.Context, id types.ID, input OptionGroupInput) (*models.ProductOptionGroup, error)
+	DeleteOptionGroup(ctx context.Context, id types.ID) error
+}
+
+type OptionGroupInput struct {
+	Name          string
+	SelectionType types.SelectionType
+	Required      bool
+	MinSelect     int
+	MaxSelect     int
+	DisplayOrder  int
+	Options       []OptionInput
+}
+
+// OptionInput describes one choice in a group. ID is set when updating an
+// existing option so that its identifier is preserved.
+type OptionInput struct {
+	ID           *types.ID
+	Name         string
+	PriceDelta   int
+	DisplayOrder int
+}
+
+type productOptionService struct {
+	groupRepo   repositories.ProductOptionGroupRepository
+	productRepo repositories.ProductRepository
+}
+
+func NewProductOptionService(groupRepo repositories.ProductOptionGroupRepository, productRepo repositories.ProductRepository) ProductOptionService {
+	return &productOptionService{
+		groupRepo:   groupRepo,
+		productRepo: productRepo,
+	}
+}
+
+func (s *productOptionService) CreateOptionGroup(ctx context.Context, productID types.ID, input OptionGroupInput) (*models.ProductOptionGroup, error) {
+	if _, err := s.productRepo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	group := &models.ProductOptionGroup{
+		ID:        types.ID(uuid.New().String()),
+		ProductID: productID,
+	}
+	if err := applyOptionGroupInput(group, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.Create(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *productOptionService) GetOptionGroup(ctx context.Context, id types.ID) (*models.ProductOptionGroup, error) {
+	return s.groupRepo.FindByID(ctx, id)
+}
+
+func (s *productOptionService) GetOptionGroups(ctx context.Context, productID types.ID) ([]models.ProductOptionGroup, error) {
+	return s.groupRepo.FindByProductID(ctx, productID)
+}
+
+func (s *productOptionService) UpdateOptionGroup(ctx context.Context, id types.ID, input OptionGroupInput) (*models.ProductOptionGroup, error) {
+	group, err := s.groupRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyOptionGroupInput(group, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.groupRepo.Update(ctx, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *productOptionService) DeleteOptionGroup(ctx context.Context, id types.ID) error {
+	return s.groupRepo.Delete(ctx, id)
+}
+
+func applyOptionGroupInput(group *models.ProductOptionGroup, input OptionGroupInput) error {
+	if input.SelectionType == 0 {
+		input.SelectionType = types.SINGLE
+	}
+	if input.MinSelect < 0 || input.MaxSelect < 0 ||
+		(input.MaxSelect > 0 && input.MinSelect > input.MaxSelect) ||
+		(input.SelectionType == types.SINGLE && input.MinSelect > 1) ||
+		input.MinSelect > len(input.Options) {
+		return ErrInvalidOptionGroup
+	}
+
+	group.Name = input.Name
+	group.SelectionType = input.SelectionType
+	group.Required = input.Required
+	group.MinSelect = input.MinSelect
+	group.MaxSelect = input.MaxSelect
+	group.DisplayOrder = input.DisplayOrder
+
+	group.Options = make([]models.ProductOption, len(input.Options))
+	for i, opt := range input.Options {
+		id := types.ID(uuid.New().String())
+		if opt.ID != nil {
+			id = *opt.ID
+		}
+		group.Options[i] = models.ProductOption{
+			ID:           id,
+			GroupID:      group.ID,
+			Name:         opt.Name,
+			PriceDelta:   opt.PriceDelta,
+			DisplayOrder: opt.DisplayOrder,
+		}
+	}
+	return nil
+}
+
+// resolveOptions checks the selected option IDs against the product's option
+// groups and returns the snapshot to store on the order item.
+func resolveOptions(groups []models.ProductOptionGroup, optionIDs []types.ID) ([]models.OrderItemOption, error) {
+	selected := make(map[types.ID]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if selected[id] {
+			return nil, ErrInvalidOptionSelection
+		}
+		selected[id] = true
+	}
+
+	var options []models.OrderItemOption
+	for _, group := range groups {
+		count := 0
+		for _, opt := range group.Options {
+			if !selected[opt.ID] {
+				continue
+			}
+			delete(selected, opt.ID)
+			count++
+			options = append(options, models.OrderItemOption{
+				OptionID:   opt.ID,
+				GroupName:  group.Name,
+				Name:       opt.Name,
+				PriceDelta: opt.PriceDelta,
+			})
+		}
+
+		min, max := group.SelectionRange()
+		if count < min || (max > 0 && count > max) {
+			return nil, ErrInvalidOptionSelection
+		}
+	}
+
+	// Anything left over does not belong to this product.
+	if len(selected) > 0 {
+		return nil, ErrInvalidOptionSelection
+	}
+	return options, nil
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type mockOptionGroupRepository struct {
+	groups map[types.ID]*models.ProductOptionGroup
+}
+
+func newMockOptionGroupRepository() *mockOptionGroupRepository {
+	return &mockOptionGroupRepository{
+		groups: make(map[types.ID]*models.ProductOptionGroup),
+	}
+}
+
+func (r *mockOptionGroupRepository) Create(ctx context.Context, group *models.ProductOptionGroup) error {
+	r.groups[group.ID] = group
+	return nil
+}
+
+func (r *mockOptionGroupRepository) FindByID(ctx context.Context, id types.ID) (*models.ProductOptionGroup, error) {
+	if group, exists := r.groups[id]; exists {
+		return group, nil
+	}
+	return nil, repositories.NewErrNotFound("ProductOptionGroup", id)
+}
+
+func (r *mockOptionGroupRepository) FindAll(ctx context.Context) ([]models.ProductOptionGroup, error) {
+	var groups []models.ProductOptionGroup
+	for _, g := range r.groups {
+		groups = append(groups, *g)
+	}
+	return groups, nil
+}
+
+func (r *mockOptionGroupRepository) FindByProductID(ctx context.Context, productID types.ID) ([]models.ProductOptionGroup, error) {
+	var groups []models.ProductOptionGroup
+	for _, g := range r.groups {
+		if g.ProductID == productID {
+			groups = append(groups, *g)
+		}
+	}
+	return groups, nil
+}
+
+func (r *mockOptionGroupRepository) Update(ctx context.Context, group *models.ProductOptionGroup) error {
+	if _, exists := r.groups[group.ID]; !exists {
+		return repositories.NewErrNotFound("ProductOptionGroup", group.ID)
+	}
+	r.groups[group.ID] = group
+	return nil
+}
+
+func (r *mockOptionGroupRepository) Delete(ctx context.Context, id types.ID) error {
+	if _, exists := r.groups[id]; !exists {
+		return repositories.NewErrNotFound("ProductOptionGroup", id)
+	}
+	delete(r.groups, id)
+	return nil
+}
+
+func TestProductOptionService_CreateOptionGroup(t *testing.T) {
+	groupRepo := newMockOptionGroupRepository()
+	prodRepo := newMockProductRepository()
+	service := NewProductOptionService(groupRepo, prodRepo)
+	ctx := context.Background()
+
+	product := &models.Product{ID: types.ID("prod1"), Name: "Yakisoba", Price: 400}
+	prodRepo.Create(ctx, product)
+
+	group, err := service.CreateOptionGroup(ctx, product.ID, OptionGroupInput{
+		Name:          "Toppings",
+		SelectionType: types.MULTIPLE,
+		MaxSelect:     2,
+		Options: []OptionInput{
+			{Name: "Fried egg", PriceDelta: 100},
+			{Name: "Extra noodles", PriceDelta: 150},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOptionGroup failed: %v", err)
+	}
+
+	if len(group.Options) != 2 {
+		t.Errorf("Expected 2 options, got %d", len(group.Options))
+	}
+
+	// Test invalid range
+	_, err = service.CreateOptionGroup(ctx, product.ID, OptionGroupInput{
+		Name:          "Size",
+		SelectionType: types.MULTIPLE,
+		MinSelect:     3,
+		MaxSelect:     2,
+		Options:       []OptionInput{{Name: "S"}, {Name: "M"}, {Name: "L"}},
+	})
+	if err != ErrInvalidOptionGroup {
+		t.Errorf("Expected ErrInvalidOptionGroup, got %v", err)
+	}
+}
+
+func TestOrderService_CreateOrderWithOptions(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	groupRepo := newMockOptionGroupRepository()
+	service := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, groupRepo, newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slot := &models.SalesSlot{ID: types.ID("slot1"), IsActive: true}
+	slotRepo.Create(ctx, slot)
+
+	product := &models.Product{ID: types.ID("prod1"), Name: "Drink", Price: 200}
+	prodRepo.Create(ctx, product)
+
+	invRepo.Create(ctx, &models.ProductInventory{
+		ID:              types.ID("inv1"),
+		SalesSlotID:     slot.ID,
+		ProductID:       product.ID,
+		InitialQuantity: 10,
+	})
+
+	groupRepo.Create(ctx, &models.ProductOptionGroup{
+		ID:            types.ID("ice"),
+		ProductID:     product.ID,
+		Name:          "Ice",
+		SelectionType: types.SINGLE,
+		Required:      true,
+		Options: []models.ProductOption{
+			{ID: types.ID("with-ice"), Name: "With ice"},
+			{ID: types.ID("no-ice"), Name: "No ice"},
+		},
+	})
+	groupRepo.Create(ctx, &models.ProductOptionGroup{
+		ID:            types.ID("extras"),
+		ProductID:     product.ID,
+		Name:          "Extras",
+		SelectionType: types.MULTIPLE,
+		Options: []models.ProductOption{
+			{ID: types.ID("lemon"), Name: "Lemon", PriceDelta: 50},
+			{ID: types.ID("syrup"), Name: "Syrup", PriceDelta: 30},
+		},
+	})
+
+	order, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+		{ProductID: product.ID, Quantity: 2, OptionIDs: []types.ID{"no-ice", "lemon", "syrup"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	if order.TotalAmount != 560 {
+		t.Errorf("Expected total amount 560, got %d", order.TotalAmount)
+	}
+
+	if len(order.Items[0].Options) != 3 {
+		t.Errorf("Expected 3 options on item, got %d", len(order.Items[0].Options))
+	}
+
+	tests := []struct {
+		name      string
+		optionIDs []types.ID
+	}{
+		{"missing required group", []types.ID{"lemon"}},
+		{"two choices in single group", []types.ID{"with-ice", "no-ice"}},
+		{"unknown option", []types.ID{"no-ice", "unknown"}},
+		{"duplicate option", []types.ID{"no-ice", "lemon", "lemon"}},
+	}
+	for _, tt := range tests {
+		_, err := service.CreateOrder(ctx, slot.ID, "", []OrderItemInput{
+			{ProductID: product.ID, Quantity: 1, OptionIDs: tt.optionIDs},
+		}, nil)
+		if err != ErrInvalidOptionSelection {
+			t.Errorf("%s: expected ErrInvalidOptionSelection, got %v", tt.name, err)
+		}
+	}
+}
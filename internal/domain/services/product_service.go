@@ -2,40 +2,103 @@ package services
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/storage"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"github.com/google/uuid"
 )
 
 type ProductService interface {
-	CreateProduct(ctx context.Context, name string, price int) (*models.Product, error)
+	CreateProduct(ctx context.Context, input ProductInput) (*models.Product, error)
 	GetProduct(ctx context.Context, id types.ID) (*models.Product, error)
-	GetAllProducts(ctx context.Context) ([]models.Product, error)
-	UpdateProduct(ctx context.Context, id types.ID, name string, price int) (*models.Product, error)
+	GetAllProducts(ctx context.Context, filter repositories.ProductFilter) ([]models.Product, error)
+	UpdateProduct(ctx context.Context, id types.ID, input ProductInput) (*models.Product, error)
 	DeleteProduct(ctx context.Context, id types.ID) error
+	UploadProductImage(ctx context.Context, id types.ID, data []byte) (*models.Product, error)
+	GetProductImage(ctx context.Context, id types.ID) (io.ReadCloser, error)
+	GetPriceHistory(ctx context.Context, id types.ID) ([]models.ProductPrice, error)
+	SchedulePriceChange(ctx context.Context, id types.ID, price int, effectiveFrom time.Time) (*models.ProductPrice, error)
+	CancelPriceChange(ctx context.Context, id, priceID types.ID) error
+	ApplyScheduledPrices(ctx context.Context) error
+}
+
+type ProductInput struct {
+	Name        string
+	Price       int
+	CategoryID  *types.ID
+	Description string
+	Allergens   types.AllergenSet
+	DietaryTags types.DietaryTagSet
+	TaxRate     types.TaxRate
+	Components  []BundleComponentInput
+}
+
+// BundleComponentInput is a product contained in a set menu. A product with
+// components is a bundle and takes its stock from the components.
+type BundleComponentInput struct {
+	ProductID types.ID
+	Quantity  int
+}
+
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
 }
 
 type productService struct {
-	repo repositories.ProductRepository
+	repo         repositories.ProductRepository
+	categoryRepo repositories.CategoryRepository
+	priceRepo    repositories.ProductPriceRepository
+	images       storage.ImageStorage
 }
 
-func NewProductService(repo repositories.ProductRepository) ProductService {
-	return &productService{repo: repo}
+func NewProductService(
+	repo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+	priceRepo repositories.ProductPriceRepository,
+	images storage.ImageStorage,
+) ProductService {
+	return &productService{
+		repo:         repo,
+		categoryRepo: categoryRepo,
+		priceRepo:    priceRepo,
+		images:       images,
+	}
 }
 
-func (s *productService) CreateProduct(ctx context.Context, name string, price int) (*models.Product, error) {
+func (s *productService) CreateProduct(ctx context.Context, input ProductInput) (*models.Product, error) {
+	if err := validateProductInput(input); err != nil {
+		return nil, err
+	}
+	if err := s.validateCategory(ctx, input.CategoryID); err != nil {
+		return nil, err
+	}
+
 	product := &models.Product{
-		ID:    types.ID(uuid.New().String()),
-		Name:  name,
-		Price: price,
+		ID: types.ID(uuid.New().String()),
+	}
+	applyProductInput(product, input)
+	if err := s.applyComponents(ctx, product, input.Components); err != nil {
+		return nil, err
 	}
 
 	if err := s.repo.Create(ctx, product); err != nil {
 		return nil, err
 	}
 
+	if err := s.recordPrice(ctx, product.ID, product.Price, time.Now()); err != nil {
+		return nil, err
+	}
+
 	return product, nil
 }
 
@@ -43,26 +106,244 @@ func (s *productService) GetProduct(ctx context.Context, id types.ID) (*models.P
 	return s.repo.FindByID(ctx, id)
 }
 
-func (s *productService) GetAllProducts(ctx context.Context) ([]models.Product, error) {
-	return s.repo.FindAll(ctx)
+func (s *productService) GetAllProducts(ctx context.Context, filter repositories.ProductFilter) ([]models.Product, error) {
+	return s.repo.FindByFilter(ctx, filter)
 }
 
-func (s *productService) UpdateProduct(ctx context.Context, id types.ID, name string, price int) (*models.Product, error) {
+func (s *productService) UpdateProduct(ctx context.Context, id types.ID, input ProductInput) (*models.Product, error) {
+	if err := validateProductInput(input); err != nil {
+		return nil, err
+	}
+
 	product, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	product.Name = name
-	product.Price = price
+	if err := s.validateCategory(ctx, input.CategoryID); err != nil {
+		return nil, err
+	}
+
+	priceChanged := product.Price != input.Price
+	applyProductInput(product, input)
+	if err := s.applyComponents(ctx, product, input.Components); err != nil {
+		return nil, err
+	}
+	product.Category = nil
 
 	if err := s.repo.Update(ctx, product); err != nil {
 		return nil, err
 	}
 
+	if priceChanged {
+		if err := s.recordPrice(ctx, product.ID, product.Price, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	return product, nil
 }
 
 func (s *productService) DeleteProduct(ctx context.Context, id types.ID) error {
 	return s.repo.Delete(ctx, id)
 }
+
+func (s *productService) UploadProductImage(ctx context.Context, id types.ID, data []byte) (*models.Product, error) {
+	product, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ext, ok := imageExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, ErrUnsupportedImage
+	}
+
+	key, err := s.images.Save(ctx, string(product.ID)+ext, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.ImagePath != "" && product.ImagePath != key {
+		if err := s.images.Delete(ctx, product.ImagePath); err != nil {
+			return nil, err
+		}
+	}
+
+	product.ImagePath = key
+	if err := s.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func (s *productService) GetProductImage(ctx context.Context, id types.ID) (io.ReadCloser, error) {
+	product, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if product.ImagePath == "" {
+		return nil, repositories.NewErrNotFound("ProductImage", id)
+	}
+
+	return s.images.Open(ctx, product.ImagePath)
+}
+
+func (s *productService) GetPriceHistory(ctx context.Context, id types.ID) ([]models.ProductPrice, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.priceRepo.FindByProductID(ctx, id)
+}
+
+func (s *productService) SchedulePriceChange(ctx context.Context, id types.ID, price int, effectiveFrom time.Time) (*models.ProductPrice, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	if price < 0 || !effectiveFrom.After(time.Now()) {
+		return nil, ErrInvalidPriceChange
+	}
+
+	entry := &models.ProductPrice{
+		ID:            types.ID(uuid.New().String()),
+		ProductID:     id,
+		Price:         price,
+		EffectiveFrom: effectiveFrom,
+	}
+	if err := s.priceRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// CancelPriceChange removes a scheduled price change. Entries already in
+// effect are part of the history and cannot be removed.
+func (s *productService) CancelPriceChange(ctx context.Context, id, priceID types.ID) error {
+	entry, err := s.priceRepo.FindByID(ctx, priceID)
+	if err != nil {
+		return err
+	}
+	if entry.ProductID != id || !entry.EffectiveFrom.After(time.Now()) {
+		return ErrInvalidPriceChange
+	}
+	return s.priceRepo.Delete(ctx, priceID)
+}
+
+// ApplyScheduledPrices copies prices that have come into effect onto their
+// products so that listings show the current price.
+func (s *productService) ApplyScheduledPrices(ctx context.Context) error {
+	prices, err := s.priceRepo.FindEffectiveAll(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range prices {
+		product, err := s.repo.FindByID(ctx, entry.ProductID)
+		if err != nil {
+			// The product may have been deleted since the change was scheduled.
+			continue
+		}
+		if product.Price == entry.Price {
+			continue
+		}
+		product.Price = entry.Price
+		product.Category = nil
+		if err := s.repo.Update(ctx, product); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *productService) recordPrice(ctx context.Context, id types.ID, price int, at time.Time) error {
+	return s.priceRepo.Create(ctx, &models.ProductPrice{
+		ID:            types.ID(uuid.New().String()),
+		ProductID:     id,
+		Price:         price,
+		EffectiveFrom: at,
+	})
+}
+
+// effectivePrice returns the price in effect at the given time, falling back
+// to the product's own price when it has no history.
+func effectivePrice(ctx context.Context, priceRepo repositories.ProductPriceRepository, product *models.Product, at time.Time) (int, error) {
+	entry, err := priceRepo.FindEffective(ctx, product.ID, at)
+	if err != nil {
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return product.Price, nil
+		}
+		return 0, err
+	}
+	return entry.Price, nil
+}
+
+func (s *productService) validateCategory(ctx context.Context, categoryID *types.ID) error {
+	if categoryID == nil {
+		return nil
+	}
+	_, err := s.categoryRepo.FindByID(ctx, *categoryID)
+	return err
+}
+
+// applyComponents turns the product into a bundle of the given components, or
+// a plain product when there are none. Bundles carry the allergens of their
+// components in addition to their own.
+func (s *productService) applyComponents(ctx context.Context, product *models.Product, inputs []BundleComponentInput) error {
+	product.IsBundle = len(inputs) > 0
+	product.Components = nil
+
+	for _, input := range inputs {
+		if input.ProductID == product.ID || input.Quantity <= 0 {
+			return ErrInvalidBundle
+		}
+		component, err := s.repo.FindByID(ctx, input.ProductID)
+		if err != nil {
+			return err
+		}
+		if component.IsBundle {
+			return ErrInvalidBundle
+		}
+
+		merged := false
+		for i := range product.Components {
+			if product.Components[i].ProductID == input.ProductID {
+				product.Components[i].Quantity += input.Quantity
+				merged = true
+			}
+		}
+		if !merged {
+			product.Components = append(product.Components, models.BundleComponent{
+				BundleID:  product.ID,
+				ProductID: input.ProductID,
+				Quantity:  input.Quantity,
+				Product:   component,
+			})
+		}
+		product.Allergens |= component.Allergens
+	}
+	return nil
+}
+
+func validateProductInput(input ProductInput) error {
+	if strings.TrimSpace(input.Name) == "" || input.Price < 0 {
+		return ErrInvalidProduct
+	}
+	return nil
+}
+
+func applyProductInput(product *models.Product, input ProductInput) {
+	product.Name = input.Name
+	product.Price = input.Price
+	product.CategoryID = input.CategoryID
+	product.Description = input.Description
+	product.Allergens = input.Allergens
+	product.DietaryTags = input.DietaryTags
+	product.TaxRate = input.TaxRate
+	if product.TaxRate == 0 {
+		product.TaxRate = types.STANDARD_RATE
+	}
+}
@@ -1,8 +1,12 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"io"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
@@ -71,12 +75,176 @@ func (r *mockProductRepository) FindByName(ctx context.Context, name string) (*m
 	}
 }
 
+func (r *mockProductRepository) FindByFilter(ctx context.Context, filter repositories.ProductFilter) ([]models.Product, error) {
+	var products []models.Product
+	for _, p := range r.products {
+		if filter.CategoryID != nil && (p.CategoryID == nil || *p.CategoryID != *filter.CategoryID) {
+			continue
+		}
+		if p.Allergens&filter.ExcludeAllergens != 0 {
+			continue
+		}
+		products = append(products, *p)
+	}
+	return products, nil
+}
+
+type mockCategoryRepository struct {
+	categories map[types.ID]*models.Category
+}
+
+func newMockCategoryRepository() *mockCategoryRepository {
+	return &mockCategoryRepository{
+		categories: make(map[types.ID]*models.Category),
+	}
+}
+
+func (r *mockCategoryRepository) Create(ctx context.Context, category *models.Category) error {
+	r.categories[category.ID] = category
+	return nil
+}
+
+func (r *mockCategoryRepository) FindByID(ctx context.Context, id types.ID) (*models.Category, error) {
+	if category, exists := r.categories[id]; exists {
+		return category, nil
+	}
+	return nil, repositories.NewErrNotFound("Category", id)
+}
+
+func (r *mockCategoryRepository) FindAll(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	for _, c := range r.categories {
+		categories = append(categories, *c)
+	}
+	return categories, nil
+}
+
+func (r *mockCategoryRepository) Update(ctx context.Context, category *models.Category) error {
+	if _, exists := r.categories[category.ID]; !exists {
+		return repositories.NewErrNotFound("Category", category.ID)
+	}
+	r.categories[category.ID] = category
+	return nil
+}
+
+func (r *mockCategoryRepository) Delete(ctx context.Context, id types.ID) error {
+	if _, exists := r.categories[id]; !exists {
+		return repositories.NewErrNotFound("Category", id)
+	}
+	delete(r.categories, id)
+	return nil
+}
+
+type mockProductPriceRepository struct {
+	prices map[types.ID]*models.ProductPrice
+}
+
+func newMockProductPriceRepository() *mockProductPriceRepository {
+	return &mockProductPriceRepository{
+		prices: make(map[types.ID]*models.ProductPrice),
+	}
+}
+
+func (r *mockProductPriceRepository) Create(ctx context.Context, price *models.ProductPrice) error {
+	r.prices[price.ID] = price
+	return nil
+}
+
+func (r *mockProductPriceRepository) FindByID(ctx context.Context, id types.ID) (*models.ProductPrice, error) {
+	if price, exists := r.prices[id]; exists {
+		return price, nil
+	}
+	return nil, repositories.NewErrNotFound("ProductPrice", id)
+}
+
+func (r *mockProductPriceRepository) FindByProductID(ctx context.Context, productID types.ID) ([]models.ProductPrice, error) {
+	var prices []models.ProductPrice
+	for _, p := range r.prices {
+		if p.ProductID == productID {
+			prices = append(prices, *p)
+		}
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].EffectiveFrom.Before(prices[j].EffectiveFrom)
+	})
+	return prices, nil
+}
+
+func (r *mockProductPriceRepository) FindEffective(ctx context.Context, productID types.ID, at time.Time) (*models.ProductPrice, error) {
+	var effective *models.ProductPrice
+	for _, p := range r.prices {
+		if p.ProductID != productID || p.EffectiveFrom.After(at) {
+			continue
+		}
+		if effective == nil || p.EffectiveFrom.After(effective.EffectiveFrom) {
+			effective = p
+		}
+	}
+	if effective == nil {
+		return nil, repositories.NewErrNotFound("ProductPrice", productID)
+	}
+	return effective, nil
+}
+
+func (r *mockProductPriceRepository) FindEffectiveAll(ctx context.Context, at time.Time) ([]models.ProductPrice, error) {
+	latest := make(map[types.ID]*models.ProductPrice)
+	for _, p := range r.prices {
+		if p.EffectiveFrom.After(at) {
+			continue
+		}
+		if current, exists := latest[p.ProductID]; !exists || p.EffectiveFrom.After(current.EffectiveFrom) {
+			latest[p.ProductID] = p
+		}
+	}
+	var prices []models.ProductPrice
+	for _, p := range latest {
+		prices = append(prices, *p)
+	}
+	return prices, nil
+}
+
+func (r *mockProductPriceRepository) Delete(ctx context.Context, id types.ID) error {
+	if _, exists := r.prices[id]; !exists {
+		return repositories.NewErrNotFound("ProductPrice", id)
+	}
+	delete(r.prices, id)
+	return nil
+}
+
+type mockImageStorage struct {
+	files map[string][]byte
+}
+
+func newMockImageStorage() *mockImageStorage {
+	return &mockImageStorage{
+		files: make(map[string][]byte),
+	}
+}
+
+func (s *mockImageStorage) Save(ctx context.Context, name string, data []byte) (string, error) {
+	s.files[name] = data
+	return name, nil
+}
+
+func (s *mockImageStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, exists := s.files[key]
+	if !exists {
+		return nil, repositories.NewErrNotFound("Image", types.ID(key))
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *mockImageStorage) Delete(ctx context.Context, key string) error {
+	delete(s.files, key)
+	return nil
+}
+
 func TestProductService_CreateProduct(t *testing.T) {
 	repo := newMockProductRepository()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockCategoryRepository(), newMockProductPriceRepository(), newMockImageStorage())
 	ctx := context.Background()
 
-	product, err := service.CreateProduct(ctx, "Test Product", 1000)
+	product, err := service.CreateProduct(ctx, ProductInput{Name: "Test Product", Price: 1000})
 	if err != nil {
 		t.Errorf("CreateProduct failed: %v", err)
 	}
@@ -97,10 +265,10 @@ func TestProductService_CreateProduct(t *testing.T) {
 
 func TestProductService_GetProduct(t *testing.T) {
 	repo := newMockProductRepository()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockCategoryRepository(), newMockProductPriceRepository(), newMockImageStorage())
 	ctx := context.Background()
 
-	created, _ := service.CreateProduct(ctx, "Test Product", 1000)
+	created, _ := service.CreateProduct(ctx, ProductInput{Name: "Test Product", Price: 1000})
 
 	product, err := service.GetProduct(ctx, created.ID)
 	if err != nil {
@@ -118,13 +286,13 @@ func TestProductService_GetProduct(t *testing.T) {
 
 func TestProductService_GetAllProducts(t *testing.T) {
 	repo := newMockProductRepository()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockCategoryRepository(), newMockProductPriceRepository(), newMockImageStorage())
 	ctx := context.Background()
 
-	p1, _ := service.CreateProduct(ctx, "Product 1", 1000)
-	p2, _ := service.CreateProduct(ctx, "Product 2", 2000)
+	p1, _ := service.CreateProduct(ctx, ProductInput{Name: "Product 1", Price: 1000})
+	p2, _ := service.CreateProduct(ctx, ProductInput{Name: "Product 2", Price: 2000})
 
-	products, err := service.GetAllProducts(ctx)
+	products, err := service.GetAllProducts(ctx, repositories.ProductFilter{})
 	if err != nil {
 		t.Errorf("GetAllProducts failed: %v", err)
 	}
@@ -146,12 +314,12 @@ func TestProductService_GetAllProducts(t *testing.T) {
 
 func TestProductService_UpdateProduct(t *testing.T) {
 	repo := newMockProductRepository()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockCategoryRepository(), newMockProductPriceRepository(), newMockImageStorage())
 	ctx := context.Background()
 
-	created, _ := service.CreateProduct(ctx, "Test Product", 1000)
+	created, _ := service.CreateProduct(ctx, ProductInput{Name: "Test Product", Price: 1000})
 
-	updated, err := service.UpdateProduct(ctx, created.ID, "Updated Product", 2000)
+	updated, err := service.UpdateProduct(ctx, created.ID, ProductInput{Name: "Updated Product", Price: 2000})
 	if err != nil {
 		t.Errorf("UpdateProduct failed: %v", err)
 	}
@@ -167,10 +335,10 @@ func TestProductService_UpdateProduct(t *testing.T) {
 
 func TestProductService_DeleteProduct(t *testing.T) {
 	repo := newMockProductRepository()
-	service := NewProductService(repo)
+	service := NewProductService(repo, newMockCategoryRepository(), newMockProductPriceRepository(), newMockImageStorage())
 	ctx := context.Background()
 
-	created, _ := service.CreateProduct(ctx, "Test Product", 1000)
+	created, _ := service.CreateProduct(ctx, ProductInput{Name: "Test Product", Price: 1000})
 
 	err := service.DeleteProduct(ctx, created.ID)
 	if err != nil {
@@ -182,3 +350,190 @@ func TestProductService_DeleteProduct(t *testing.T) {
 		t.Error("Expected error when getting deleted product")
 	}
 }
+
+func TestProductService_FilterProducts(t *testing.T) {
+	repo := newMockProductRepository()
+	categoryRepo := newMockCategoryRepository()
+	service := NewProductService(repo, categoryRepo, newMockProductPriceRepository(), newMockImageStorage())
+	ctx := context.Background()
+
+	category := &models.Category{ID: types.ID("cat1"), Name: "Food"}
+	categoryRepo.Create(ctx, category)
+
+	yakisoba, _ := service.CreateProduct(ctx, ProductInput{
+		Name:       "Yakisoba",
+		Price:      400,
+		CategoryID: &category.ID,
+		Allergens:  types.NewAllergenSet(types.WHEAT, types.SOYBEAN),
+	})
+	service.CreateProduct(ctx, ProductInput{
+		Name:       "Crepe",
+		Price:      300,
+		CategoryID: &category.ID,
+		Allergens:  types.NewAllergenSet(types.EGG, types.MILK, types.WHEAT),
+	})
+	service.CreateProduct(ctx, ProductInput{Name: "Tea", Price: 150})
+
+	products, _ := service.GetAllProducts(ctx, repositories.ProductFilter{CategoryID: &category.ID})
+	if len(products) != 2 {
+		t.Errorf("Expected 2 products in category, got %d", len(products))
+	}
+
+	products, _ = service.GetAllProducts(ctx, repositories.ProductFilter{
+		CategoryID:       &category.ID,
+		ExcludeAllergens: types.NewAllergenSet(types.EGG),
+	})
+	if len(products) != 1 || products[0].ID != yakisoba.ID {
+		t.Errorf("Expected only egg-free product, got %v", products)
+	}
+
+	// Test unknown category is rejected
+	unknown := types.ID("unknown")
+	if _, err := service.CreateProduct(ctx, ProductInput{Name: "Juice", Price: 200, CategoryID: &unknown}); err == nil {
+		t.Error("Expected error for unknown category")
+	}
+}
+
+func TestProductService_UploadProductImage(t *testing.T) {
+	repo := newMockProductRepository()
+	images := newMockImageStorage()
+	service := NewProductService(repo, newMockCategoryRepository(), newMockProductPriceRepository(), images)
+	ctx := context.Background()
+
+	created, _ := service.CreateProduct(ctx, ProductInput{Name: "Test Product", Price: 1000})
+
+	if _, err := service.UploadProductImage(ctx, created.ID, []byte("not an image")); err != ErrUnsupportedImage {
+		t.Errorf("Expected ErrUnsupportedImage, got %v", err)
+	}
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	product, err := service.UploadProductImage(ctx, created.ID, png)
+	if err != nil {
+		t.Fatalf("UploadProductImage failed: %v", err)
+	}
+
+	if product.ImagePath != string(created.ID)+".png" {
+		t.Errorf("Expected image path %s.png, got %s", created.ID, product.ImagePath)
+	}
+
+	image, err := service.GetProductImage(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetProductImage failed: %v", err)
+	}
+	defer image.Close()
+
+	data, _ := io.ReadAll(image)
+	if !bytes.Equal(data, png) {
+		t.Error("Expected stored image to be returned")
+	}
+}
+
+func TestProductService_CreateBundle(t *testing.T) {
+	repo := newMockProductRepository()
+	service := NewProductService(repo, newMockCategoryRepository(), newMockProductPriceRepository(), newMockImageStorage())
+	ctx := context.Background()
+
+	yakisoba, _ := service.CreateProduct(ctx, ProductInput{
+		Name:      "Yakisoba",
+		Price:     400,
+		Allergens: types.NewAllergenSet(types.WHEAT),
+	})
+	drink, _ := service.CreateProduct(ctx, ProductInput{Name: "Drink", Price: 150})
+
+	bundle, err := service.CreateProduct(ctx, ProductInput{
+		Name:  "Yakisoba set",
+		Price: 500,
+		Components: []BundleComponentInput{
+			{ProductID: yakisoba.ID, Quantity: 1},
+			{ProductID: drink.ID, Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct failed: %v", err)
+	}
+
+	if !bundle.IsBundle || len(bundle.Components) != 2 {
+		t.Errorf("Expected bundle with 2 components, got %v", bundle.Components)
+	}
+
+	if !bundle.Allergens.Has(types.WHEAT) {
+		t.Error("Expected bundle to inherit component allergens")
+	}
+
+	// Test bundles cannot contain bundles
+	_, err = service.CreateProduct(ctx, ProductInput{
+		Name:       "Double set",
+		Price:      900,
+		Components: []BundleComponentInput{{ProductID: bundle.ID, Quantity: 2}},
+	})
+	if err != ErrInvalidBundle {
+		t.Errorf("Expected ErrInvalidBundle, got %v", err)
+	}
+}
+
+func TestProductService_PriceHistory(t *testing.T) {
+	repo := newMockProductRepository()
+	priceRepo := newMockProductPriceRepository()
+	service := NewProductService(repo, newMockCategoryRepository(), priceRepo, newMockImageStorage())
+	ctx := context.Background()
+
+	created, _ := service.CreateProduct(ctx, ProductInput{Name: "Yakisoba", Price: 400})
+	service.UpdateProduct(ctx, created.ID, ProductInput{Name: "Yakisoba", Price: 450})
+	service.UpdateProduct(ctx, created.ID, ProductInput{Name: "Yakisoba (large)", Price: 450})
+
+	history, err := service.GetPriceHistory(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetPriceHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 price entries, got %d", len(history))
+	}
+
+	// Test scheduling
+	if _, err := service.SchedulePriceChange(ctx, created.ID, 300, time.Now().Add(-time.Minute)); err != ErrInvalidPriceChange {
+		t.Errorf("Expected ErrInvalidPriceChange for past time, got %v", err)
+	}
+
+	scheduled, err := service.SchedulePriceChange(ctx, created.ID, 300, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SchedulePriceChange failed: %v", err)
+	}
+
+	if err := service.ApplyScheduledPrices(ctx); err != nil {
+		t.Fatalf("ApplyScheduledPrices failed: %v", err)
+	}
+	product, _ := service.GetProduct(ctx, created.ID)
+	if product.Price != 450 {
+		t.Errorf("Expected price 450 before the change, got %d", product.Price)
+	}
+
+	// Move the whole history into the past, keeping its order
+	for _, p := range priceRepo.prices {
+		p.EffectiveFrom = p.EffectiveFrom.Add(-2 * time.Hour)
+	}
+	if err := service.ApplyScheduledPrices(ctx); err != nil {
+		t.Fatalf("ApplyScheduledPrices failed: %v", err)
+	}
+	product, _ = service.GetProduct(ctx, created.ID)
+	if product.Price != 300 {
+		t.Errorf("Expected price 300 after the change, got %d", product.Price)
+	}
+
+	if err := service.CancelPriceChange(ctx, created.ID, scheduled.ID); err != ErrInvalidPriceChange {
+		t.Errorf("Expected ErrInvalidPriceChange for applied change, got %v", err)
+	}
+}
+
+func TestProductService_RejectsInvalidInput(t *testing.T) {
+	service := NewProductService(newMockProductRepository(), newMockCategoryRepository(), newMockProductPriceRepository(), newMockImageStorage())
+	ctx := context.Background()
+
+	for _, input := range []ProductInput{
+		{Name: " ", Price: 100},
+		{Name: "Test Product", Price: -1},
+	} {
+		if _, err := service.CreateProduct(ctx, input); err != ErrInvalidProduct {
+			t.Errorf("Expected ErrInvalidProduct for %+v, got %v", input, err)
+		}
+	}
+}
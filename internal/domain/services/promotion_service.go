@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, input PromotionInput) (*models.Promotion, error)
+	GetPromotion(ctx context.Context, id types.ID) (*models.Promotion, error)
+	GetAllPromotions(ctx context.Context) ([]models.Promotion, error)
+	UpdatePromotion(ctx context.Context, id types.ID, input PromotionInput) (*models.Promotion, error)
+	DeletePromotion(ctx context.Context, id types.ID) error
+	GetUsageReport(ctx context.Context) ([]repositories.PromotionUsage, error)
+}
+
+type PromotionInput struct {
+	Name         string
+	Type         types.PromotionType
+	Value        int
+	BuyQuantity  int
+	FreeQuantity int
+	ProductID    *types.ID
+	SalesSlotID  *types.ID
+	Code         *string
+	MinAmount    int
+	UsageLimit   *int
+	StartsAt     *time.Time
+	EndsAt       *time.Time
+	IsActive     bool
+}
+
+type promotionService struct {
+	repo        repositories.PromotionRepository
+	productRepo repositories.ProductRepository
+}
+
+func NewPromotionService(repo repositories.PromotionRepository, productRepo repositories.ProductRepository) PromotionService {
+	return &promotionService{
+		repo:        repo,
+		productRepo: productRepo,
+	}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, input PromotionInput) (*models.Promotion, error) {
+	promotion := &models.Promotion{
+		ID: types.ID(uuid.New().String()),
+	}
+	if err := s.applyPromotionInput(ctx, promotion, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) GetPromotion(ctx context.Context, id types.ID) (*models.Promotion, error) {
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *promotionService) GetAllPromotions(ctx context.Context) ([]models.Promotion, error) {
+	return s.repo.FindAll(ctx)
+}
+
+func (s *promotionService) UpdatePromotion(ctx context.Context, id types.ID, input PromotionInput) (*models.Promotion, error) {
+	promotion, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyPromotionInput(ctx, promotion, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+func (s *promotionService) DeletePromotion(ctx context.Context, id types.ID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *promotionService) GetUsageReport(ctx context.Context) ([]repositories.PromotionUsage, error) {
+	return s.repo.SummarizeUsage(ctx)
+}
+
+func (s *promotionService) applyPromotionInput(ctx context.Context, promotion *models.Promotion, input PromotionInput) error {
+	switch input.Type {
+	case types.PERCENTAGE:
+		if input.Value <= 0 || input.Value > 100 {
+			return ErrInvalidPromotion
+		}
+	case types.FIXED_AMOUNT:
+		if input.Value <= 0 {
+			return ErrInvalidPromotion
+		}
+	case types.BUY_X_GET_Y:
+		if input.ProductID == nil || input.BuyQuantity <= 0 || input.FreeQuantity <= 0 {
+			return ErrInvalidPromotion
+		}
+	default:
+		return ErrInvalidPromotion
+	}
+	if input.StartsAt != nil && input.EndsAt != nil && !input.StartsAt.Before(*input.EndsAt) {
+		return ErrInvalidTimeRange
+	}
+	if input.UsageLimit != nil && *input.UsageLimit <= 0 {
+		return ErrInvalidPromotion
+	}
+	if input.ProductID != nil {
+		if _, err := s.productRepo.FindByID(ctx, *input.ProductID); err != nil {
+			return err
+		}
+	}
+
+	var code *string
+	if input.Code != nil && strings.TrimSpace(*input.Code) != "" {
+		normalized := normalizeCouponCode(*input.Code)
+		code = &normalized
+	}
+
+	promotion.Name = input.Name
+	promotion.Type = input.Type
+	promotion.Value = input.Value
+	promotion.BuyQuantity = input.BuyQuantity
+	promotion.FreeQuantity = input.FreeQuantity
+	promotion.ProductID = input.ProductID
+	promotion.SalesSlotID = input.SalesSlotID
+	promotion.Code = code
+	promotion.MinAmount = input.MinAmount
+	promotion.UsageLimit = input.UsageLimit
+	promotion.StartsAt = input.StartsAt
+	promotion.EndsAt = input.EndsAt
+	promotion.IsActive = input.IsActive
+	return nil
+}
+
+// Coupon codes are matched case-insensitively.
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// applyPromotions works out the discounts for an order. Automatic promotions
+// apply whenever they match; every coupon code must match a promotion that is
+// currently available, or the order is refused. Discounts are applied in
+// order and never take the total below zero.
+func applyPromotions(
+	ctx context.Context,
+	repo repositories.PromotionRepository,
+	salesSlotID types.ID,
+	items []models.OrderItem,
+	couponCodes []string,
+	at time.Time,
+) ([]models.OrderDiscount, error) {
+	promotions, err := repo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []models.Promotion
+	for _, p := range promotions {
+		if p.Code == nil {
+			candidates = append(candidates, p)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range couponCodes {
+		code = normalizeCouponCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		promotion, err := repo.FindByCode(ctx, code)
+		if err != nil {
+			return nil, ErrInvalidCoupon
+		}
+		if !promotion.IsAvailable(salesSlotID, at) {
+			return nil, ErrInvalidCoupon
+		}
+		candidates = append(candidates, *promotion)
+	}
+
+	remaining := 0
+	for _, item := range items {
+		remaining += item.GetSubtotal()
+	}
+	subtotal := remaining
+
+	var discounts []models.OrderDiscount
+	for _, p := range candidates {
+		if !p.IsAvailable(salesSlotID, at) || subtotal < p.MinAmount {
+			if p.Code != nil {
+				return nil, ErrInvalidCoupon
+			}
+			continue
+		}
+
+		if p.UsageLimit != nil {
+			used, err := repo.CountUsage(ctx, p.ID)
+			if err != nil {
+				return nil, err
+			}
+			if used >= *p.UsageLimit {
+				if p.Code != nil {
+					return nil, ErrCouponUsedUp
+				}
+				continue
+			}
+		}
+
+		amount := discountAmount(&p, items)
+		if amount > remaining {
+			amount = remaining
+		}
+		if amount <= 0 {
+			continue
+		}
+		remaining -= amount
+
+		discounts = append(discounts, models.OrderDiscount{
+			PromotionID: p.ID,
+			ProductID:   p.ProductID,
+			Name:        p.Name,
+			Code:        p.Code,
+			Amount:      amount,
+		})
+	}
+	return discounts, nil
+}
+
+// reapplyDiscounts works the order's discounts out again for its changed
+// items. Only the promotions already on the order are considered, as they
+// were when it was placed; those whose items or minimum amount no longer
+// qualify are dropped, and the total never goes below zero. A discount whose
+// promotion has since been deleted keeps its amount.
+func reapplyDiscounts(
+	ctx context.Context,
+	repo repositories.PromotionRepository,
+	discounts []models.OrderDiscount,
+	items []models.OrderItem,
+) ([]models.OrderDiscount, error) {
+	remaining := 0
+	for _, item := range items {
+		remaining += item.GetSubtotal()
+	}
+	subtotal := remaining
+
+	var result []models.OrderDiscount
+	for _, d := range discounts {
+		promotion, err := repo.FindByID(ctx, d.PromotionID)
+		var notFound *repositories.ErrNotFound
+		switch {
+		case err == nil:
+			if subtotal < promotion.MinAmount {
+				continue
+			}
+			d.Amount = discountAmount(promotion, items)
+		case !errors.As(err, &notFound):
+			return nil, err
+		}
+
+		d.Amount = min(d.Amount, remaining)
+		if d.Amount <= 0 {
+			continue
+		}
+		remaining -= d.Amount
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+func discountAmount(p *models.Promotion, items []models.OrderItem) int {
+	// Collect the unit price of every unit the promotion targets.
+	var prices []int
+	base := 0
+	for _, item := range items {
+		if p.ProductID != nil && item.ProductID != *p.ProductID {
+			continue
+		}
+		base += item.GetSubtotal()
+		for i := 0; i < item.Quantity; i++ {
+			prices = append(prices, item.GetUnitPrice())
+		}
+	}
+
+	switch p.Type {
+	case types.PERCENTAGE:
+		return base * p.Value / 100
+	case types.FIXED_AMOUNT:
+		if base == 0 {
+			return 0
+		}
+		return min(p.Value, base)
+	case types.BUY_X_GET_Y:
+		free := len(prices) / (p.BuyQuantity + p.FreeQuantity) * p.FreeQuantity
+		// The cheapest units are the free ones.
+		sort.Ints(prices)
+		amount := 0
+		for _, price := range prices[:free] {
+			amount += price
+		}
+		return amount
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type mockPromotionRepository struct {
+	promotions map[types.ID]*models.Promotion
+	usage      map[types.ID]int
+}
+
+func newMockPromotionRepository() *mockPromotionRepository {
+	return &mockPromotionRepository{
+		promotions: make(map[types.ID]*models.Promotion),
+		usage:      make(map[types.ID]int),
+	}
+}
+
+func (r *mockPromotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	r.promotions[promotion.ID] = promotion
+	return nil
+}
+
+func (r *mockPromotionRepository) FindByID(ctx context.Context, id types.ID) (*models.Promotion, error) {
+	if promotion, exists := r.promotions[id]; exists {
+		return promotion, nil
+	}
+	return nil, repositories.NewErrNotFound("Promotion", id)
+}
+
+func (r *mockPromotionRepository) FindAll(ctx context.Context) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	for _, p := range r.promotions {
+		promotions = append(promotions, *p)
+	}
+	return promotions, nil
+}
+
+func (r *mockPromotionRepository) Update(ctx context.Context, promotion *models.Promotion) error {
+	if _, exists := r.promotions[promotion.ID]; !exists {
+		return repositories.NewErrNotFound("Promotion", promotion.ID)
+	}
+	r.promotions[promotion.ID] = promotion
+	return nil
+}
+
+func (r *mockPromotionRepository) Delete(ctx context.Context, id types.ID) error {
+	if _, exists := r.promotions[id]; !exists {
+		return repositories.NewErrNotFound("Promotion", id)
+	}
+	delete(r.promotions, id)
+	return nil
+}
+
+func (r *mockPromotionRepository) FindActive(ctx context.Context) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	for _, p := range r.promotions {
+		if p.IsActive {
+			promotions = append(promotions, *p)
+		}
+	}
+	return promotions, nil
+}
+
+func (r *mockPromotionRepository) FindByCode(ctx context.Context, code string) (*models.Promotion, error) {
+	for _, p := range r.promotions {
+		if p.Code != nil && *p.Code == code {
+			return p, nil
+		}
+	}
+	return nil, repositories.NewErrNotFound("Promotion", types.ID(code))
+}
+
+func (r *mockPromotionRepository) CountUsage(ctx context.Context, promotionID types.ID) (int, error) {
+	return r.usage[promotionID], nil
+}
+
+func (r *mockPromotionRepository) SummarizeUsage(ctx context.Context) ([]repositories.PromotionUsage, error) {
+	var usage []repositories.PromotionUsage
+	for id, uses := range r.usage {
+		usage = append(usage, repositories.PromotionUsage{PromotionID: id, Uses: uses})
+	}
+	return usage, nil
+}
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	repo := newMockPromotionRepository()
+	service := NewPromotionService(repo, newMockProductRepository())
+	ctx := context.Background()
+
+	code := " staff "
+	promotion, err := service.CreatePromotion(ctx, PromotionInput{
+		Name:     "Staff discount",
+		Type:     types.PERCENTAGE,
+		Value:    20,
+		Code:     &code,
+		IsActive: true,
+	})
+	if err != nil {
+		t.Fatalf("CreatePromotion failed: %v", err)
+	}
+
+	if *promotion.Code != "STAFF" {
+		t.Errorf("Expected normalized code STAFF, got %s", *promotion.Code)
+	}
+
+	// Test invalid percentage
+	_, err = service.CreatePromotion(ctx, PromotionInput{Name: "Too much", Type: types.PERCENTAGE, Value: 150})
+	if err != ErrInvalidPromotion {
+		t.Errorf("Expected ErrInvalidPromotion, got %v", err)
+	}
+
+	// Test buy X get Y requires a product
+	_, err = service.CreatePromotion(ctx, PromotionInput{Name: "3 for 2", Type: types.BUY_X_GET_Y, BuyQuantity: 2, FreeQuantity: 1})
+	if err != ErrInvalidPromotion {
+		t.Errorf("Expected ErrInvalidPromotion, got %v", err)
+	}
+}
+
+func TestPromotionService_InactivePromotion(t *testing.T) {
+	repo := newMockPromotionRepository()
+	service := NewPromotionService(repo, newMockProductRepository())
+	ctx := context.Background()
+
+	input := PromotionInput{Name: "Next week", Type: types.FIXED_AMOUNT, Value: 100}
+	promotion, err := service.CreatePromotion(ctx, input)
+	if err != nil {
+		t.Fatalf("CreatePromotion failed: %v", err)
+	}
+	if stored, _ := repo.FindByID(ctx, promotion.ID); stored.IsActive {
+		t.Error("Expected the promotion to be created inactive")
+	}
+	if active, _ := repo.FindActive(ctx); len(active) != 0 {
+		t.Errorf("Expected no active promotions, got %+v", active)
+	}
+
+	input.IsActive = true
+	if _, err := service.UpdatePromotion(ctx, promotion.ID, input); err != nil {
+		t.Fatalf("UpdatePromotion failed: %v", err)
+	}
+	input.IsActive = false
+	if _, err := service.UpdatePromotion(ctx, promotion.ID, input); err != nil {
+		t.Fatalf("UpdatePromotion failed: %v", err)
+	}
+	if stored, _ := repo.FindByID(ctx, promotion.ID); stored.IsActive {
+		t.Error("Expected the promotion to be deactivated")
+	}
+}
+
+func TestApplyPromotions(t *testing.T) {
+	repo := newMockPromotionRepository()
+	ctx := context.Background()
+	now := time.Now()
+	slotID := types.ID("slot1")
+	drinkID := types.ID("drink")
+	yesterday := now.Add(-24 * time.Hour)
+	staff := "STAFF"
+	limit := 1
+
+	repo.Create(ctx, &models.Promotion{
+		ID:           types.ID("drinks"),
+		Name:         "Drinks 3 for 2",
+		Type:         types.BUY_X_GET_Y,
+		ProductID:    &drinkID,
+		BuyQuantity:  2,
+		FreeQuantity: 1,
+		IsActive:     true,
+	})
+	repo.Create(ctx, &models.Promotion{
+		ID:       types.ID("expired"),
+		Name:     "Opening sale",
+		Type:     types.FIXED_AMOUNT,
+		Value:    100,
+		EndsAt:   &yesterday,
+		IsActive: true,
+	})
+	repo.Create(ctx, &models.Promotion{
+		ID:         types.ID("staff"),
+		Name:       "Staff discount",
+		Type:       types.PERCENTAGE,
+		Value:      10,
+		Code:       &staff,
+		UsageLimit: &limit,
+		IsActive:   true,
+	})
+
+	items := []models.OrderItem{
+		{ProductID: drinkID, Quantity: 3, Price: 150},
+		{ProductID: types.ID("yakisoba"), Quantity: 1, Price: 400},
+	}
+
+	discounts, err := applyPromotions(ctx, repo, slotID, items, nil, now)
+	if err != nil {
+		t.Fatalf("applyPromotions failed: %v", err)
+	}
+	if len(discounts) != 1 || discounts[0].Amount != 150 {
+		t.Errorf("Expected one free drink, got %v", discounts)
+	}
+
+	discounts, err = applyPromotions(ctx, repo, slotID, items, []string{"staff"}, now)
+	if err != nil {
+		t.Fatalf("applyPromotions failed: %v", err)
+	}
+	if len(discounts) != 2 || discounts[1].Amount != 85 {
+		t.Errorf("Expected staff discount of 85, got %v", discounts)
+	}
+
+	if _, err := applyPromotions(ctx, repo, slotID, items, []string{"UNKNOWN"}, now); err != ErrInvalidCoupon {
+		t.Errorf("Expected ErrInvalidCoupon, got %v", err)
+	}
+
+	repo.usage[types.ID("staff")] = 1
+	if _, err := applyPromotions(ctx, repo, slotID, items, []string{"STAFF"}, now); err != ErrCouponUsedUp {
+		t.Errorf("Expected ErrCouponUsedUp, got %v", err)
+	}
+}
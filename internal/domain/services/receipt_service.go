@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type ReceiptService interface {
+	RenderTicket(ctx context.Context, ticketID types.ID, kind receipt.Kind, format receipt.Format) ([]byte, error)
+	PrintTicket(ctx context.Context, ticketID types.ID, kind receipt.Kind, reprint bool) error
+}
+
+type receiptService struct {
+	ticketRepo repositories.OrderTicketRepository
+	orderRepo  repositories.OrderRepository
+	renderer   receipt.Renderer
+	printer    receipt.Printer
+}
+
+// NewReceiptService creates a receipt service. printer may be nil when no
+// printer is configured, in which case documents can only be downloaded.
+func NewReceiptService(
+	ticketRepo repositories.OrderTicketRepository,
+	orderRepo repositories.OrderRepository,
+	renderer receipt.Renderer,
+	printer receipt.Printer,
+) ReceiptService {
+	return &receiptService{
+		ticketRepo: ticketRepo,
+		orderRepo:  orderRepo,
+		renderer:   renderer,
+		printer:    printer,
+	}
+}
+
+func (s *receiptService) RenderTicket(ctx context.Context, ticketID types.ID, kind receipt.Kind, format receipt.Format) ([]byte, error) {
+	doc, err := s.buildDocument(ctx, ticketID, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderer.Render(doc, format)
+}
+
+func (s *receiptService) PrintTicket(ctx context.Context, ticketID types.ID, kind receipt.Kind, reprint bool) error {
+	if s.printer == nil {
+		return ErrPrinterNotConfigured
+	}
+
+	doc, err := s.buildDocument(ctx, ticketID, kind)
+	if err != nil {
+		return err
+	}
+	doc.Reprint = reprint
+
+	data, err := s.renderer.Render(doc, receipt.FormatESCPOS)
+	if err != nil {
+		return err
+	}
+
+	return s.printer.Print(ctx, data)
+}
+
+func (s *receiptService) buildDocument(ctx context.Context, ticketID types.ID, kind receipt.Kind) (*receipt.Document, error) {
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, ticket.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt.NewDocument(kind, order, ticket), nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+type mockReceiptRenderer struct {
+	lastDoc    *receipt.Document
+	lastFormat receipt.Format
+}
+
+func (r *mockReceiptRenderer) Render(doc *receipt.Document, format receipt.Format) ([]byte, error) {
+	r.lastDoc = doc
+	r.lastFormat = format
+	return []byte(string(format) + ":" + doc.TicketNumber), nil
+}
+
+type mockPrinter struct {
+	jobs [][]byte
+}
+
+func (p *mockPrinter) Print(ctx context.Context, data []byte) error {
+	p.jobs = append(p.jobs, data)
+	return nil
+}
+
+func setupReceiptTest(ctx context.Context) (*mockOrderTicketRepository, *mockOrderRepository, *models.OrderTicket) {
+	ticketRepo := newMockOrderTicketRepository()
+	orderRepo := newMockOrderRepository()
+
+	order := &models.Order{
+		ID:          types.ID("order1"),
+		Status:      types.CONFIRMED,
+		TotalAmount: 1200,
+		Items: []models.OrderItem{
+			{
+				ProductID: types.ID("prod1"),
+				Quantity:  3,
+				Price:     400,
+				Product:   &models.Product{ID: types.ID("prod1"), Name: "焼きそば", Price: 400},
+			},
+		},
+	}
+	orderRepo.Create(ctx, order)
+
+	tendered, change := 2000, 800
+	ticket := &models.OrderTicket{
+		ID:             types.ID("ticket1"),
+		TicketNumber:   "A001",
+		OrderID:        order.ID,
+		PaymentMethod:  types.CASH,
+		TenderedAmount: &tendered,
+		ChangeAmount:   &change,
+		IsPaid:         true,
+	}
+	ticketRepo.Create(ctx, ticket)
+
+	return ticketRepo, orderRepo, ticket
+}
+
+func TestReceiptService_RenderTicket(t *testing.T) {
+	ctx := context.Background()
+	ticketRepo, orderRepo, ticket := setupReceiptTest(ctx)
+	renderer := &mockReceiptRenderer{}
+	service := NewReceiptService(ticketRepo, orderRepo, renderer, nil)
+
+	data, err := service.RenderTicket(ctx, ticket.ID, receipt.KindReceipt, receipt.FormatPDF)
+	if err != nil {
+		t.Fatalf("RenderTicket failed: %v", err)
+	}
+
+	if string(data) != "pdf:A001" {
+		t.Errorf("Unexpected rendered output %q", data)
+	}
+
+	doc := renderer.lastDoc
+	if len(doc.Items) != 1 || doc.Items[0].Name != "焼きそば" || doc.Items[0].Subtotal != 1200 {
+		t.Errorf("Unexpected receipt items %+v", doc.Items)
+	}
+
+	if doc.TotalAmount != 1200 {
+		t.Errorf("Expected total amount 1200, got %d", doc.TotalAmount)
+	}
+
+	if doc.ChangeAmount == nil || *doc.ChangeAmount != 800 {
+		t.Errorf("Expected change amount 800, got %v", doc.ChangeAmount)
+	}
+
+	// Kitchen slips only carry items and the ticket number
+	_, err = service.RenderTicket(ctx, ticket.ID, receipt.KindKitchenSlip, receipt.FormatESCPOS)
+	if err != nil {
+		t.Fatalf("RenderTicket failed: %v", err)
+	}
+
+	doc = renderer.lastDoc
+	if doc.TicketNumber != "A001" || len(doc.Items) != 1 {
+		t.Errorf("Unexpected kitchen slip %+v", doc)
+	}
+
+	if doc.TotalAmount != 0 || doc.PaymentMethod != "" || doc.TenderedAmount != nil {
+		t.Errorf("Expected kitchen slip without payment details, got %+v", doc)
+	}
+}
+
+func TestReceiptService_PrintTicket(t *testing.T) {
+	ctx := context.Background()
+	ticketRepo, orderRepo, ticket := setupReceiptTest(ctx)
+	renderer := &mockReceiptRenderer{}
+
+	service := NewReceiptService(ticketRepo, orderRepo, renderer, nil)
+	if err := service.PrintTicket(ctx, ticket.ID, receipt.KindReceipt, false); err != ErrPrinterNotConfigured {
+		t.Errorf("Expected ErrPrinterNotConfigured, got %v", err)
+	}
+
+	printer := &mockPrinter{}
+	service = NewReceiptService(ticketRepo, orderRepo, renderer, printer)
+	if err := service.PrintTicket(ctx, ticket.ID, receipt.KindReceipt, true); err != nil {
+		t.Fatalf("PrintTicket failed: %v", err)
+	}
+
+	if len(printer.jobs) != 1 {
+		t.Fatalf("Expected 1 print job, got %d", len(printer.jobs))
+	}
+
+	if renderer.lastFormat != receipt.FormatESCPOS {
+		t.Errorf("Expected ESC/POS output, got %v", renderer.lastFormat)
+	}
+
+	if !renderer.lastDoc.Reprint {
+		t.Error("Expected document to be marked as reprint")
+	}
+}
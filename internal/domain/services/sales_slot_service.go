@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
@@ -11,45 +14,101 @@ import (
 )
 
 type SalesSlotService interface {
-	CreateSalesSlot(ctx context.Context, startTime, endTime time.Time) (*models.SalesSlot, error)
+	CreateSalesSlot(ctx context.Context, booth string, startTime, endTime time.Time) (*models.SalesSlot, error)
 	GetSalesSlot(ctx context.Context, id types.ID) (*models.SalesSlot, error)
 	GetAllSalesSlots(ctx context.Context) ([]models.SalesSlot, error)
 	FindByTimeRange(ctx context.Context, startTime, endTime time.Time) ([]models.SalesSlot, error)
+	GenerateSalesSlots(ctx context.Context, pattern SlotPattern, dryRun bool) ([]GeneratedSlot, error)
+	UpdateSalesSlot(ctx context.Context, id types.ID, booth string, startTime, endTime time.Time, force bool) (*models.SalesSlot, error)
+	DeleteSalesSlot(ctx context.Context, id types.ID, force bool) error
 	ActivateSalesSlot(ctx context.Context, id types.ID) error
 	DeactivateSalesSlot(ctx context.Context, id types.ID) error
-	AddProductToSlot(ctx context.Context, slotID types.ID, productID types.ID, initialQuantity int) (*models.ProductInventory, error)
-	UpdateInventory(ctx context.Context, slotID types.ID, productID types.ID, reserved, sold int) error
+	SetCapacity(ctx context.Context, id types.ID, maxOrders, maxItems *int) (*models.SalesSlot, error)
+	SetOverride(ctx context.Context, id types.ID, override types.SlotOverride) (*models.SalesSlot, error)
+	GetTransitions(ctx context.Context, id types.ID) ([]models.SalesSlotTransition, error)
+	ApplySchedule(ctx context.Context) error
+	AddProductToSlot(ctx context.Context, slotID types.ID, productID types.ID, initialQuantity int, price *int) (*models.ProductInventory, error)
+	SetSlotPrice(ctx context.Context, slotID types.ID, productID types.ID, price *int) (*models.ProductInventory, error)
+	SetPurchaseLimits(ctx context.Context, slotID types.ID, productID types.ID, maxPerOrder, maxPerCustomer *int) (*models.ProductInventory, error)
+	SetLowStockThreshold(ctx context.Context, slotID types.ID, productID types.ID, threshold *int) (*models.ProductInventory, error)
+	SetAvailability(ctx context.Context, slotID types.ID, productID types.ID, available bool) (*models.ProductInventory, error)
+	GetStockLedger(ctx context.Context, slotID types.ID, productID types.ID) (*StockLedger, error)
+	AdjustStock(ctx context.Context, slotID types.ID, productID types.ID, movementType types.StockMovementType, quantity int, reason string) (*StockLedger, error)
+	ReconcileStock(ctx context.Context, slotID types.ID, productID types.ID) (*StockLedger, error)
+	TransferStock(ctx context.Context, fromSlotID, toSlotID types.ID, productID types.ID, quantity int, reason string) (*StockTransfer, error)
+	RollOverStock(ctx context.Context, slotID types.ID) ([]StockTransfer, error)
 	GetSlotInventories(ctx context.Context, slotID types.ID) ([]models.ProductInventory, error)
 }
 
+// SlotSchedule is when slots without an override are open: from OpenBefore
+// before their start time until CloseAfter after their end time. Overlap
+// decides which slots may share time.
+type SlotSchedule struct {
+	OpenBefore time.Duration
+	CloseAfter time.Duration
+	Overlap    types.SlotOverlapPolicy
+}
+
+func (s SlotSchedule) isOpen(slot *models.SalesSlot, at time.Time) bool {
+	return !at.Before(slot.StartTime.Add(-s.OpenBefore)) && at.Before(slot.EndTime.Add(s.CloseAfter))
+}
+
 type salesSlotService struct {
-	slotRepo repositories.SalesSlotRepository
-	invRepo  repositories.ProductInventoryRepository
-	prodRepo repositories.ProductRepository
+	slotRepo       repositories.SalesSlotRepository
+	invRepo        repositories.ProductInventoryRepository
+	prodRepo       repositories.ProductRepository
+	orderRepo      repositories.OrderRepository
+	ingredientRepo repositories.IngredientRepository
+	schedule       SlotSchedule
+	publisher      events.Publisher
+	alerts         stockAlerts
 }
 
 func NewSalesSlotService(
 	slotRepo repositories.SalesSlotRepository,
 	invRepo repositories.ProductInventoryRepository,
 	prodRepo repositories.ProductRepository,
+	orderRepo repositories.OrderRepository,
+	ingredientRepo repositories.IngredientRepository,
+	schedule SlotSchedule,
+	publisher events.Publisher,
 ) SalesSlotService {
 	return &salesSlotService{
-		slotRepo: slotRepo,
-		invRepo:  invRepo,
-		prodRepo: prodRepo,
+		slotRepo:       slotRepo,
+		invRepo:        invRepo,
+		prodRepo:       prodRepo,
+		orderRepo:      orderRepo,
+		ingredientRepo: ingredientRepo,
+		schedule:       schedule,
+		publisher:      publisher,
+		alerts:         stockAlerts{invRepo: invRepo, publisher: publisher},
 	}
 }
 
-func (s *salesSlotService) CreateSalesSlot(ctx context.Context, startTime, endTime time.Time) (*models.SalesSlot, error) {
-	if endTime.Before(startTime) {
+func (s *salesSlotService) CreateSalesSlot(ctx context.Context, booth string, startTime, endTime time.Time) (*models.SalesSlot, error) {
+	if !endTime.After(startTime) {
 		return nil, ErrInvalidTimeRange
 	}
 
+	booth = strings.TrimSpace(booth)
+	overlapping, err := s.slotRepo.FindOverlapping(ctx, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	if conflicts := s.conflicting(overlapping, "", booth); len(conflicts) > 0 {
+		return nil, &SlotConflictError{
+			Message:   "販売枠の時間が他の販売枠と重なっています",
+			Conflicts: conflicts,
+		}
+	}
+
 	slot := &models.SalesSlot{
 		ID:        types.ID(uuid.New().String()),
+		Booth:     booth,
 		StartTime: startTime,
 		EndTime:   endTime,
 		IsActive:  false,
+		Override:  types.AUTO,
 	}
 
 	if err := s.slotRepo.Create(ctx, slot); err != nil {
@@ -60,35 +119,510 @@ func (s *salesSlotService) CreateSalesSlot(ctx context.Context, startTime, endTi
 }
 
 func (s *salesSlotService) GetSalesSlot(ctx context.Context, id types.ID) (*models.SalesSlot, error) {
-	return s.slotRepo.FindByID(ctx, id)
+	slot, err := s.slotRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	slots := []models.SalesSlot{*slot}
+	if err := s.countUsage(ctx, slots); err != nil {
+		return nil, err
+	}
+	return &slots[0], nil
 }
 
 func (s *salesSlotService) GetAllSalesSlots(ctx context.Context) ([]models.SalesSlot, error) {
-	return s.slotRepo.FindAll(ctx)
+	slots, err := s.slotRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slots, s.countUsage(ctx, slots)
 }
 
 func (s *salesSlotService) FindByTimeRange(ctx context.Context, startTime, endTime time.Time) ([]models.SalesSlot, error) {
-	return s.slotRepo.FindByTimeRange(ctx, startTime, endTime)
+	slots, err := s.slotRepo.FindByTimeRange(ctx, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	return slots, s.countUsage(ctx, slots)
 }
 
+// UpdateSalesSlot moves the slot to another booth or time. Orders are kept,
+// so the times of a slot with orders are only changed when forced.
+func (s *salesSlotService) UpdateSalesSlot(ctx context.Context, id types.ID, booth string, startTime, endTime time.Time, force bool) (*models.SalesSlot, error) {
+	if !endTime.After(startTime) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	slot, err := s.slotRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	booth = strings.TrimSpace(booth)
+
+	if !force && (!slot.StartTime.Equal(startTime) || !slot.EndTime.Equal(endTime)) {
+		if err := s.checkNoOrders(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	overlapping, err := s.slotRepo.FindOverlapping(ctx, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	if conflicts := s.conflicting(overlapping, id, booth); len(conflicts) > 0 {
+		return nil, &SlotConflictError{
+			Message:   "販売枠の時間が他の販売枠と重なっています",
+			Conflicts: conflicts,
+		}
+	}
+	if slot.IsActive && booth != slot.Booth {
+		moved := *slot
+		moved.Booth = booth
+		if err := s.checkActiveInBooth(ctx, &moved); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.slotRepo.UpdateTimes(ctx, id, booth, startTime, endTime); err != nil {
+		return nil, err
+	}
+	return s.GetSalesSlot(ctx, id)
+}
+
+// DeleteSalesSlot deletes the slot and its stock. A slot with orders is only
+// deleted when forced, which cancels the orders, releases their reserved
+// stock and voids their unpaid tickets. Slots with confirmed or paid orders
+// are never deleted; reserved orders have used no ingredients yet, so there
+// are none to put back.
+func (s *salesSlotService) DeleteSalesSlot(ctx context.Context, id types.ID, force bool) error {
+	if _, err := s.slotRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	orders, err := s.orderRepo.FindBySalesSlotID(ctx, id)
+	if err != nil {
+		return err
+	}
+	var orderIDs []types.ID
+	var movements []models.StockMovement
+	for _, order := range orders {
+		if order.Status == types.CANCELLED {
+			continue
+		}
+		if !force {
+			return ErrSlotHasOrders
+		}
+		if order.Status == types.CONFIRMED || (order.Ticket != nil && order.Ticket.IsPaid) {
+			return ErrSlotHasSales
+		}
+		orderIDs = append(orderIDs, order.ID)
+		movements = append(movements, orderMovements(&order, order.Items, -1, 0, "販売枠の削除")...)
+	}
+
+	err = s.slotRepo.DeleteWithOrders(ctx, id, orderIDs, movements)
+	if errors.Is(err, repositories.ErrOrderStatusChanged) {
+		return ErrSlotHasSales
+	}
+	return err
+}
+
+// checkNoOrders returns ErrSlotHasOrders when the slot has orders that were
+// not cancelled.
+func (s *salesSlotService) checkNoOrders(ctx context.Context, id types.ID) error {
+	usages, err := s.orderRepo.CountBySalesSlots(ctx, []types.ID{id})
+	if err != nil {
+		return err
+	}
+	for _, u := range usages {
+		if u.Orders > 0 {
+			return ErrSlotHasOrders
+		}
+	}
+	return nil
+}
+
+// GenerateSalesSlots creates the slots of the pattern and adds the pattern's
+// products to them. Slots that already exist at the same booth and times are
+// kept, and only products they lack are added, so a pattern can be generated
+// again after it was extended or a run was interrupted. Nothing is written
+// when any slot overlaps another, or on a dry run.
+func (s *salesSlotService) GenerateSalesSlots(ctx context.Context, pattern SlotPattern, dryRun bool) ([]GeneratedSlot, error) {
+	pattern.Booth = strings.TrimSpace(pattern.Booth)
+	times, err := pattern.slots()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tp := range pattern.Products {
+		product, err := s.prodRepo.FindByID(ctx, tp.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if product.IsBundle {
+			return nil, ErrBundleInventory
+		}
+	}
+
+	generated := make([]GeneratedSlot, len(times))
+	var conflicts []models.SalesSlot
+	for i, t := range times {
+		overlapping, err := s.slotRepo.FindOverlapping(ctx, t[0], t[1])
+		if err != nil {
+			return nil, err
+		}
+
+		g := GeneratedSlot{
+			Slot: models.SalesSlot{
+				ID:        types.ID(uuid.New().String()),
+				Booth:     pattern.Booth,
+				StartTime: t[0],
+				EndTime:   t[1],
+				Override:  types.AUTO,
+			},
+			Created: true,
+		}
+		for _, other := range overlapping {
+			if other.Booth == pattern.Booth && other.StartTime.Equal(t[0]) && other.EndTime.Equal(t[1]) {
+				g.Slot, g.Created = other, false
+				break
+			}
+		}
+		g.Conflicts = s.conflicting(overlapping, g.Slot.ID, pattern.Booth)
+		conflicts = append(conflicts, g.Conflicts...)
+
+		if g.AddedProducts, err = s.missingProducts(ctx, &g, pattern.Products); err != nil {
+			return nil, err
+		}
+		generated[i] = g
+	}
+
+	if dryRun {
+		return generated, nil
+	}
+	if len(conflicts) > 0 {
+		return nil, &SlotConflictError{
+			Message:   "生成する販売枠が他の販売枠と重なっています",
+			Conflicts: conflicts,
+		}
+	}
+
+	templates := make(map[types.ID]SlotTemplateProduct, len(pattern.Products))
+	for _, tp := range pattern.Products {
+		templates[tp.ProductID] = tp
+	}
+	for i := range generated {
+		g := &generated[i]
+		if g.Created {
+			if err := s.slotRepo.Create(ctx, &g.Slot); err != nil {
+				return nil, err
+			}
+		}
+		for _, productID := range g.AddedProducts {
+			tp := templates[productID]
+			if err := s.invRepo.Create(ctx, &models.ProductInventory{
+				SalesSlotID: g.Slot.ID,
+				ProductID:   productID,
+				Price:       tp.Price,
+			}); err != nil {
+				return nil, err
+			}
+			if err := s.restock(ctx, g.Slot.ID, productID, tp.InitialQuantity); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return generated, nil
+}
+
+// missingProducts returns the template products the generated slot does not
+// sell yet.
+func (s *salesSlotService) missingProducts(ctx context.Context, g *GeneratedSlot, products []SlotTemplateProduct) ([]types.ID, error) {
+	existing := make(map[types.ID]bool)
+	if !g.Created {
+		inventories, err := s.invRepo.FindBySalesSlotID(ctx, g.Slot.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range inventories {
+			existing[inv.ProductID] = true
+		}
+	}
+
+	var missing []types.ID
+	for _, tp := range products {
+		if !existing[tp.ProductID] {
+			missing = append(missing, tp.ProductID)
+		}
+	}
+	return missing, nil
+}
+
+// countUsage fills in the orders and items taken in each slot.
+func (s *salesSlotService) countUsage(ctx context.Context, slots []models.SalesSlot) error {
+	ids := make([]types.ID, len(slots))
+	for i, slot := range slots {
+		ids[i] = slot.ID
+	}
+	usages, err := s.orderRepo.CountBySalesSlots(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[types.ID]repositories.SlotUsage, len(usages))
+	for _, u := range usages {
+		byID[u.SalesSlotID] = u
+	}
+	for i := range slots {
+		slots[i].OrderCount = byID[slots[i].ID].Orders
+		slots[i].ItemCount = byID[slots[i].ID].Items
+	}
+	return nil
+}
+
+// SetCapacity sets how many orders and items the slot takes. A nil limit
+// removes it; limits below what has already been ordered only stop new
+// orders.
+func (s *salesSlotService) SetCapacity(ctx context.Context, id types.ID, maxOrders, maxItems *int) (*models.SalesSlot, error) {
+	if (maxOrders != nil && *maxOrders < 1) || (maxItems != nil && *maxItems < 1) {
+		return nil, ErrInvalidCapacity
+	}
+
+	if err := s.slotRepo.UpdateCapacity(ctx, id, maxOrders, maxItems); err != nil {
+		return nil, err
+	}
+	return s.GetSalesSlot(ctx, id)
+}
+
+// ActivateSalesSlot opens the slot now. Slots without an override are closed
+// again by the scheduler outside their schedule; use SetOverride to keep
+// them open.
 func (s *salesSlotService) ActivateSalesSlot(ctx context.Context, id types.ID) error {
-	return s.slotRepo.ActivateSlot(ctx, id)
+	slot, err := s.slotRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkActiveInBooth(ctx, slot); err != nil {
+		return err
+	}
+	return s.transition(ctx, id, true, types.MANUAL)
 }
 
 func (s *salesSlotService) DeactivateSalesSlot(ctx context.Context, id types.ID) error {
-	return s.slotRepo.DeactivateSlot(ctx, id)
+	if _, err := s.slotRepo.FindByID(ctx, id); err != nil {
+		return err
+	}
+	return s.transition(ctx, id, false, types.MANUAL)
 }
 
-func (s *salesSlotService) AddProductToSlot(ctx context.Context, slotID types.ID, productID types.ID, initialQuantity int) (*models.ProductInventory, error) {
+// SetOverride pins the slot open or closed, or with AUTO hands it back to
+// the schedule, and brings its state in line right away. A slot is not
+// opened while another slot in its booth is active; pinning it open then
+// fails.
+func (s *salesSlotService) SetOverride(ctx context.Context, id types.ID, override types.SlotOverride) (*models.SalesSlot, error) {
+	slot, err := s.slotRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	active := s.schedule.isOpen(slot, time.Now())
+	switch override {
+	case types.PINNED_OPEN:
+		active = true
+	case types.PINNED_CLOSED:
+		active = false
+	}
+	if active {
+		if err := s.checkActiveInBooth(ctx, slot); err != nil {
+			if override == types.PINNED_OPEN {
+				return nil, err
+			}
+			var conflict *SlotConflictError
+			if !errors.As(err, &conflict) {
+				return nil, err
+			}
+			active = false
+		}
+	}
+
+	previous := slot.Override
+	if err := s.slotRepo.UpdateOverride(ctx, id, override); err != nil {
+		return nil, err
+	}
+	err = s.transition(ctx, id, active, types.OVERRIDE)
+	var conflict *SlotConflictError
+	if errors.As(err, &conflict) {
+		// Another slot in the booth opened since it was checked.
+		if override == types.PINNED_OPEN {
+			if err := s.slotRepo.UpdateOverride(ctx, id, previous); err != nil {
+				return nil, err
+			}
+			return nil, conflict
+		}
+		err = s.transition(ctx, id, false, types.OVERRIDE)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetSalesSlot(ctx, id)
+}
+
+func (s *salesSlotService) GetTransitions(ctx context.Context, id types.ID) ([]models.SalesSlotTransition, error) {
+	if _, err := s.slotRepo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.slotRepo.FindTransitions(ctx, id)
+}
+
+// ApplySchedule opens and closes the slots without an override according to
+// the schedule. It is safe to run on several server instances at once.
+// Slots are closed first so that the next slot in a booth can open; a slot
+// whose booth still has another active slot stays closed until it is free.
+func (s *salesSlotService) ApplySchedule(ctx context.Context) error {
+	now := time.Now()
+	slots, err := s.slotRepo.FindOutOfSchedule(ctx, now.Add(s.schedule.OpenBefore), now.Add(-s.schedule.CloseAfter))
+	if err != nil {
+		return err
+	}
+
+	var opening []models.SalesSlot
+	for _, slot := range slots {
+		if s.schedule.isOpen(&slot, now) {
+			opening = append(opening, slot)
+			continue
+		}
+		if err := s.transition(ctx, slot.ID, false, types.SCHEDULED); err != nil {
+			return err
+		}
+	}
+
+	for _, slot := range opening {
+		if err := s.checkActiveInBooth(ctx, &slot); err != nil {
+			var conflict *SlotConflictError
+			if errors.As(err, &conflict) {
+				continue
+			}
+			return err
+		}
+		if err := s.transition(ctx, slot.ID, true, types.SCHEDULED); err != nil {
+			var conflict *SlotConflictError
+			if errors.As(err, &conflict) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkActiveInBooth returns a SlotConflictError when another slot in the
+// slot's booth is active.
+func (s *salesSlotService) checkActiveInBooth(ctx context.Context, slot *models.SalesSlot) error {
+	active, err := s.slotRepo.FindActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var conflicts []models.SalesSlot
+	for _, other := range active {
+		if other.ID != slot.ID && other.Booth == slot.Booth {
+			conflicts = append(conflicts, other)
+		}
+	}
+	if len(conflicts) > 0 {
+		return &SlotConflictError{
+			Message:   "同じブースで別の販売枠が受付中です",
+			Conflicts: conflicts,
+		}
+	}
+	return nil
+}
+
+// boothConflict returns the SlotConflictError for a slot refused because
+// another slot in its booth is active.
+func (s *salesSlotService) boothConflict(ctx context.Context, id types.ID) error {
+	slot, err := s.slotRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkActiveInBooth(ctx, slot); err != nil {
+		return err
+	}
+	// The other slot closed again before it could be listed.
+	return &SlotConflictError{Message: "同じブースで別の販売枠が受付中です"}
+}
+
+// conflicting returns the overlapping slots that the overlap policy does not
+// allow next to a slot in the booth, leaving out the slot itself.
+func (s *salesSlotService) conflicting(overlapping []models.SalesSlot, id types.ID, booth string) []models.SalesSlot {
+	var conflicts []models.SalesSlot
+	for _, other := range overlapping {
+		if other.ID == id {
+			continue
+		}
+		if s.schedule.Overlap == types.OVERLAP_PER_BOOTH && other.Booth != booth {
+			continue
+		}
+		conflicts = append(conflicts, other)
+	}
+	return conflicts
+}
+
+// transition opens or closes the slot and, unless it already was in that
+// state, publishes the change. A SlotConflictError is returned when another
+// slot in the booth opened since checkActiveInBooth was called.
+func (s *salesSlotService) transition(ctx context.Context, id types.ID, active bool, cause types.SlotTransitionCause) error {
+	transition := &models.SalesSlotTransition{
+		ID:          types.ID(uuid.New().String()),
+		SalesSlotID: id,
+		IsActive:    active,
+		Cause:       cause,
+		CreatedAt:   time.Now(),
+	}
+	changed, err := s.slotRepo.Transition(ctx, transition)
+	if errors.Is(err, repositories.ErrBoothSlotActive) {
+		return s.boothConflict(ctx, id)
+	}
+	if err != nil || !changed {
+		return err
+	}
+
+	if s.publisher != nil {
+		eventType := events.SlotClosed
+		if active {
+			eventType = events.SlotOpened
+		}
+		s.publisher.Publish(ctx, events.Event{
+			Type:       eventType,
+			EntityID:   id,
+			OccurredAt: transition.CreatedAt,
+			Payload:    transition,
+		})
+	}
+	return nil
+}
+
+func (s *salesSlotService) AddProductToSlot(ctx context.Context, slotID types.ID, productID types.ID, initialQuantity int, price *int) (*models.ProductInventory, error) {
+	if price != nil && *price < 0 {
+		return nil, ErrInvalidSlotPrice
+	}
+	if initialQuantity < 0 {
+		return nil, ErrInvalidQuantity
+	}
+
 	_, err := s.slotRepo.FindByID(ctx, slotID)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = s.prodRepo.FindByID(ctx, productID)
+	product, err := s.prodRepo.FindByID(ctx, productID)
 	if err != nil {
 		return nil, err
 	}
+	// Bundles are sold from their components' stock.
+	if product.IsBundle {
+		return nil, ErrBundleInventory
+	}
 
 	existing, err := s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
 	if err == nil && existing != nil {
@@ -96,31 +630,106 @@ func (s *salesSlotService) AddProductToSlot(ctx context.Context, slotID types.ID
 	}
 
 	inventory := &models.ProductInventory{
-		SalesSlotID:     slotID,
-		ProductID:       productID,
-		InitialQuantity: initialQuantity,
+		SalesSlotID: slotID,
+		ProductID:   productID,
+		Price:       price,
 	}
-
 	if err := s.invRepo.Create(ctx, inventory); err != nil {
 		return nil, err
 	}
+	if err := s.restock(ctx, slotID, productID, initialQuantity); err != nil {
+		return nil, err
+	}
+
+	return s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
+}
+
+// SetSlotPrice sets or, with a nil price, clears the product's price override
+// in the slot.
+func (s *salesSlotService) SetSlotPrice(ctx context.Context, slotID types.ID, productID types.ID, price *int) (*models.ProductInventory, error) {
+	if price != nil && *price < 0 {
+		return nil, ErrInvalidSlotPrice
+	}
+
+	inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.invRepo.UpdatePrice(ctx, inventory.ID, price); err != nil {
+		return nil, err
+	}
 
+	inventory.Price = price
 	return inventory, nil
 }
 
-func (s *salesSlotService) UpdateInventory(ctx context.Context, slotID types.ID, productID types.ID, reserved, sold int) error {
+// SetPurchaseLimits sets how many of the product one order and one customer
+// may take in the slot. A nil limit removes it.
+func (s *salesSlotService) SetPurchaseLimits(ctx context.Context, slotID types.ID, productID types.ID, maxPerOrder, maxPerCustomer *int) (*models.ProductInventory, error) {
+	if (maxPerOrder != nil && *maxPerOrder < 1) || (maxPerCustomer != nil && *maxPerCustomer < 1) {
+		return nil, ErrInvalidPurchaseLimit
+	}
+
 	inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := s.invRepo.UpdateLimits(ctx, inventory.ID, maxPerOrder, maxPerCustomer); err != nil {
+		return nil, err
+	}
+
+	inventory.MaxPerOrder = maxPerOrder
+	inventory.MaxPerCustomer = maxPerCustomer
+	return inventory, nil
+}
+
+// SetLowStockThreshold sets or, with a nil threshold, clears the available
+// quantity at which the product's stock in the slot is reported as low.
+func (s *salesSlotService) SetLowStockThreshold(ctx context.Context, slotID types.ID, productID types.ID, threshold *int) (*models.ProductInventory, error) {
+	if threshold != nil && *threshold < 0 {
+		return nil, ErrInvalidStockThreshold
+	}
+
+	inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.invRepo.UpdateLowStockThreshold(ctx, inventory.ID, threshold); err != nil {
+		return nil, err
+	}
+
+	inventory.LowStockThreshold = threshold
+	return inventory, nil
+}
+
+// SetAvailability stops or resumes orders of the product in the slot
+// regardless of its stock.
+func (s *salesSlotService) SetAvailability(ctx context.Context, slotID types.ID, productID types.ID, available bool) (*models.ProductInventory, error) {
+	inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
+	if err != nil {
+		return nil, err
 	}
 
-	if reserved+sold > inventory.InitialQuantity {
-		return ErrInsufficientInventory
+	if err := s.invRepo.UpdateAvailability(ctx, inventory.ID, !available); err != nil {
+		return nil, err
 	}
 
-	return s.invRepo.UpdateQuantities(ctx, inventory.ID, reserved, sold)
+	inventory.Unavailable = !available
+	return inventory, nil
 }
 
+// GetSlotInventories returns the products of the slot with how many more of
+// each the booth's ingredients make.
 func (s *salesSlotService) GetSlotInventories(ctx context.Context, slotID types.ID) ([]models.ProductInventory, error) {
-	return s.invRepo.FindBySalesSlotID(ctx, slotID)
+	inventories, err := s.invRepo.FindBySalesSlotID(ctx, slotID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.setMakeableQuantities(ctx, slotID, inventories); err != nil {
+		return nil, err
+	}
+	return inventories, nil
 }
@@ -2,16 +2,28 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"github.com/google/uuid"
 )
 
 type mockSalesSlotRepository struct {
-	slots map[types.ID]*models.SalesSlot
+	slots       map[types.ID]*models.SalesSlot
+	transitions []models.SalesSlotTransition
+	// cancelled and released hold the orders and stock movements of the last
+	// DeleteWithOrders.
+	cancelled []types.ID
+	released  []models.StockMovement
+	// staleActive makes FindActive return no slots, as a read made before
+	// another request opened one would.
+	staleActive bool
 }
 
 func newMockSalesSlotRepository() *mockSalesSlotRepository {
@@ -41,6 +53,9 @@ func (r *mockSalesSlotRepository) FindAll(ctx context.Context) ([]models.SalesSl
 }
 
 func (r *mockSalesSlotRepository) FindActive(ctx context.Context) ([]models.SalesSlot, error) {
+	if r.staleActive {
+		return nil, nil
+	}
 	var slots []models.SalesSlot
 	for _, s := range r.slots {
 		if s.IsActive {
@@ -63,6 +78,29 @@ func (r *mockSalesSlotRepository) FindByTimeRange(ctx context.Context, start, en
 	return slots, nil
 }
 
+func (r *mockSalesSlotRepository) FindOverlapping(ctx context.Context, start, end time.Time) ([]models.SalesSlot, error) {
+	var slots []models.SalesSlot
+	for _, s := range r.slots {
+		if s.Overlaps(start, end) {
+			slots = append(slots, *s)
+		}
+	}
+	return slots, nil
+}
+
+func (r *mockSalesSlotRepository) FindNext(ctx context.Context, booth string, after time.Time) (*models.SalesSlot, error) {
+	var next *models.SalesSlot
+	for _, s := range r.slots {
+		if s.Booth == booth && !s.StartTime.Before(after) && (next == nil || s.StartTime.Before(next.StartTime)) {
+			next = s
+		}
+	}
+	if next == nil {
+		return nil, repositories.NewErrNotFound("SalesSlot", types.ID(booth))
+	}
+	return next, nil
+}
+
 func (r *mockSalesSlotRepository) Update(ctx context.Context, slot *models.SalesSlot) error {
 	if _, exists := r.slots[slot.ID]; !exists {
 		return repositories.NewErrNotFound("SalesSlot", slot.ID)
@@ -97,8 +135,90 @@ func (r *mockSalesSlotRepository) DeactivateSlot(ctx context.Context, id types.I
 	return nil
 }
 
+func (r *mockSalesSlotRepository) UpdateTimes(ctx context.Context, id types.ID, booth string, start, end time.Time) error {
+	slot, exists := r.slots[id]
+	if !exists {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	slot.Booth = booth
+	slot.StartTime = start
+	slot.EndTime = end
+	return nil
+}
+
+func (r *mockSalesSlotRepository) DeleteWithOrders(ctx context.Context, id types.ID, orderIDs []types.ID, movements []models.StockMovement) error {
+	if _, exists := r.slots[id]; !exists {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	delete(r.slots, id)
+	r.cancelled = orderIDs
+	r.released = movements
+	return nil
+}
+
+func (r *mockSalesSlotRepository) UpdateCapacity(ctx context.Context, id types.ID, maxOrders, maxItems *int) error {
+	slot, exists := r.slots[id]
+	if !exists {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	slot.MaxOrders = maxOrders
+	slot.MaxItems = maxItems
+	return nil
+}
+
+func (r *mockSalesSlotRepository) UpdateOverride(ctx context.Context, id types.ID, override types.SlotOverride) error {
+	slot, exists := r.slots[id]
+	if !exists {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	slot.Override = override
+	return nil
+}
+
+func (r *mockSalesSlotRepository) FindOutOfSchedule(ctx context.Context, openBy, closedAfter time.Time) ([]models.SalesSlot, error) {
+	var slots []models.SalesSlot
+	for _, s := range r.slots {
+		if s.Override == types.PINNED_OPEN || s.Override == types.PINNED_CLOSED {
+			continue
+		}
+		due := !s.StartTime.After(openBy) && s.EndTime.After(closedAfter)
+		if s.IsActive != due {
+			slots = append(slots, *s)
+		}
+	}
+	return slots, nil
+}
+
+func (r *mockSalesSlotRepository) Transition(ctx context.Context, transition *models.SalesSlotTransition) (bool, error) {
+	slot, exists := r.slots[transition.SalesSlotID]
+	if !exists || slot.IsActive == transition.IsActive {
+		return false, nil
+	}
+	if transition.IsActive {
+		for _, other := range r.slots {
+			if other.ID != slot.ID && other.Booth == slot.Booth && other.IsActive {
+				return false, repositories.ErrBoothSlotActive
+			}
+		}
+	}
+	slot.IsActive = transition.IsActive
+	r.transitions = append(r.transitions, *transition)
+	return true, nil
+}
+
+func (r *mockSalesSlotRepository) FindTransitions(ctx context.Context, salesSlotID types.ID) ([]models.SalesSlotTransition, error) {
+	var transitions []models.SalesSlotTransition
+	for _, t := range r.transitions {
+		if t.SalesSlotID == salesSlotID {
+			transitions = append(transitions, t)
+		}
+	}
+	return transitions, nil
+}
+
 type mockInventoryRepository struct {
 	inventories map[types.ID]*models.ProductInventory
+	movements   []models.StockMovement
 }
 
 func newMockInventoryRepository() *mockInventoryRepository {
@@ -108,6 +228,9 @@ func newMockInventoryRepository() *mockInventoryRepository {
 }
 
 func (r *mockInventoryRepository) Create(ctx context.Context, inventory *models.ProductInventory) error {
+	if inventory.ID == "" {
+		inventory.ID = types.ID(uuid.New().String())
+	}
 	r.inventories[inventory.ID] = inventory
 	return nil
 }
@@ -172,13 +295,95 @@ func (r *mockInventoryRepository) FindBySalesSlotAndProduct(ctx context.Context,
 	return nil, repositories.NewErrNotFound("ProductInventory", "")
 }
 
-func (r *mockInventoryRepository) UpdateQuantities(ctx context.Context, id types.ID, reserved, sold int) error {
+func (r *mockInventoryRepository) UpdatePrice(ctx context.Context, id types.ID, price *int) error {
+	inv, exists := r.inventories[id]
+	if !exists {
+		return repositories.NewErrNotFound("ProductInventory", id)
+	}
+	inv.Price = price
+	return nil
+}
+
+func (r *mockInventoryRepository) UpdateLimits(ctx context.Context, id types.ID, maxPerOrder, maxPerCustomer *int) error {
 	inv, exists := r.inventories[id]
 	if !exists {
 		return repositories.NewErrNotFound("ProductInventory", id)
 	}
-	inv.ReservedQuantity = reserved
-	inv.SoldQuantity = sold
+	inv.MaxPerOrder = maxPerOrder
+	inv.MaxPerCustomer = maxPerCustomer
+	return nil
+}
+
+func (r *mockInventoryRepository) ApplyMovements(ctx context.Context, movements []models.StockMovement) error {
+	// Movements already applied are undone on failure, as the transaction
+	// would be rolled back.
+	applied := len(r.movements)
+	undo := func() {
+		for _, m := range r.movements[applied:] {
+			inv := r.inventories[m.InventoryID]
+			initial, reserved, sold := m.Deltas()
+			inv.InitialQuantity -= initial
+			inv.ReservedQuantity -= reserved
+			inv.SoldQuantity -= sold
+		}
+		r.movements = r.movements[:applied]
+	}
+	for _, m := range movements {
+		inv, err := r.FindBySalesSlotAndProduct(ctx, m.SalesSlotID, m.ProductID)
+		if err != nil {
+			undo()
+			return err
+		}
+		initial, reserved, sold := m.Deltas()
+		if (initial < 0 || reserved > 0 || sold > 0) &&
+			inv.InitialQuantity+initial < inv.ReservedQuantity+reserved+inv.SoldQuantity+sold {
+			undo()
+			return repositories.ErrStockShortage
+		}
+		inv.InitialQuantity += initial
+		inv.ReservedQuantity += reserved
+		inv.SoldQuantity += sold
+		m.InventoryID = inv.ID
+		r.movements = append(r.movements, m)
+	}
+	return nil
+}
+
+func (r *mockInventoryRepository) UpdateLowStockThreshold(ctx context.Context, id types.ID, threshold *int) error {
+	inv, exists := r.inventories[id]
+	if !exists {
+		return repositories.NewErrNotFound("ProductInventory", id)
+	}
+	inv.LowStockThreshold = threshold
+	return nil
+}
+
+func (r *mockInventoryRepository) UpdateAvailability(ctx context.Context, id types.ID, unavailable bool) error {
+	inv, exists := r.inventories[id]
+	if !exists {
+		return repositories.NewErrNotFound("ProductInventory", id)
+	}
+	inv.Unavailable = unavailable
+	return nil
+}
+
+func (r *mockInventoryRepository) FindMovements(ctx context.Context, inventoryID types.ID) ([]models.StockMovement, error) {
+	var movements []models.StockMovement
+	for _, m := range r.movements {
+		if m.InventoryID == inventoryID {
+			movements = append(movements, m)
+		}
+	}
+	return movements, nil
+}
+
+func (r *mockInventoryRepository) Reconcile(ctx context.Context, inventoryID types.ID, reconcile func(inventory *models.ProductInventory, movements []models.StockMovement) []models.StockMovement) error {
+	inv, exists := r.inventories[inventoryID]
+	if !exists {
+		return repositories.NewErrNotFound("ProductInventory", inventoryID)
+	}
+	movements, _ := r.FindMovements(ctx, inventoryID)
+	r.movements = append(r.movements, reconcile(inv, movements)...)
 	return nil
 }
 
@@ -186,13 +391,13 @@ func TestSalesSlotService_CreateSalesSlot(t *testing.T) {
 	slotRepo := newMockSalesSlotRepository()
 	invRepo := newMockInventoryRepository()
 	productRepo := newMockProductRepository()
-	service := NewSalesSlotService(slotRepo, invRepo, productRepo)
+	service := NewSalesSlotService(slotRepo, invRepo, productRepo, newMockOrderRepository(), newMockIngredientRepository(), SlotSchedule{}, nil)
 	ctx := context.Background()
 
 	start := time.Now()
 	end := start.Add(2 * time.Hour)
 
-	slot, err := service.CreateSalesSlot(ctx, start, end)
+	slot, err := service.CreateSalesSlot(ctx, "", start, end)
 	if err != nil {
 		t.Errorf("CreateSalesSlot failed: %v", err)
 	}
@@ -214,10 +419,10 @@ func TestSalesSlotService_ActivateDeactivate(t *testing.T) {
 	slotRepo := newMockSalesSlotRepository()
 	invRepo := newMockInventoryRepository()
 	productRepo := newMockProductRepository()
-	service := NewSalesSlotService(slotRepo, invRepo, productRepo)
+	service := NewSalesSlotService(slotRepo, invRepo, productRepo, newMockOrderRepository(), newMockIngredientRepository(), SlotSchedule{}, nil)
 	ctx := context.Background()
 
-	slot, _ := service.CreateSalesSlot(ctx, time.Now(), time.Now().Add(2*time.Hour))
+	slot, _ := service.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
 
 	err := service.ActivateSalesSlot(ctx, slot.ID)
 	if err != nil {
@@ -244,10 +449,10 @@ func TestSalesSlotService_AddProductToSlot(t *testing.T) {
 	slotRepo := newMockSalesSlotRepository()
 	invRepo := newMockInventoryRepository()
 	productRepo := newMockProductRepository()
-	service := NewSalesSlotService(slotRepo, invRepo, productRepo)
+	service := NewSalesSlotService(slotRepo, invRepo, productRepo, newMockOrderRepository(), newMockIngredientRepository(), SlotSchedule{}, nil)
 	ctx := context.Background()
 
-	slot, _ := service.CreateSalesSlot(ctx, time.Now(), time.Now().Add(2*time.Hour))
+	slot, _ := service.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(2*time.Hour))
 
 	product := &models.Product{
 		Name:  "Test Product",
@@ -258,7 +463,7 @@ func TestSalesSlotService_AddProductToSlot(t *testing.T) {
 		t.Errorf("Failed to create product: %v", err)
 	}
 
-	inventory, err := service.AddProductToSlot(ctx, slot.ID, product.ID, 100)
+	inventory, err := service.AddProductToSlot(ctx, slot.ID, product.ID, 100, nil)
 	if err != nil {
 		t.Errorf("AddProductToSlot failed: %v", err)
 	}
@@ -280,15 +485,15 @@ func TestSalesSlotService_FindByTimeRange(t *testing.T) {
 	slotRepo := newMockSalesSlotRepository()
 	invRepo := newMockInventoryRepository()
 	productRepo := newMockProductRepository()
-	service := NewSalesSlotService(slotRepo, invRepo, productRepo)
+	service := NewSalesSlotService(slotRepo, invRepo, productRepo, newMockOrderRepository(), newMockIngredientRepository(), SlotSchedule{}, nil)
 	ctx := context.Background()
 
 	start := time.Now()
 	mid := start.Add(1 * time.Hour)
 	end := start.Add(2 * time.Hour)
 
-	service.CreateSalesSlot(ctx, start, mid)
-	service.CreateSalesSlot(ctx, mid, end)
+	service.CreateSalesSlot(ctx, "", start, mid)
+	service.CreateSalesSlot(ctx, "", mid, end)
 
 	slots, err := service.FindByTimeRange(ctx, start, end)
 	if err != nil {
@@ -299,3 +504,487 @@ func TestSalesSlotService_FindByTimeRange(t *testing.T) {
 		t.Errorf("Expected 2 slots, got %d", len(slots))
 	}
 }
+
+func TestSalesSlotService_SetSlotPrice(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	service := NewSalesSlotService(slotRepo, invRepo, prodRepo, newMockOrderRepository(), newMockIngredientRepository(), SlotSchedule{}, nil)
+	ctx := context.Background()
+
+	slot, _ := service.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(time.Hour))
+	product := &models.Product{ID: types.ID("prod1"), Name: "Test Product", Price: 500}
+	prodRepo.Create(ctx, product)
+
+	negative := -100
+	if _, err := service.AddProductToSlot(ctx, slot.ID, product.ID, 10, &negative); err != ErrInvalidSlotPrice {
+		t.Errorf("Expected ErrInvalidSlotPrice, got %v", err)
+	}
+
+	price := 400
+	inventory, err := service.AddProductToSlot(ctx, slot.ID, product.ID, 10, &price)
+	if err != nil {
+		t.Fatalf("AddProductToSlot failed: %v", err)
+	}
+	if inventory.GetPrice(product.Price) != 400 {
+		t.Errorf("Expected slot price 400, got %d", inventory.GetPrice(product.Price))
+	}
+
+	inventory, err = service.SetSlotPrice(ctx, slot.ID, product.ID, nil)
+	if err != nil {
+		t.Fatalf("SetSlotPrice failed: %v", err)
+	}
+	if inventory.GetPrice(product.Price) != 500 {
+		t.Errorf("Expected product price 500 after clearing, got %d", inventory.GetPrice(product.Price))
+	}
+}
+
+func TestSalesSlotService_Capacity(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	orderRepo := newMockOrderRepository()
+	service := NewSalesSlotService(slotRepo, newMockInventoryRepository(), newMockProductRepository(), orderRepo, newMockIngredientRepository(), SlotSchedule{}, nil)
+	ctx := context.Background()
+
+	slot, err := service.CreateSalesSlot(ctx, "", time.Now(), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CreateSalesSlot failed: %v", err)
+	}
+
+	zero := 0
+	if _, err := service.SetCapacity(ctx, slot.ID, &zero, nil); err != ErrInvalidCapacity {
+		t.Errorf("Expected ErrInvalidCapacity, got %v", err)
+	}
+
+	maxOrders := 10
+	if _, err := service.SetCapacity(ctx, slot.ID, &maxOrders, nil); err != nil {
+		t.Fatalf("SetCapacity failed: %v", err)
+	}
+
+	for i := 0; i < 8; i++ {
+		orderRepo.CreateWithItems(ctx, &models.Order{SalesSlotID: slot.ID, Status: types.RESERVED}, []models.OrderItem{{Quantity: 2}}, nil)
+	}
+	orderRepo.CreateWithItems(ctx, &models.Order{SalesSlotID: slot.ID, Status: types.CANCELLED}, []models.OrderItem{{Quantity: 1}}, nil)
+
+	found, err := service.GetSalesSlot(ctx, slot.ID)
+	if err != nil {
+		t.Fatalf("GetSalesSlot failed: %v", err)
+	}
+	if found.OrderCount != 8 || found.ItemCount != 16 {
+		t.Errorf("Expected 8 orders with 16 items, got %d with %d", found.OrderCount, found.ItemCount)
+	}
+	if r := found.RemainingOrders(); r == nil || *r != 2 {
+		t.Errorf("Expected 2 remaining orders, got %v", r)
+	}
+	if found.RemainingItems() != nil {
+		t.Error("Expected items not to be limited")
+	}
+	if !found.IsAlmostFull() {
+		t.Error("Expected the slot to be almost full")
+	}
+}
+
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event events.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestSalesSlotService_ApplySchedule(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	publisher := &recordingPublisher{}
+	schedule := SlotSchedule{OpenBefore: 10 * time.Minute, CloseAfter: 5 * time.Minute}
+	service := NewSalesSlotService(slotRepo, newMockInventoryRepository(), newMockProductRepository(), newMockOrderRepository(), newMockIngredientRepository(), schedule, publisher)
+	ctx := context.Background()
+
+	now := time.Now()
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "opening", Booth: "opening", StartTime: now.Add(5 * time.Minute), EndTime: now.Add(time.Hour), Override: types.AUTO})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "later", Booth: "later", StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour), Override: types.AUTO})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "grace", Booth: "grace", StartTime: now.Add(-time.Hour), EndTime: now.Add(-time.Minute), IsActive: true, Override: types.AUTO})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "ended", Booth: "ended", StartTime: now.Add(-time.Hour), EndTime: now.Add(-10 * time.Minute), IsActive: true, Override: types.AUTO})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "pinned", Booth: "pinned", StartTime: now.Add(-time.Hour), EndTime: now.Add(-10 * time.Minute), IsActive: true, Override: types.PINNED_OPEN})
+
+	if err := service.ApplySchedule(ctx); err != nil {
+		t.Fatalf("ApplySchedule failed: %v", err)
+	}
+
+	expected := map[types.ID]bool{"opening": true, "later": false, "grace": true, "ended": false, "pinned": true}
+	for id, active := range expected {
+		if slotRepo.slots[id].IsActive != active {
+			t.Errorf("Expected slot %s active=%v", id, active)
+		}
+	}
+
+	if len(publisher.events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(publisher.events))
+	}
+	if len(slotRepo.transitions) != 2 || slotRepo.transitions[0].Cause != types.SCHEDULED {
+		t.Errorf("Expected 2 scheduled transitions, got %+v", slotRepo.transitions)
+	}
+
+	// A second run finds nothing to do
+	if err := service.ApplySchedule(ctx); err != nil {
+		t.Fatalf("ApplySchedule failed: %v", err)
+	}
+	if len(publisher.events) != 2 {
+		t.Errorf("Expected no more events, got %d", len(publisher.events))
+	}
+
+	// Pinning a slot closed overrides the schedule
+	slot, err := service.SetOverride(ctx, "opening", types.PINNED_CLOSED)
+	if err != nil {
+		t.Fatalf("SetOverride failed: %v", err)
+	}
+	if slot.IsActive || slot.Override != types.PINNED_CLOSED {
+		t.Errorf("Expected the slot pinned closed, got active=%v override=%s", slot.IsActive, slot.Override)
+	}
+	service.ApplySchedule(ctx)
+	if slotRepo.slots["opening"].IsActive {
+		t.Error("Expected the pinned slot to stay closed")
+	}
+
+	// Back to AUTO reopens it right away
+	if _, err := service.SetOverride(ctx, "opening", types.AUTO); err != nil {
+		t.Fatalf("SetOverride failed: %v", err)
+	}
+	transitions, _ := service.GetTransitions(ctx, "opening")
+	if len(transitions) != 3 || !transitions[2].IsActive || transitions[2].Cause != types.OVERRIDE {
+		t.Errorf("Expected the slot reopened by override, got %+v", transitions)
+	}
+	last := publisher.events[len(publisher.events)-1]
+	if last.Type != events.SlotOpened || last.EntityID != "opening" {
+		t.Errorf("Expected a slot opened event, got %+v", last)
+	}
+}
+
+func TestSalesSlotService_CreateSalesSlot_Overlap(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 11, 3, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		policy    types.SlotOverlapPolicy
+		booth     string
+		start     time.Time
+		end       time.Time
+		conflicts int
+		err       error
+	}{
+		{name: "overlapping", policy: types.REJECT_OVERLAP, booth: "B", start: start.Add(30 * time.Minute), end: start.Add(90 * time.Minute), conflicts: 1},
+		{name: "adjacent", policy: types.REJECT_OVERLAP, booth: "A", start: start.Add(time.Hour), end: start.Add(2 * time.Hour)},
+		{name: "other booth", policy: types.OVERLAP_PER_BOOTH, booth: "B", start: start, end: start.Add(time.Hour)},
+		{name: "same booth", policy: types.OVERLAP_PER_BOOTH, booth: " A ", start: start.Add(30 * time.Minute), end: start.Add(90 * time.Minute), conflicts: 1},
+		{name: "empty range", policy: types.REJECT_OVERLAP, booth: "B", start: start.Add(3 * time.Hour), end: start.Add(3 * time.Hour), err: ErrInvalidTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slotRepo := newMockSalesSlotRepository()
+			service := NewSalesSlotService(slotRepo, newMockInventoryRepository(), newMockProductRepository(), newMockOrderRepository(), newMockIngredientRepository(), SlotSchedule{Overlap: tt.policy}, nil)
+			existing, _ := service.CreateSalesSlot(ctx, "A", start, start.Add(time.Hour))
+
+			slot, err := service.CreateSalesSlot(ctx, tt.booth, tt.start, tt.end)
+			if tt.err != nil {
+				if err != tt.err {
+					t.Fatalf("Expected %v, got %v", tt.err, err)
+				}
+				return
+			}
+
+			var conflict *SlotConflictError
+			if tt.conflicts > 0 {
+				if !errors.As(err, &conflict) {
+					t.Fatalf("Expected a conflict, got %v", err)
+				}
+				if len(conflict.Conflicts) != tt.conflicts || conflict.Conflicts[0].ID != existing.ID {
+					t.Errorf("Expected the existing slot as conflict, got %+v", conflict.Conflicts)
+				}
+				if len(slotRepo.slots) != 1 {
+					t.Errorf("Expected no slot to be created, got %d slots", len(slotRepo.slots))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateSalesSlot failed: %v", err)
+			}
+			if slot.Booth != strings.TrimSpace(tt.booth) {
+				t.Errorf("Expected booth %q, got %q", tt.booth, slot.Booth)
+			}
+		})
+	}
+}
+
+func TestSalesSlotService_OneActiveSlotPerBooth(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	schedule := SlotSchedule{CloseAfter: 10 * time.Minute, Overlap: types.OVERLAP_PER_BOOTH}
+	service := NewSalesSlotService(slotRepo, newMockInventoryRepository(), newMockProductRepository(), newMockOrderRepository(), newMockIngredientRepository(), schedule, nil)
+	ctx := context.Background()
+
+	now := time.Now()
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "first", Booth: "A", StartTime: now.Add(-time.Hour), EndTime: now.Add(-5 * time.Minute), IsActive: true, Override: types.AUTO})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "next", Booth: "A", StartTime: now.Add(-5 * time.Minute), EndTime: now.Add(time.Hour), Override: types.AUTO})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "other", Booth: "B", StartTime: now.Add(-5 * time.Minute), EndTime: now.Add(time.Hour), Override: types.AUTO})
+
+	// The next slot waits while the first is still open after its end
+	if err := service.ApplySchedule(ctx); err != nil {
+		t.Fatalf("ApplySchedule failed: %v", err)
+	}
+	if slotRepo.slots["next"].IsActive || !slotRepo.slots["other"].IsActive {
+		t.Errorf("Expected only the slot at the free booth to open")
+	}
+
+	var conflict *SlotConflictError
+	err := service.ActivateSalesSlot(ctx, "next")
+	if !errors.As(err, &conflict) || len(conflict.Conflicts) != 1 || conflict.Conflicts[0].ID != "first" {
+		t.Fatalf("Expected activation to conflict with the first slot, got %v", err)
+	}
+	if _, err := service.SetOverride(ctx, "next", types.PINNED_OPEN); !errors.As(err, &conflict) {
+		t.Fatalf("Expected pinning open to conflict, got %v", err)
+	}
+	if slotRepo.slots["next"].Override != types.AUTO {
+		t.Errorf("Expected the override to be left unchanged, got %s", slotRepo.slots["next"].Override)
+	}
+
+	// Handing a slot back to the schedule leaves it closed while the booth is taken
+	slot, err := service.SetOverride(ctx, "next", types.AUTO)
+	if err != nil {
+		t.Fatalf("SetOverride failed: %v", err)
+	}
+	if slot.IsActive {
+		t.Error("Expected the slot to stay closed")
+	}
+
+	// Once the first slot closes the next one opens
+	if err := service.DeactivateSalesSlot(ctx, "first"); err != nil {
+		t.Fatalf("DeactivateSalesSlot failed: %v", err)
+	}
+	slotRepo.slots["first"].Override = types.PINNED_CLOSED
+	if err := service.ApplySchedule(ctx); err != nil {
+		t.Fatalf("ApplySchedule failed: %v", err)
+	}
+	if !slotRepo.slots["next"].IsActive {
+		t.Error("Expected the next slot to open")
+	}
+}
+
+func TestSalesSlotService_OneActiveSlotPerBoothOnStaleRead(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	schedule := SlotSchedule{CloseAfter: 10 * time.Minute, Overlap: types.OVERLAP_PER_BOOTH}
+	service := NewSalesSlotService(slotRepo, newMockInventoryRepository(), newMockProductRepository(), newMockOrderRepository(), newMockIngredientRepository(), schedule, nil)
+	ctx := context.Background()
+
+	now := time.Now()
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "first", Booth: "A", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour), IsActive: true, Override: types.PINNED_OPEN})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "next", Booth: "A", StartTime: now.Add(-5 * time.Minute), EndTime: now.Add(time.Hour), Override: types.AUTO})
+	// The first slot opened after the booth was checked.
+	slotRepo.staleActive = true
+
+	var conflict *SlotConflictError
+	if err := service.ActivateSalesSlot(ctx, "next"); !errors.As(err, &conflict) {
+		t.Fatalf("Expected activation to conflict, got %v", err)
+	}
+	if _, err := service.SetOverride(ctx, "next", types.PINNED_OPEN); !errors.As(err, &conflict) {
+		t.Fatalf("Expected pinning open to conflict, got %v", err)
+	}
+	if slotRepo.slots["next"].Override != types.AUTO {
+		t.Errorf("Expected the override to be restored, got %s", slotRepo.slots["next"].Override)
+	}
+	if err := service.ApplySchedule(ctx); err != nil {
+		t.Fatalf("ApplySchedule failed: %v", err)
+	}
+	if slotRepo.slots["next"].IsActive {
+		t.Error("Expected the next slot to stay closed")
+	}
+}
+
+func TestSalesSlotService_GenerateSalesSlots(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	service := NewSalesSlotService(slotRepo, invRepo, prodRepo, newMockOrderRepository(), newMockIngredientRepository(), SlotSchedule{Overlap: types.OVERLAP_PER_BOOTH}, nil)
+	ctx := context.Background()
+
+	yakisoba := &models.Product{Name: "焼きそば", Price: 400}
+	drink := &models.Product{Name: "ラムネ", Price: 150}
+	prodRepo.Create(ctx, yakisoba)
+	prodRepo.Create(ctx, drink)
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	pattern := SlotPattern{
+		Booth:      "A",
+		FirstDay:   time.Date(2026, 11, 3, 0, 0, 0, 0, tokyo),
+		LastDay:    time.Date(2026, 11, 4, 0, 0, 0, 0, tokyo),
+		OpensAt:    10 * time.Hour,
+		ClosesAt:   12 * time.Hour,
+		SlotLength: 30 * time.Minute,
+		Location:   tokyo,
+		Products:   []SlotTemplateProduct{{ProductID: yakisoba.ID, InitialQuantity: 20}},
+	}
+
+	preview, err := service.GenerateSalesSlots(ctx, pattern, true)
+	if err != nil {
+		t.Fatalf("GenerateSalesSlots failed: %v", err)
+	}
+	if len(preview) != 8 || len(slotRepo.slots) != 0 || len(invRepo.inventories) != 0 {
+		t.Fatalf("Expected a preview of 8 slots without writing, got %d slots", len(preview))
+	}
+
+	generated, err := service.GenerateSalesSlots(ctx, pattern, false)
+	if err != nil {
+		t.Fatalf("GenerateSalesSlots failed: %v", err)
+	}
+	if len(generated) != 8 || len(slotRepo.slots) != 8 || len(invRepo.inventories) != 8 {
+		t.Fatalf("Expected 8 slots with stock, got %d slots and %d inventories", len(slotRepo.slots), len(invRepo.inventories))
+	}
+	if inv, _ := invRepo.FindBySalesSlotAndProduct(ctx, generated[0].Slot.ID, yakisoba.ID); inv == nil || inv.InitialQuantity != 20 {
+		t.Errorf("Expected 20 yakisoba in the first slot, got %+v", inv)
+	}
+
+	// Generating again with another product only adds that product
+	pattern.Products = append(pattern.Products, SlotTemplateProduct{ProductID: drink.ID, InitialQuantity: 50})
+	again, err := service.GenerateSalesSlots(ctx, pattern, false)
+	if err != nil {
+		t.Fatalf("GenerateSalesSlots failed: %v", err)
+	}
+	if len(slotRepo.slots) != 8 || len(invRepo.inventories) != 16 {
+		t.Errorf("Expected no new slots and 8 new inventories, got %d slots and %d inventories", len(slotRepo.slots), len(invRepo.inventories))
+	}
+	if again[0].Created || again[0].Slot.ID != generated[0].Slot.ID || len(again[0].AddedProducts) != 1 || again[0].AddedProducts[0] != drink.ID {
+		t.Errorf("Expected the existing slot to get only the drink, got %+v", again[0])
+	}
+
+	// Slots overlapping others at the booth are reported and nothing is written
+	pattern.SlotLength = 45 * time.Minute
+	preview, err = service.GenerateSalesSlots(ctx, pattern, true)
+	if err != nil {
+		t.Fatalf("GenerateSalesSlots failed: %v", err)
+	}
+	if len(preview[0].Conflicts) == 0 {
+		t.Error("Expected the preview to list conflicts")
+	}
+	var conflict *SlotConflictError
+	if _, err := service.GenerateSalesSlots(ctx, pattern, false); !errors.As(err, &conflict) {
+		t.Fatalf("Expected a conflict, got %v", err)
+	}
+	if len(slotRepo.slots) != 8 {
+		t.Errorf("Expected no slots to be written, got %d", len(slotRepo.slots))
+	}
+
+	// Other booths may overlap under the per-booth policy
+	pattern.Booth = "B"
+	if _, err := service.GenerateSalesSlots(ctx, pattern, false); err != nil {
+		t.Fatalf("GenerateSalesSlots failed: %v", err)
+	}
+}
+
+func TestSalesSlotService_UpdateSalesSlot(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	orderRepo := newMockOrderRepository()
+	service := NewSalesSlotService(slotRepo, newMockInventoryRepository(), newMockProductRepository(), orderRepo, newMockIngredientRepository(), SlotSchedule{}, nil)
+	ctx := context.Background()
+
+	start := time.Date(2026, 11, 3, 10, 0, 0, 0, time.UTC)
+	slot, _ := service.CreateSalesSlot(ctx, "A", start, start.Add(time.Hour))
+	other, _ := service.CreateSalesSlot(ctx, "A", start.Add(2*time.Hour), start.Add(3*time.Hour))
+	orderRepo.Create(ctx, &models.Order{ID: "order1", SalesSlotID: slot.ID, Status: types.RESERVED})
+
+	if _, err := service.UpdateSalesSlot(ctx, slot.ID, "A", start, start.Add(90*time.Minute), false); err != ErrSlotHasOrders {
+		t.Fatalf("Expected ErrSlotHasOrders, got %v", err)
+	}
+
+	// Moving to another booth keeps the times and needs no force
+	updated, err := service.UpdateSalesSlot(ctx, slot.ID, " B ", start, start.Add(time.Hour), false)
+	if err != nil {
+		t.Fatalf("UpdateSalesSlot failed: %v", err)
+	}
+	if updated.Booth != "B" {
+		t.Errorf("Expected booth B, got %q", updated.Booth)
+	}
+
+	var conflict *SlotConflictError
+	if _, err := service.UpdateSalesSlot(ctx, slot.ID, "B", start, start.Add(150*time.Minute), true); !errors.As(err, &conflict) || conflict.Conflicts[0].ID != other.ID {
+		t.Fatalf("Expected a conflict with the other slot, got %v", err)
+	}
+
+	updated, err = service.UpdateSalesSlot(ctx, slot.ID, "B", start, start.Add(2*time.Hour), true)
+	if err != nil {
+		t.Fatalf("UpdateSalesSlot failed: %v", err)
+	}
+	if !updated.EndTime.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("Expected the slot to end at %s, got %s", start.Add(2*time.Hour), updated.EndTime)
+	}
+	if updated.OrderCount != 1 {
+		t.Errorf("Expected the order to be kept, got %d orders", updated.OrderCount)
+	}
+}
+
+func TestSalesSlotService_DeleteSalesSlot(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	orderRepo := newMockOrderRepository()
+	service := NewSalesSlotService(slotRepo, newMockInventoryRepository(), newMockProductRepository(), orderRepo, newMockIngredientRepository(), SlotSchedule{}, nil)
+	ctx := context.Background()
+
+	start := time.Now()
+	empty, _ := service.CreateSalesSlot(ctx, "A", start, start.Add(time.Hour))
+	if err := service.DeleteSalesSlot(ctx, empty.ID, false); err != nil {
+		t.Fatalf("DeleteSalesSlot failed: %v", err)
+	}
+
+	slot, _ := service.CreateSalesSlot(ctx, "A", start, start.Add(time.Hour))
+	orderRepo.Create(ctx, &models.Order{ID: "reserved", SalesSlotID: slot.ID, Status: types.RESERVED, Items: []models.OrderItem{
+		{ProductID: "yakisoba", Quantity: 2},
+		{ProductID: "set", Quantity: 1, Components: []models.OrderItemComponent{
+			{ProductID: "yakisoba", Quantity: 1},
+			{ProductID: "ramune", Quantity: 1},
+		}},
+	}})
+	orderRepo.Create(ctx, &models.Order{ID: "cancelled", SalesSlotID: slot.ID, Status: types.CANCELLED, Items: []models.OrderItem{
+		{ProductID: "yakisoba", Quantity: 5},
+	}})
+	paid := &models.Order{ID: "paid", SalesSlotID: slot.ID, Status: types.RESERVED, Ticket: &models.OrderTicket{IsPaid: true}, Items: []models.OrderItem{
+		{ProductID: "yakisoba", Quantity: 1},
+	}}
+	orderRepo.Create(ctx, paid)
+	confirmed := &models.Order{ID: "confirmed", SalesSlotID: slot.ID, Status: types.CONFIRMED, Items: []models.OrderItem{
+		{ProductID: "yakisoba", Quantity: 1},
+	}}
+	orderRepo.Create(ctx, confirmed)
+
+	if err := service.DeleteSalesSlot(ctx, slot.ID, false); err != ErrSlotHasOrders {
+		t.Fatalf("Expected ErrSlotHasOrders, got %v", err)
+	}
+	if err := service.DeleteSalesSlot(ctx, slot.ID, true); err != ErrSlotHasSales {
+		t.Fatalf("Expected ErrSlotHasSales with confirmed and paid orders, got %v", err)
+	}
+	delete(orderRepo.orders, confirmed.ID)
+	if err := service.DeleteSalesSlot(ctx, slot.ID, true); err != ErrSlotHasSales {
+		t.Fatalf("Expected ErrSlotHasSales with a paid order, got %v", err)
+	}
+	if _, exists := slotRepo.slots[slot.ID]; !exists {
+		t.Fatal("Expected the slot to be kept")
+	}
+
+	delete(orderRepo.orders, paid.ID)
+	if err := service.DeleteSalesSlot(ctx, slot.ID, true); err != nil {
+		t.Fatalf("DeleteSalesSlot failed: %v", err)
+	}
+	if len(slotRepo.cancelled) != 1 || slotRepo.cancelled[0] != "reserved" {
+		t.Errorf("Expected the reserved order cancelled, got %v", slotRepo.cancelled)
+	}
+	reserved := make(map[types.ID]int)
+	for _, m := range slotRepo.released {
+		_, r, s := m.Deltas()
+		if s != 0 {
+			t.Errorf("Expected no sold stock released, got %+v", m)
+		}
+		reserved[m.ProductID] += r
+	}
+	if reserved["yakisoba"] != -3 || reserved["ramune"] != -1 {
+		t.Errorf("Expected 3 reserved yakisoba and 1 ramune released, got %v", reserved)
+	}
+
+	if err := service.DeleteSalesSlot(ctx, slot.ID, true); err == nil {
+		t.Error("Expected an error deleting a missing slot")
+	}
+}
@@ -1,42 +1,87 @@
 package services
 
 import (
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/storage"
 )
 
 // ServiceFactory manages all service instances
 type ServiceFactory interface {
 	ProductService() ProductService
+	CategoryService() CategoryService
+	ProductOptionService() ProductOptionService
 	SalesSlotService() SalesSlotService
 	OrderService() OrderService
 	OrderTicketService() OrderTicketService
+	ReceiptService() ReceiptService
+	PromotionService() PromotionService
+	IngredientService() IngredientService
+	KitchenService() KitchenService
+	WaitTimeService() WaitTimeService
 }
 
 type serviceFactory struct {
-	productService     ProductService
-	salesSlotService   SalesSlotService
-	orderService       OrderService
-	orderTicketService OrderTicketService
+	productService       ProductService
+	categoryService      CategoryService
+	productOptionService ProductOptionService
+	salesSlotService     SalesSlotService
+	orderService         OrderService
+	orderTicketService   OrderTicketService
+	receiptService       ReceiptService
+	promotionService     PromotionService
+	ingredientService    IngredientService
+	kitchenService       KitchenService
+	waitTimeService      WaitTimeService
 }
 
 // NewServiceFactory creates a new service factory instance
 func NewServiceFactory(
 	productRepo repositories.ProductRepository,
+	categoryRepo repositories.CategoryRepository,
+	productOptionGroupRepo repositories.ProductOptionGroupRepository,
 	salesSlotRepo repositories.SalesSlotRepository,
 	productInventoryRepo repositories.ProductInventoryRepository,
 	orderRepo repositories.OrderRepository,
 	orderTicketRepo repositories.OrderTicketRepository,
+	promotionRepo repositories.PromotionRepository,
+	productPriceRepo repositories.ProductPriceRepository,
+	ingredientRepo repositories.IngredientRepository,
+	receiptRenderer receipt.Renderer,
+	printer receipt.Printer,
+	ticketSigner *TicketSigner,
+	imageStorage storage.ImageStorage,
+	taxPolicy TaxPolicy,
+	slotSchedule SlotSchedule,
+	waitPolicy WaitPolicy,
+	publisher events.Publisher,
+	subscriber events.Subscriber,
 ) ServiceFactory {
-	productSvc := NewProductService(productRepo)
-	salesSlotSvc := NewSalesSlotService(salesSlotRepo, productInventoryRepo, productRepo)
-	orderSvc := NewOrderService(orderRepo, salesSlotRepo, productInventoryRepo, productRepo)
-	orderTicketSvc := NewOrderTicketService(orderTicketRepo, orderRepo)
+	productSvc := NewProductService(productRepo, categoryRepo, productPriceRepo, imageStorage)
+	categorySvc := NewCategoryService(categoryRepo)
+	salesSlotSvc := NewSalesSlotService(salesSlotRepo, productInventoryRepo, productRepo, orderRepo, ingredientRepo, slotSchedule, publisher)
+	productOptionSvc := NewProductOptionService(productOptionGroupRepo, productRepo)
+	orderSvc := NewOrderService(orderRepo, salesSlotRepo, productInventoryRepo, productRepo, productOptionGroupRepo, promotionRepo, productPriceRepo, ingredientRepo, taxPolicy, waitPolicy, publisher)
+	promotionSvc := NewPromotionService(promotionRepo, productRepo)
+	orderTicketSvc := NewOrderTicketService(orderTicketRepo, orderRepo, ticketSigner)
+	receiptSvc := NewReceiptService(orderTicketRepo, orderRepo, receiptRenderer, printer)
+	ingredientSvc := NewIngredientService(ingredientRepo, productRepo)
+	kitchenSvc := NewKitchenService(orderRepo, salesSlotRepo, publisher, subscriber)
+	waitTimeSvc := NewWaitTimeService(orderRepo, salesSlotRepo, orderTicketRepo, waitPolicy)
 
 	return &serviceFactory{
-		productService:     productSvc,
-		salesSlotService:   salesSlotSvc,
-		orderService:       orderSvc,
-		orderTicketService: orderTicketSvc,
+		productService:       productSvc,
+		categoryService:      categorySvc,
+		productOptionService: productOptionSvc,
+		salesSlotService:     salesSlotSvc,
+		orderService:         orderSvc,
+		orderTicketService:   orderTicketSvc,
+		receiptService:       receiptSvc,
+		promotionService:     promotionSvc,
+		ingredientService:    ingredientSvc,
+		kitchenService:       kitchenSvc,
+		waitTimeService:      waitTimeSvc,
 	}
 }
 
@@ -44,6 +89,14 @@ func (f *serviceFactory) ProductService() ProductService {
 	return f.productService
 }
 
+func (f *serviceFactory) CategoryService() CategoryService {
+	return f.categoryService
+}
+
+func (f *serviceFactory) ProductOptionService() ProductOptionService {
+	return f.productOptionService
+}
+
 func (f *serviceFactory) SalesSlotService() SalesSlotService {
 	return f.salesSlotService
 }
@@ -55,3 +108,23 @@ func (f *serviceFactory) OrderService() OrderService {
 func (f *serviceFactory) OrderTicketService() OrderTicketService {
 	return f.orderTicketService
 }
+
+func (f *serviceFactory) ReceiptService() ReceiptService {
+	return f.receiptService
+}
+
+func (f *serviceFactory) PromotionService() PromotionService {
+	return f.promotionService
+}
+
+func (f *serviceFactory) IngredientService() IngredientService {
+	return f.ingredientService
+}
+
+func (f *serviceFactory) KitchenService() KitchenService {
+	return f.kitchenService
+}
+
+func (f *serviceFactory) WaitTimeService() WaitTimeService {
+	return f.waitTimeService
+}
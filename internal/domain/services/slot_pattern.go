@@ -0,0 +1,128 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// maxGeneratedSlots bounds how many slots one pattern may produce, to catch
+// patterns with a wrong date range or slot length.
+const maxGeneratedSlots = 500
+
+// SlotPattern describes sales slots of equal length repeated every day from
+// FirstDay to LastDay. Times of day are offsets from midnight in Location.
+type SlotPattern struct {
+	Booth      string
+	FirstDay   time.Time
+	LastDay    time.Time
+	OpensAt    time.Duration
+	ClosesAt   time.Duration
+	SlotLength time.Duration
+	// Breaks are left without slots; slots resume at the end of a break.
+	Breaks   []SlotBreak
+	Location *time.Location
+	// Products are added to every slot.
+	Products []SlotTemplateProduct
+}
+
+type SlotBreak struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// SlotTemplateProduct is a product and its stock for each generated slot.
+type SlotTemplateProduct struct {
+	ProductID       types.ID
+	InitialQuantity int
+	Price           *int
+}
+
+// GeneratedSlot is a slot of a pattern. Created is false for slots that
+// already existed; AddedProducts are the products the slot was missing.
+// Conflicts are the slots it overlaps; slots are only written without any.
+type GeneratedSlot struct {
+	Slot          models.SalesSlot
+	Created       bool
+	AddedProducts []types.ID
+	Conflicts     []models.SalesSlot
+}
+
+// ParseClock parses a time of day such as "09:30" into the offset from
+// midnight. "24:00" is accepted for the end of the day.
+func ParseClock(s string) (time.Duration, bool) {
+	h, m, ok := strings.Cut(s, ":")
+	if !ok || len(h) != 2 || len(m) != 2 {
+		return 0, false
+	}
+	hours, err := strconv.Atoi(h)
+	if err != nil {
+		return 0, false
+	}
+	minutes, err := strconv.Atoi(m)
+	if err != nil || minutes > 59 || hours > 24 || (hours == 24 && minutes > 0) {
+		return 0, false
+	}
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute, true
+}
+
+func (p SlotPattern) validate() error {
+	if p.Location == nil || p.SlotLength <= 0 || p.LastDay.Before(p.FirstDay) ||
+		p.OpensAt < 0 || p.ClosesAt > 24*time.Hour || p.OpensAt+p.SlotLength > p.ClosesAt {
+		return ErrInvalidSlotPattern
+	}
+	for _, b := range p.Breaks {
+		if b.Start >= b.End || b.Start < p.OpensAt || b.End > p.ClosesAt {
+			return ErrInvalidSlotPattern
+		}
+	}
+
+	seen := make(map[types.ID]bool, len(p.Products))
+	for _, product := range p.Products {
+		if seen[product.ProductID] || product.InitialQuantity < 0 || (product.Price != nil && *product.Price < 0) {
+			return ErrInvalidSlotPattern
+		}
+		seen[product.ProductID] = true
+	}
+	return nil
+}
+
+// slots returns the start and end times of the pattern's slots in order.
+func (p SlotPattern) slots() ([][2]time.Time, error) {
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	var slots [][2]time.Time
+	first := time.Date(p.FirstDay.Year(), p.FirstDay.Month(), p.FirstDay.Day(), 0, 0, 0, 0, p.Location)
+	last := time.Date(p.LastDay.Year(), p.LastDay.Month(), p.LastDay.Day(), 0, 0, 0, 0, p.Location)
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		for at := p.OpensAt; at+p.SlotLength <= p.ClosesAt; {
+			if b, ok := p.breakDuring(at, at+p.SlotLength); ok {
+				at = b.End
+				continue
+			}
+			slots = append(slots, [2]time.Time{day.Add(at), day.Add(at + p.SlotLength)})
+			if len(slots) > maxGeneratedSlots {
+				return nil, ErrTooManySlots
+			}
+			at += p.SlotLength
+		}
+	}
+	return slots, nil
+}
+
+// breakDuring returns the first break that overlaps the given time of day.
+func (p SlotPattern) breakDuring(start, end time.Duration) (SlotBreak, bool) {
+	var found SlotBreak
+	ok := false
+	for _, b := range p.Breaks {
+		if b.Start < end && start < b.End && (!ok || b.Start < found.Start) {
+			found, ok = b, true
+		}
+	}
+	return found, ok
+}
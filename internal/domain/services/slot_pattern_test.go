@@ -0,0 +1,110 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"09:30", 9*time.Hour + 30*time.Minute, true},
+		{"00:00", 0, true},
+		{"24:00", 24 * time.Hour, true},
+		{"24:30", 0, false},
+		{"9:30", 0, false},
+		{"09:60", 0, false},
+		{"0930", 0, false},
+	}
+
+	for _, tt := range tests {
+		d, ok := ParseClock(tt.input)
+		if ok != tt.ok || d != tt.expected {
+			t.Errorf("ParseClock(%q) = %v, %v; expected %v, %v", tt.input, d, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestSlotPattern_Slots(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	day := time.Date(2026, 11, 3, 0, 0, 0, 0, tokyo)
+	base := SlotPattern{
+		FirstDay:   day,
+		LastDay:    day,
+		OpensAt:    10 * time.Hour,
+		ClosesAt:   13 * time.Hour,
+		SlotLength: 40 * time.Minute,
+		Location:   tokyo,
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(p *SlotPattern)
+		expected []string
+		err      error
+	}{
+		{
+			name:     "drops the slot that does not fit",
+			modify:   func(p *SlotPattern) {},
+			expected: []string{"10:00", "10:40", "11:20", "12:00"},
+		},
+		{
+			name: "resumes after a break",
+			modify: func(p *SlotPattern) {
+				p.Breaks = []SlotBreak{{Start: 11 * time.Hour, End: 11*time.Hour + 30*time.Minute}}
+			},
+			expected: []string{"10:00", "11:30", "12:10"},
+		},
+		{
+			name: "repeats every day",
+			modify: func(p *SlotPattern) {
+				p.LastDay = day.AddDate(0, 0, 1)
+				p.SlotLength = 90 * time.Minute
+			},
+			expected: []string{"10:00", "11:30", "10:00", "11:30"},
+		},
+		{
+			name:   "last day before first",
+			modify: func(p *SlotPattern) { p.LastDay = day.AddDate(0, 0, -1) },
+			err:    ErrInvalidSlotPattern,
+		},
+		{
+			name:   "break outside open hours",
+			modify: func(p *SlotPattern) { p.Breaks = []SlotBreak{{Start: 9 * time.Hour, End: 11 * time.Hour}} },
+			err:    ErrInvalidSlotPattern,
+		},
+		{
+			name: "too many slots",
+			modify: func(p *SlotPattern) {
+				p.LastDay = day.AddDate(0, 1, 0)
+				p.SlotLength = 5 * time.Minute
+			},
+			err: ErrTooManySlots,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern := base
+			tt.modify(&pattern)
+			slots, err := pattern.slots()
+			if err != tt.err {
+				t.Fatalf("Expected error %v, got %v", tt.err, err)
+			}
+			if len(slots) != len(tt.expected) {
+				t.Fatalf("Expected %d slots, got %d", len(tt.expected), len(slots))
+			}
+			for i, start := range tt.expected {
+				if got := slots[i][0].Format("15:04"); got != start {
+					t.Errorf("Expected slot %d to start at %s, got %s", i, start, got)
+				}
+				if slots[i][1].Sub(slots[i][0]) != pattern.SlotLength {
+					t.Errorf("Expected slot %d to last %v", i, pattern.SlotLength)
+				}
+			}
+		})
+	}
+}
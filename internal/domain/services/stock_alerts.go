@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// stockAlerts publishes StockLow and StockSoldOut for the inventories whose
+// available stock a change brings down to their threshold or to zero.
+type stockAlerts struct {
+	invRepo   repositories.ProductInventoryRepository
+	publisher events.Publisher
+}
+
+type inventoryKey struct {
+	slotID    types.ID
+	productID types.ID
+}
+
+// track runs change, which applies the movements, and publishes the alerts
+// the movements call for. Alerts are only published once, when the
+// available stock first crosses the threshold.
+func (a stockAlerts) track(ctx context.Context, movements []models.StockMovement, change func() error) error {
+	if a.publisher == nil {
+		return change()
+	}
+
+	before := make(map[inventoryKey]int)
+	for _, m := range movements {
+		key := inventoryKey{m.SalesSlotID, m.ProductID}
+		if _, seen := before[key]; seen {
+			continue
+		}
+		inventory, err := a.invRepo.FindBySalesSlotAndProduct(ctx, key.slotID, key.productID)
+		if err != nil {
+			// Left for change to report.
+			continue
+		}
+		before[key] = inventory.GetAvailableQuantity()
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	for _, m := range movements {
+		key := inventoryKey{m.SalesSlotID, m.ProductID}
+		available, tracked := before[key]
+		if !tracked {
+			continue
+		}
+		delete(before, key)
+
+		inventory, err := a.invRepo.FindBySalesSlotAndProduct(ctx, key.slotID, key.productID)
+		if err != nil {
+			continue
+		}
+		eventType := ""
+		switch {
+		case inventory.IsSoldOut() && available > 0:
+			eventType = events.StockSoldOut
+		case inventory.IsLowStock() && available > *inventory.LowStockThreshold:
+			eventType = events.StockLow
+		default:
+			continue
+		}
+		a.publisher.Publish(ctx, events.Event{
+			Type:       eventType,
+			EntityID:   inventory.ID,
+			OccurredAt: time.Now(),
+			Payload:    inventory,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+func TestOrderService_StockAlerts(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	orderRepo := newMockOrderRepository()
+	orderRepo.inventories = invRepo
+	publisher := &recordingPublisher{}
+	slotService := NewSalesSlotService(slotRepo, invRepo, prodRepo, orderRepo, newMockIngredientRepository(), SlotSchedule{}, publisher)
+	orderService := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), publisher)
+	ctx := context.Background()
+
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot1", IsActive: true})
+	prodRepo.Create(ctx, &models.Product{ID: "prod1", Name: "焼きそば", Price: 400})
+	if _, err := slotService.AddProductToSlot(ctx, "slot1", "prod1", 10, nil); err != nil {
+		t.Fatalf("AddProductToSlot failed: %v", err)
+	}
+	threshold := 3
+	if _, err := slotService.SetLowStockThreshold(ctx, "slot1", "prod1", &threshold); err != nil {
+		t.Fatalf("SetLowStockThreshold failed: %v", err)
+	}
+	negative := -1
+	if _, err := slotService.SetLowStockThreshold(ctx, "slot1", "prod1", &negative); err != ErrInvalidStockThreshold {
+		t.Errorf("Expected ErrInvalidStockThreshold, got %v", err)
+	}
+
+	order := func(quantity int) {
+		t.Helper()
+		if _, err := orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod1", Quantity: quantity}}, nil); err != nil {
+			t.Fatalf("CreateOrder failed: %v", err)
+		}
+	}
+	order(6)
+	if len(publisher.events) != 0 {
+		t.Errorf("Expected no alert above the threshold, got %+v", publisher.events)
+	}
+	order(1)
+	order(1)
+	if len(publisher.events) != 1 || publisher.events[0].Type != events.StockLow {
+		t.Fatalf("Expected one low-stock alert, got %+v", publisher.events)
+	}
+	order(2)
+	if len(publisher.events) != 2 || publisher.events[1].Type != events.StockSoldOut {
+		t.Fatalf("Expected a sold-out alert, got %+v", publisher.events)
+	}
+	if inventory := publisher.events[1].Payload.(*models.ProductInventory); !inventory.IsSoldOut() {
+		t.Errorf("Expected the payload to be the sold out inventory, got %+v", inventory)
+	}
+
+	if _, err := slotService.AdjustStock(ctx, "slot1", "prod1", types.RESTOCK, 5, "追加仕入れ"); err != nil {
+		t.Fatalf("AdjustStock failed: %v", err)
+	}
+	inventory, err := slotService.SetAvailability(ctx, "slot1", "prod1", false)
+	if err != nil || !inventory.Unavailable {
+		t.Fatalf("SetAvailability failed: %+v, %v", inventory, err)
+	}
+	if _, err := orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod1", Quantity: 1}}, nil); err != ErrProductUnavailable {
+		t.Errorf("Expected ErrProductUnavailable, got %v", err)
+	}
+	if _, err := slotService.SetAvailability(ctx, "slot1", "prod1", true); err != nil {
+		t.Fatalf("SetAvailability failed: %v", err)
+	}
+	order(1)
+}
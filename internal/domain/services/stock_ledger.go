@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// StockLedger is the stock of a product in a sales slot next to its ledger.
+// Initial, Reserved and Sold are the ledger's totals, which match the
+// inventory's quantities unless stock was changed outside the ledger.
+type StockLedger struct {
+	Inventory models.ProductInventory
+	Movements []models.StockMovement
+	Initial   int
+	Reserved  int
+	Sold      int
+}
+
+func newStockLedger(inventory *models.ProductInventory, movements []models.StockMovement) *StockLedger {
+	ledger := &StockLedger{Inventory: *inventory, Movements: movements}
+	for _, m := range movements {
+		initial, reserved, sold := m.Deltas()
+		ledger.Initial += initial
+		ledger.Reserved += reserved
+		ledger.Sold += sold
+	}
+	return ledger
+}
+
+// Consistent reports whether the inventory's quantities match the ledger.
+func (l *StockLedger) Consistent() bool {
+	return l.Inventory.InitialQuantity == l.Initial &&
+		l.Inventory.ReservedQuantity == l.Reserved &&
+		l.Inventory.SoldQuantity == l.Sold
+}
+
+func (s *salesSlotService) GetStockLedger(ctx context.Context, slotID types.ID, productID types.ID) (*StockLedger, error) {
+	inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
+	if err != nil {
+		return nil, err
+	}
+	movements, err := s.invRepo.FindMovements(ctx, inventory.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newStockLedger(inventory, movements), nil
+}
+
+// AdjustStock records a restock, waste or correction of the stock. Restocks
+// and waste take a positive quantity; corrections add theirs, which may be
+// negative. Stock cannot go below what has been reserved and sold.
+func (s *salesSlotService) AdjustStock(ctx context.Context, slotID types.ID, productID types.ID, movementType types.StockMovementType, quantity int, reason string) (*StockLedger, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || quantity == 0 {
+		return nil, ErrInvalidStockAdjustment
+	}
+	switch movementType {
+	case types.RESTOCK, types.WASTE:
+		if quantity < 0 {
+			return nil, ErrInvalidStockAdjustment
+		}
+		if movementType == types.WASTE {
+			quantity = -quantity
+		}
+	case types.CORRECTION:
+	default:
+		return nil, ErrInvalidStockAdjustment
+	}
+
+	inventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, slotID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if inventory.GetAvailableQuantity()+quantity < 0 {
+		return nil, ErrInsufficientInventory
+	}
+
+	movements := []models.StockMovement{{
+		SalesSlotID: slotID,
+		ProductID:   productID,
+		Type:        movementType,
+		Quantity:    quantity,
+		Reason:      reason,
+	}}
+	if err := s.alerts.track(ctx, movements, func() error {
+		return s.invRepo.ApplyMovements(ctx, movements)
+	}); err != nil {
+		if errors.Is(err, repositories.ErrStockShortage) {
+			return nil, ErrInsufficientInventory
+		}
+		return nil, err
+	}
+	return s.GetStockLedger(ctx, slotID, productID)
+}
+
+// ReconcileStock brings the inventory and its ledger back in line. The
+// ledger is read again under the inventory's lock, so stock moved since it
+// was first read is taken into account.
+func (s *salesSlotService) ReconcileStock(ctx context.Context, slotID types.ID, productID types.ID) (*StockLedger, error) {
+	ledger, err := s.GetStockLedger(ctx, slotID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if ledger.Consistent() {
+		return ledger, nil
+	}
+
+	if err := s.invRepo.Reconcile(ctx, ledger.Inventory.ID, reconcileLedger); err != nil {
+		return nil, err
+	}
+	return s.GetStockLedger(ctx, slotID, productID)
+}
+
+// reconcileLedger works out how to bring the inventory and its ledger in
+// line. The ledger starts with the stock a product is added to the slot
+// with; stock added before the ledger was kept has no such start, so its
+// ledger gets opening entries, which are returned, for the inventory's
+// quantities less the ledger's totals, the stock the later entries were
+// made on. Otherwise the inventory's quantities are set to the ledger's
+// totals.
+func reconcileLedger(inventory *models.ProductInventory, movements []models.StockMovement) []models.StockMovement {
+	ledger := newStockLedger(inventory, movements)
+	if ledger.Consistent() {
+		return nil
+	}
+	if ledgerStarted(movements) {
+		inventory.InitialQuantity = ledger.Initial
+		inventory.ReservedQuantity = ledger.Reserved
+		inventory.SoldQuantity = ledger.Sold
+		return nil
+	}
+
+	var opening []models.StockMovement
+	for _, m := range []models.StockMovement{
+		{Type: types.CORRECTION, Quantity: inventory.InitialQuantity - ledger.Initial},
+		{Type: types.RESERVATION, Quantity: inventory.ReservedQuantity - ledger.Reserved},
+		{Type: types.SALE, Quantity: inventory.SoldQuantity - ledger.Sold},
+	} {
+		if m.Quantity == 0 {
+			continue
+		}
+		m.InventoryID, m.SalesSlotID, m.ProductID, m.Reason = inventory.ID, inventory.SalesSlotID, inventory.ProductID, openingStockReason
+		// Dated when the stock was added, before the entries made on it.
+		m.CreatedAt = inventory.CreatedAt
+		opening = append(opening, m)
+	}
+	return opening
+}
+
+const (
+	initialStockReason = "初期在庫"
+	openingStockReason = "台帳開始時点の在庫"
+)
+
+// ledgerStarted reports whether the movements include the start of the
+// ledger: the stock the product was added with, or opening entries.
+func ledgerStarted(movements []models.StockMovement) bool {
+	for _, m := range movements {
+		if m.Reason == openingStockReason || (m.Type == types.RESTOCK && m.Reason == initialStockReason) {
+			return true
+		}
+	}
+	return false
+}
+
+// restock records the stock a product starts with in a slot.
+func (s *salesSlotService) restock(ctx context.Context, slotID types.ID, productID types.ID, quantity int) error {
+	if quantity == 0 {
+		return nil
+	}
+	return s.invRepo.ApplyMovements(ctx, []models.StockMovement{{
+		SalesSlotID: slotID,
+		ProductID:   productID,
+		Type:        types.RESTOCK,
+		Quantity:    quantity,
+		Reason:      initialStockReason,
+	}})
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+func TestSalesSlotService_StockLedger(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	orderRepo := newMockOrderRepository()
+	orderRepo.inventories = invRepo
+	slotService := NewSalesSlotService(slotRepo, invRepo, prodRepo, orderRepo, newMockIngredientRepository(), SlotSchedule{}, nil)
+	orderService := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot1", IsActive: true})
+	prodRepo.Create(ctx, &models.Product{ID: "prod1", Name: "焼きそば", Price: 400})
+	if _, err := slotService.AddProductToSlot(ctx, "slot1", "prod1", 10, nil); err != nil {
+		t.Fatalf("AddProductToSlot failed: %v", err)
+	}
+
+	order, err := orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod1", Quantity: 2}}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if err := orderService.UpdateOrderStatus(ctx, order.ID, types.CONFIRMED); err != nil {
+		t.Fatalf("UpdateOrderStatus failed: %v", err)
+	}
+
+	ledger, err := slotService.GetStockLedger(ctx, "slot1", "prod1")
+	if err != nil {
+		t.Fatalf("GetStockLedger failed: %v", err)
+	}
+	expected := []struct {
+		movementType types.StockMovementType
+		quantity     int
+	}{
+		{types.RESTOCK, 10},
+		{types.RESERVATION, 2},
+		{types.RELEASE, -2},
+		{types.SALE, 2},
+	}
+	if len(ledger.Movements) != len(expected) {
+		t.Fatalf("Expected %d movements, got %+v", len(expected), ledger.Movements)
+	}
+	for i, e := range expected {
+		m := ledger.Movements[i]
+		if m.Type != e.movementType || m.Quantity != e.quantity || m.Reason == "" {
+			t.Errorf("Expected movement %d to be %s %d, got %+v", i, e.movementType, e.quantity, m)
+		}
+		if i > 0 && (m.OrderID == nil || *m.OrderID != order.ID) {
+			t.Errorf("Expected movement %d to belong to the order", i)
+		}
+	}
+	if !ledger.Consistent() || ledger.Initial != 10 || ledger.Reserved != 0 || ledger.Sold != 2 {
+		t.Errorf("Expected a consistent ledger of 10/0/2, got %d/%d/%d", ledger.Initial, ledger.Reserved, ledger.Sold)
+	}
+
+	ledger, err = slotService.AdjustStock(ctx, "slot1", "prod1", types.WASTE, 3, " 焦げ ")
+	if err != nil {
+		t.Fatalf("AdjustStock failed: %v", err)
+	}
+	if ledger.Inventory.InitialQuantity != 7 || ledger.Movements[4].Quantity != -3 || ledger.Movements[4].Reason != "焦げ" {
+		t.Errorf("Expected 3 to be written off, got %+v", ledger.Movements[4])
+	}
+	if _, err := slotService.AdjustStock(ctx, "slot1", "prod1", types.WASTE, 6, "焦げ"); err != ErrInsufficientInventory {
+		t.Errorf("Expected ErrInsufficientInventory, got %v", err)
+	}
+	for _, movementType := range []types.StockMovementType{types.SALE, types.RESERVATION} {
+		if _, err := slotService.AdjustStock(ctx, "slot1", "prod1", movementType, 1, "手入力"); err != ErrInvalidStockAdjustment {
+			t.Errorf("Expected %s to be rejected, got %v", movementType, err)
+		}
+	}
+	if _, err := slotService.AdjustStock(ctx, "slot1", "prod1", types.RESTOCK, 1, " "); err != ErrInvalidStockAdjustment {
+		t.Errorf("Expected a missing reason to be rejected, got %v", err)
+	}
+
+	// Stock changed outside the ledger is set back to the ledger's totals
+	inventory, _ := invRepo.FindBySalesSlotAndProduct(ctx, "slot1", "prod1")
+	inventory.InitialQuantity = 100
+	ledger, _ = slotService.GetStockLedger(ctx, "slot1", "prod1")
+	if ledger.Consistent() {
+		t.Error("Expected the ledger to differ from the stock")
+	}
+	ledger, err = slotService.ReconcileStock(ctx, "slot1", "prod1")
+	if err != nil {
+		t.Fatalf("ReconcileStock failed: %v", err)
+	}
+	if !ledger.Consistent() || ledger.Inventory.InitialQuantity != 7 {
+		t.Errorf("Expected the stock to be set back to 7, got %d", ledger.Inventory.InitialQuantity)
+	}
+
+	// Stock from before the ledger gets opening entries
+	prodRepo.Create(ctx, &models.Product{ID: "prod2", Name: "ラムネ", Price: 150})
+	invRepo.Create(ctx, &models.ProductInventory{ID: "inv2", SalesSlotID: "slot1", ProductID: "prod2", InitialQuantity: 20, ReservedQuantity: 1, SoldQuantity: 4})
+	ledger, err = slotService.ReconcileStock(ctx, "slot1", "prod2")
+	if err != nil {
+		t.Fatalf("ReconcileStock failed: %v", err)
+	}
+	if !ledger.Consistent() || len(ledger.Movements) != 3 || ledger.Inventory.InitialQuantity != 20 {
+		t.Errorf("Expected opening entries for the current stock, got %+v", ledger)
+	}
+
+	// Orders placed on stock from before the ledger leave the stock it
+	// started with out of the ledger
+	prodRepo.Create(ctx, &models.Product{ID: "prod3", Name: "フランクフルト", Price: 200})
+	invRepo.Create(ctx, &models.ProductInventory{ID: "inv3", SalesSlotID: "slot1", ProductID: "prod3", InitialQuantity: 30, ReservedQuantity: 2, SoldQuantity: 5})
+	order, err = orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod3", Quantity: 3}}, nil)
+	if err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	if err := orderService.UpdateOrderStatus(ctx, order.ID, types.CONFIRMED); err != nil {
+		t.Fatalf("UpdateOrderStatus failed: %v", err)
+	}
+	if _, err := orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod3", Quantity: 1}}, nil); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+	ledger, _ = slotService.GetStockLedger(ctx, "slot1", "prod3")
+	if ledger.Consistent() {
+		t.Error("Expected the ledger to differ from the stock")
+	}
+
+	ledger, err = slotService.ReconcileStock(ctx, "slot1", "prod3")
+	if err != nil {
+		t.Fatalf("ReconcileStock failed: %v", err)
+	}
+	inv := ledger.Inventory
+	if !ledger.Consistent() || inv.InitialQuantity != 30 || inv.ReservedQuantity != 3 || inv.SoldQuantity != 8 {
+		t.Errorf("Expected the stock kept at 30/3/8, got %d/%d/%d", inv.InitialQuantity, inv.ReservedQuantity, inv.SoldQuantity)
+	}
+	opening := make(map[types.StockMovementType]int)
+	for _, m := range ledger.Movements {
+		if m.Reason == openingStockReason {
+			opening[m.Type] += m.Quantity
+		}
+	}
+	if len(opening) != 3 || opening[types.CORRECTION] != 30 || opening[types.RESERVATION] != 2 || opening[types.SALE] != 5 {
+		t.Errorf("Expected opening entries of 30/2/5, got %v", opening)
+	}
+}
+
+func TestReconcileLedger(t *testing.T) {
+	inventory := &models.ProductInventory{ID: "inv1", SalesSlotID: "slot1", ProductID: "prod1", InitialQuantity: 10, ReservedQuantity: 2}
+	movements := []models.StockMovement{
+		{Type: types.RESTOCK, Quantity: 10, Reason: initialStockReason},
+		{Type: types.RESERVATION, Quantity: 2},
+	}
+	if opening := reconcileLedger(inventory, movements); opening != nil || inventory.InitialQuantity != 10 || inventory.ReservedQuantity != 2 {
+		t.Fatalf("Expected a consistent inventory to be left alone, got %+v and %+v", inventory, opening)
+	}
+
+	// A reservation recorded after the ledger was first read is counted.
+	movements = append(movements, models.StockMovement{Type: types.RESERVATION, Quantity: 3})
+	if opening := reconcileLedger(inventory, movements); opening != nil || inventory.ReservedQuantity != 5 {
+		t.Errorf("Expected 5 reserved without opening entries, got %d and %+v", inventory.ReservedQuantity, opening)
+	}
+
+	// Stock from before the ledger gets opening entries instead.
+	inventory = &models.ProductInventory{ID: "inv2", SalesSlotID: "slot1", ProductID: "prod2", InitialQuantity: 8, SoldQuantity: 3}
+	opening := reconcileLedger(inventory, []models.StockMovement{{Type: types.SALE, Quantity: 1}})
+	if len(opening) != 2 || opening[0].Quantity != 8 || opening[1].Type != types.SALE || opening[1].Quantity != 2 {
+		t.Fatalf("Expected opening entries for 8 in stock and 2 sold, got %+v", opening)
+	}
+	if opening[0].InventoryID != "inv2" || opening[0].Reason != openingStockReason || inventory.InitialQuantity != 8 {
+		t.Errorf("Unexpected opening entries %+v for %+v", opening, inventory)
+	}
+}
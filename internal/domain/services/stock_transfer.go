@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// rollOverReason is the reason recorded for stock carried over to the next
+// slot when a slot closes.
+const rollOverReason = "販売枠終了時の繰越"
+
+// StockTransfer is stock of a product moved from one slot to another, with
+// both inventories as they are after the move.
+type StockTransfer struct {
+	From     models.ProductInventory
+	To       models.ProductInventory
+	Quantity int
+}
+
+// TransferStock moves available stock of a product to another slot, adding
+// the product to that slot with the same price and limits when it is not
+// sold there yet. Reserved stock is never moved.
+func (s *salesSlotService) TransferStock(ctx context.Context, fromSlotID, toSlotID types.ID, productID types.ID, quantity int, reason string) (*StockTransfer, error) {
+	reason = strings.TrimSpace(reason)
+	if fromSlotID == toSlotID || quantity <= 0 || reason == "" {
+		return nil, ErrInvalidStockTransfer
+	}
+
+	from, err := s.invRepo.FindBySalesSlotAndProduct(ctx, fromSlotID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if from.GetAvailableQuantity() < quantity {
+		return nil, ErrInsufficientInventory
+	}
+	if _, err := s.slotRepo.FindByID(ctx, toSlotID); err != nil {
+		return nil, err
+	}
+	return s.transfer(ctx, from, toSlotID, quantity, reason)
+}
+
+// RollOverStock moves the available stock of every product of a slot to the
+// next slot of the same booth. Nothing is moved when there is no next slot.
+func (s *salesSlotService) RollOverStock(ctx context.Context, slotID types.ID) ([]StockTransfer, error) {
+	slot, err := s.slotRepo.FindByID(ctx, slotID)
+	if err != nil {
+		return nil, err
+	}
+	next, err := s.slotRepo.FindNext(ctx, slot.Booth, slot.EndTime)
+	if err != nil {
+		var notFound *repositories.ErrNotFound
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	inventories, err := s.invRepo.FindBySalesSlotID(ctx, slotID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(inventories, func(i, j int) bool {
+		return inventories[i].ProductID < inventories[j].ProductID
+	})
+
+	var transfers []StockTransfer
+	for i := range inventories {
+		quantity := inventories[i].GetAvailableQuantity()
+		if quantity <= 0 {
+			continue
+		}
+		transfer, err := s.transfer(ctx, &inventories[i], next.ID, quantity, rollOverReason)
+		if err != nil {
+			return transfers, err
+		}
+		transfers = append(transfers, *transfer)
+	}
+	return transfers, nil
+}
+
+func (s *salesSlotService) transfer(ctx context.Context, from *models.ProductInventory, toSlotID types.ID, quantity int, reason string) (*StockTransfer, error) {
+	if _, err := s.invRepo.FindBySalesSlotAndProduct(ctx, toSlotID, from.ProductID); err != nil {
+		var notFound *repositories.ErrNotFound
+		if !errors.As(err, &notFound) {
+			return nil, err
+		}
+		if err := s.invRepo.Create(ctx, &models.ProductInventory{
+			SalesSlotID:    toSlotID,
+			ProductID:      from.ProductID,
+			Price:          from.Price,
+			MaxPerOrder:    from.MaxPerOrder,
+			MaxPerCustomer: from.MaxPerCustomer,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	fromSlotID := from.SalesSlotID
+	movements := []models.StockMovement{
+		{
+			SalesSlotID:    fromSlotID,
+			ProductID:      from.ProductID,
+			Type:           types.TRANSFER,
+			Quantity:       -quantity,
+			Reason:         reason,
+			TransferSlotID: &toSlotID,
+		},
+		{
+			SalesSlotID:    toSlotID,
+			ProductID:      from.ProductID,
+			Type:           types.TRANSFER,
+			Quantity:       quantity,
+			Reason:         reason,
+			TransferSlotID: &fromSlotID,
+		},
+	}
+	if err := s.alerts.track(ctx, movements, func() error {
+		return s.invRepo.ApplyMovements(ctx, movements)
+	}); err != nil {
+		if errors.Is(err, repositories.ErrStockShortage) {
+			return nil, ErrInsufficientInventory
+		}
+		return nil, err
+	}
+
+	fromInventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, fromSlotID, from.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	toInventory, err := s.invRepo.FindBySalesSlotAndProduct(ctx, toSlotID, from.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	return &StockTransfer{From: *fromInventory, To: *toInventory, Quantity: quantity}, nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+func TestSalesSlotService_TransferStock(t *testing.T) {
+	slotRepo := newMockSalesSlotRepository()
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	orderRepo := newMockOrderRepository()
+	orderRepo.inventories = invRepo
+	slotService := NewSalesSlotService(slotRepo, invRepo, prodRepo, orderRepo, newMockIngredientRepository(), SlotSchedule{}, nil)
+	orderService := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), DefaultWaitPolicy(), nil)
+	ctx := context.Background()
+
+	start := time.Date(2026, 11, 3, 10, 0, 0, 0, time.UTC)
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot1", Booth: "A", StartTime: start, EndTime: start.Add(30 * time.Minute), IsActive: true})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot2", Booth: "A", StartTime: start.Add(30 * time.Minute), EndTime: start.Add(time.Hour)})
+	prodRepo.Create(ctx, &models.Product{ID: "prod1", Name: "焼きそば", Price: 400})
+	price := 350
+	if _, err := slotService.AddProductToSlot(ctx, "slot1", "prod1", 10, &price); err != nil {
+		t.Fatalf("AddProductToSlot failed: %v", err)
+	}
+	if _, err := orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod1", Quantity: 4}}, nil); err != nil {
+		t.Fatalf("CreateOrder failed: %v", err)
+	}
+
+	transfer, err := slotService.TransferStock(ctx, "slot1", "slot2", "prod1", 2, " 売れ残り ")
+	if err != nil {
+		t.Fatalf("TransferStock failed: %v", err)
+	}
+	if transfer.From.InitialQuantity != 8 || transfer.To.InitialQuantity != 2 || transfer.To.Price == nil || *transfer.To.Price != price {
+		t.Errorf("Expected 2 to move to a new inventory at the same price, got %+v", transfer)
+	}
+	ledger, err := slotService.GetStockLedger(ctx, "slot2", "prod1")
+	if err != nil {
+		t.Fatalf("GetStockLedger failed: %v", err)
+	}
+	if m := ledger.Movements[0]; m.Quantity != 2 || m.Reason != "売れ残り" || m.TransferSlotID == nil || *m.TransferSlotID != "slot1" {
+		t.Errorf("Expected the transfer in the ledger, got %+v", m)
+	}
+
+	// 8 remain, of which 4 are reserved.
+	if _, err := slotService.TransferStock(ctx, "slot1", "slot2", "prod1", 5, "売れ残り"); err != ErrInsufficientInventory {
+		t.Errorf("Expected reserved stock not to move, got %v", err)
+	}
+	for _, tc := range []struct {
+		to       types.ID
+		quantity int
+		reason   string
+	}{
+		{"slot1", 1, "売れ残り"},
+		{"slot2", 0, "売れ残り"},
+		{"slot2", 1, ""},
+	} {
+		if _, err := slotService.TransferStock(ctx, "slot1", tc.to, "prod1", tc.quantity, tc.reason); err != ErrInvalidStockTransfer {
+			t.Errorf("Expected %+v to be rejected, got %v", tc, err)
+		}
+	}
+
+	transfers, err := slotService.RollOverStock(ctx, "slot1")
+	if err != nil {
+		t.Fatalf("RollOverStock failed: %v", err)
+	}
+	if len(transfers) != 1 || transfers[0].Quantity != 4 || transfers[0].From.GetAvailableQuantity() != 0 || transfers[0].To.InitialQuantity != 6 {
+		t.Errorf("Expected the 4 unreserved to roll over, got %+v", transfers)
+	}
+	if transfers, err := slotService.RollOverStock(ctx, "slot2"); err != nil || len(transfers) != 0 {
+		t.Errorf("Expected nothing to roll over without a next slot, got %+v, %v", transfers, err)
+	}
+}
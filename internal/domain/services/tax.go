@@ -0,0 +1,128 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// TaxPolicy is how consumption tax is applied to new orders.
+type TaxPolicy struct {
+	Mode     types.TaxMode
+	Rounding types.TaxRounding
+}
+
+func DefaultTaxPolicy() TaxPolicy {
+	return TaxPolicy{
+		Mode:     types.TAX_INCLUSIVE,
+		Rounding: types.ROUND_DOWN,
+	}
+}
+
+// apply sets the order's tax breakdown and total from the given items and the
+// order's discounts. Orders keep the tax mode they were created with.
+func (p TaxPolicy) apply(order *models.Order, items []models.OrderItem) {
+	if order.TaxMode == 0 {
+		order.TaxMode = p.Mode
+	}
+	order.Taxes = calculateTaxes(items, order.Discounts, order.TaxMode, p.Rounding)
+
+	total := -order.GetDiscountAmount()
+	for _, item := range items {
+		total += item.GetSubtotal()
+	}
+	if total < 0 {
+		total = 0
+	}
+	if order.TaxMode == types.TAX_EXCLUSIVE {
+		total += order.GetTaxAmount()
+	}
+	order.TotalAmount = total
+}
+
+func itemTaxRate(item models.OrderItem) types.TaxRate {
+	if item.TaxRate == 0 {
+		return types.STANDARD_RATE
+	}
+	return item.TaxRate
+}
+
+// calculateTaxes totals the items per tax rate, takes the discounts off the
+// rates they apply to and rounds the tax once per rate. Discounts for a single
+// product come off that product's rate; other discounts are split across the
+// rates in proportion to their amounts.
+func calculateTaxes(items []models.OrderItem, discounts []models.OrderDiscount, mode types.TaxMode, rounding types.TaxRounding) []models.OrderTax {
+	taxable := make(map[types.TaxRate]int)
+	for _, item := range items {
+		taxable[itemTaxRate(item)] += item.GetSubtotal()
+	}
+
+	for _, d := range discounts {
+		eligible := make(map[types.TaxRate]int)
+		for _, item := range items {
+			if d.ProductID == nil || *d.ProductID == item.ProductID {
+				eligible[itemTaxRate(item)] += item.GetSubtotal()
+			}
+		}
+		for rate, amount := range allocate(d.Amount, eligible) {
+			taxable[rate] -= amount
+			if taxable[rate] < 0 {
+				taxable[rate] = 0
+			}
+		}
+	}
+
+	var taxes []models.OrderTax
+	for _, rate := range sortedRates(taxable) {
+		amount := taxable[rate]
+		if amount == 0 {
+			continue
+		}
+		percent := rate.Percent()
+		tax := rounding.Divide(amount*percent, 100)
+		if mode != types.TAX_EXCLUSIVE {
+			tax = rounding.Divide(amount*percent, 100+percent)
+		}
+		taxes = append(taxes, models.OrderTax{
+			Rate:          rate,
+			TaxableAmount: amount,
+			TaxAmount:     tax,
+		})
+	}
+	return taxes
+}
+
+// allocate splits amount across the rates in proportion to weights. The last
+// rate takes the remainder so that the shares add up to amount.
+func allocate(amount int, weights map[types.TaxRate]int) map[types.TaxRate]int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return nil
+	}
+
+	shares := make(map[types.TaxRate]int, len(weights))
+	rates := sortedRates(weights)
+	remaining := amount
+	for i, rate := range rates {
+		share := amount * weights[rate] / total
+		if i == len(rates)-1 {
+			share = remaining
+		}
+		shares[rate] = share
+		remaining -= share
+	}
+	return shares
+}
+
+func sortedRates(m map[types.TaxRate]int) []types.TaxRate {
+	rates := make([]types.TaxRate, 0, len(m))
+	for rate := range m {
+		rates = append(rates, rate)
+	}
+	sort.Slice(rates, func(i, j int) bool { return rates[i] < rates[j] })
+	return rates
+}
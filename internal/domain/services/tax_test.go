@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+func TestCalculateTaxes(t *testing.T) {
+	items := []models.OrderItem{
+		{ProductID: types.ID("yakisoba"), Quantity: 2, Price: 540, TaxRate: types.REDUCED_RATE},
+		{ProductID: types.ID("goods"), Quantity: 1, Price: 1100, TaxRate: types.STANDARD_RATE},
+	}
+
+	tests := []struct {
+		name      string
+		discounts []models.OrderDiscount
+		mode      types.TaxMode
+		rounding  types.TaxRounding
+		expected  []models.OrderTax
+	}{
+		{
+			name:     "inclusive",
+			mode:     types.TAX_INCLUSIVE,
+			rounding: types.ROUND_DOWN,
+			expected: []models.OrderTax{
+				{Rate: types.STANDARD_RATE, TaxableAmount: 1100, TaxAmount: 100},
+				{Rate: types.REDUCED_RATE, TaxableAmount: 1080, TaxAmount: 80},
+			},
+		},
+		{
+			name:     "exclusive rounds once per rate",
+			mode:     types.TAX_EXCLUSIVE,
+			rounding: types.ROUND_UP,
+			expected: []models.OrderTax{
+				{Rate: types.STANDARD_RATE, TaxableAmount: 1100, TaxAmount: 110},
+				{Rate: types.REDUCED_RATE, TaxableAmount: 1080, TaxAmount: 87},
+			},
+		},
+		{
+			name: "product discount comes off its own rate",
+			discounts: []models.OrderDiscount{
+				{ProductID: idPtr("yakisoba"), Amount: 540},
+			},
+			mode:     types.TAX_INCLUSIVE,
+			rounding: types.ROUND_DOWN,
+			expected: []models.OrderTax{
+				{Rate: types.STANDARD_RATE, TaxableAmount: 1100, TaxAmount: 100},
+				{Rate: types.REDUCED_RATE, TaxableAmount: 540, TaxAmount: 40},
+			},
+		},
+		{
+			name: "order discount is split by amount",
+			discounts: []models.OrderDiscount{
+				{Amount: 218},
+			},
+			mode:     types.TAX_INCLUSIVE,
+			rounding: types.ROUND_HALF_UP,
+			expected: []models.OrderTax{
+				{Rate: types.STANDARD_RATE, TaxableAmount: 990, TaxAmount: 90},
+				{Rate: types.REDUCED_RATE, TaxableAmount: 972, TaxAmount: 72},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxes := calculateTaxes(items, tt.discounts, tt.mode, tt.rounding)
+			if len(taxes) != len(tt.expected) {
+				t.Fatalf("Expected %d rates, got %d", len(tt.expected), len(taxes))
+			}
+			for i, expected := range tt.expected {
+				if taxes[i] != expected {
+					t.Errorf("Expected %+v, got %+v", expected, taxes[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTaxPolicy_ExclusiveTotal(t *testing.T) {
+	policy := TaxPolicy{Mode: types.TAX_EXCLUSIVE, Rounding: types.ROUND_DOWN}
+	order := &models.Order{
+		Discounts: []models.OrderDiscount{{Amount: 100}},
+	}
+	items := []models.OrderItem{
+		{Quantity: 3, Price: 400, TaxRate: types.REDUCED_RATE},
+	}
+
+	policy.apply(order, items)
+
+	if order.TaxMode != types.TAX_EXCLUSIVE {
+		t.Errorf("Expected tax mode EXCLUSIVE, got %s", order.TaxMode)
+	}
+	if order.GetTaxAmount() != 88 {
+		t.Errorf("Expected tax 88, got %d", order.GetTaxAmount())
+	}
+	if order.TotalAmount != 1188 {
+		t.Errorf("Expected total amount 1188, got %d", order.TotalAmount)
+	}
+}
+
+func idPtr(id string) *types.ID {
+	v := types.ID(id)
+	return &v
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+// WaitPolicy is how wait times are estimated. The kitchen is assumed to work
+// through orders in the order they were confirmed, Lanes orders at a time,
+// preparing the items of an order together.
+type WaitPolicy struct {
+	Lanes int
+	// DefaultPrepTime is assumed for items before any item in the slot has
+	// been prepared.
+	DefaultPrepTime time.Duration
+	// Window is how many of the most recent ready items the average
+	// preparation times are taken over.
+	Window int
+}
+
+func DefaultWaitPolicy() WaitPolicy {
+	return WaitPolicy{Lanes: 1, DefaultPrepTime: 5 * time.Minute, Window: 20}
+}
+
+// TicketWait is where a ticket's order is in the kitchen. EstimatedWait is
+// zero once the order is ready.
+type TicketWait struct {
+	Ticket        models.OrderTicket
+	SalesSlotID   types.ID
+	Ready         bool
+	EstimatedWait time.Duration
+}
+
+type WaitTimeService interface {
+	GetPrepTimes(ctx context.Context, salesSlotID types.ID) (*repositories.PrepTimes, error)
+	// GetCallDisplay returns the undelivered tickets of the active slots,
+	// optionally only the booth's, earliest confirmed first.
+	GetCallDisplay(ctx context.Context, booth string) ([]TicketWait, error)
+	GetTicketWait(ctx context.Context, ticketID types.ID) (*TicketWait, error)
+}
+
+type waitTimeService struct {
+	orderRepo  repositories.OrderRepository
+	slotRepo   repositories.SalesSlotRepository
+	ticketRepo repositories.OrderTicketRepository
+	estimator  waitEstimator
+}
+
+func NewWaitTimeService(
+	orderRepo repositories.OrderRepository,
+	slotRepo repositories.SalesSlotRepository,
+	ticketRepo repositories.OrderTicketRepository,
+	policy WaitPolicy,
+) WaitTimeService {
+	return &waitTimeService{
+		orderRepo:  orderRepo,
+		slotRepo:   slotRepo,
+		ticketRepo: ticketRepo,
+		estimator:  waitEstimator{orderRepo: orderRepo, policy: policy},
+	}
+}
+
+func (s *waitTimeService) GetPrepTimes(ctx context.Context, salesSlotID types.ID) (*repositories.PrepTimes, error) {
+	if _, err := s.slotRepo.FindByID(ctx, salesSlotID); err != nil {
+		return nil, err
+	}
+	return s.orderRepo.FindPrepTimes(ctx, salesSlotID, s.estimator.window())
+}
+
+func (s *waitTimeService) GetCallDisplay(ctx context.Context, booth string) ([]TicketWait, error) {
+	slots, err := s.slotRepo.FindActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var slotIDs []types.ID
+	for _, slot := range slots {
+		if booth == "" || slot.Booth == booth {
+			slotIDs = append(slotIDs, slot.ID)
+		}
+	}
+	orders, err := s.orderRepo.FindUndelivered(ctx, slotIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	waits := make(map[types.ID]map[types.ID]time.Duration)
+	result := make([]TicketWait, 0, len(orders))
+	for _, order := range orders {
+		if order.Ticket == nil {
+			continue
+		}
+		if _, exists := waits[order.SalesSlotID]; !exists {
+			q, err := s.estimator.queue(ctx, order.SalesSlotID, now)
+			if err != nil {
+				return nil, err
+			}
+			waits[order.SalesSlotID] = q.waits
+		}
+		wait, queued := waits[order.SalesSlotID][order.ID]
+		result = append(result, TicketWait{
+			Ticket:        *order.Ticket,
+			SalesSlotID:   order.SalesSlotID,
+			Ready:         !queued,
+			EstimatedWait: wait,
+		})
+	}
+	return result, nil
+}
+
+func (s *waitTimeService) GetTicketWait(ctx context.Context, ticketID types.ID) (*TicketWait, error) {
+	ticket, err := s.ticketRepo.FindByID(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.orderRepo.FindByID(ctx, ticket.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != types.CONFIRMED {
+		return nil, ErrOrderNotConfirmed
+	}
+
+	q, err := s.estimator.queue(ctx, order.SalesSlotID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	wait, queued := q.waits[order.ID]
+	return &TicketWait{
+		Ticket:        *ticket,
+		SalesSlotID:   order.SalesSlotID,
+		Ready:         !queued,
+		EstimatedWait: wait,
+	}, nil
+}
+
+type waitEstimator struct {
+	orderRepo repositories.OrderRepository
+	policy    WaitPolicy
+}
+
+func (e waitEstimator) window() int {
+	if e.policy.Window < 1 {
+		return DefaultWaitPolicy().Window
+	}
+	return e.policy.Window
+}
+
+// kitchenLanes tracks when each lane of the kitchen is next free, counted
+// from now.
+type kitchenLanes []time.Duration
+
+// add gives the work to the lane free soonest and returns when it is done.
+func (l kitchenLanes) add(work time.Duration) time.Duration {
+	next := 0
+	for i := range l {
+		if l[i] < l[next] {
+			next = i
+		}
+	}
+	l[next] += work
+	return l[next]
+}
+
+// kitchenQueue is the expected state of a slot's kitchen queue. waits has
+// how long each order on the queue is expected to take to be ready; lanes
+// are as they will be once all of them are.
+type kitchenQueue struct {
+	waits map[types.ID]time.Duration
+	lanes kitchenLanes
+	times *repositories.PrepTimes
+}
+
+func (e waitEstimator) queue(ctx context.Context, salesSlotID types.ID, now time.Time) (*kitchenQueue, error) {
+	times, err := e.orderRepo.FindPrepTimes(ctx, salesSlotID, e.window())
+	if err != nil {
+		return nil, err
+	}
+	orders, err := e.orderRepo.FindKitchenQueue(ctx, []types.ID{salesSlotID})
+	if err != nil {
+		return nil, err
+	}
+
+	q := &kitchenQueue{
+		waits: make(map[types.ID]time.Duration, len(orders)),
+		lanes: make(kitchenLanes, max(e.policy.Lanes, 1)),
+		times: times,
+	}
+	for _, order := range orders {
+		q.waits[order.ID] = q.lanes.add(e.remaining(times, order.Items, now))
+	}
+	return q, nil
+}
+
+// estimateNew returns how long an order with the items is expected to take
+// to be ready if it is confirmed now.
+func (e waitEstimator) estimateNew(ctx context.Context, salesSlotID types.ID, items []models.OrderItem) (time.Duration, error) {
+	now := time.Now()
+	q, err := e.queue(ctx, salesSlotID, now)
+	if err != nil {
+		return 0, err
+	}
+	return q.lanes.add(e.remaining(q.times, items, now)), nil
+}
+
+// remaining returns how long the items that are not ready still take. Items
+// of an order are prepared together, so it is the longest of them.
+func (e waitEstimator) remaining(times *repositories.PrepTimes, items []models.OrderItem, now time.Time) time.Duration {
+	var longest time.Duration
+	for _, item := range items {
+		if item.PrepStatus == types.READY {
+			continue
+		}
+		left := e.prepTime(times, item.ProductID)
+		if item.PrepStatus == types.PREPARING && item.PrepStartedAt != nil {
+			left -= now.Sub(*item.PrepStartedAt)
+		}
+		if left > longest {
+			longest = left
+		}
+	}
+	return longest
+}
+
+// prepTime returns the product's average preparation time, falling back to
+// the slot's and then to the policy's default.
+func (e waitEstimator) prepTime(times *repositories.PrepTimes, productID types.ID) time.Duration {
+	if t, exists := times.ByProduct[productID]; exists && t > 0 {
+		return t
+	}
+	if times.Slot > 0 {
+		return times.Slot
+	}
+	return e.policy.DefaultPrepTime
+}
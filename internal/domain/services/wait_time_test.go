@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+)
+
+func TestWaitTimeService_Estimates(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	ticketRepo := newMockOrderTicketRepository()
+	ctx := context.Background()
+
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot1", Booth: "A", IsActive: true})
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	order := func(id types.ID, confirmedAt *time.Time, ticketNumber string, items ...models.OrderItem) {
+		for i := range items {
+			items[i].OrderID = id
+		}
+		ticket := &models.OrderTicket{ID: "ticket-" + id, OrderID: id, TicketNumber: ticketNumber}
+		ticketRepo.Create(ctx, ticket)
+		orderRepo.Create(ctx, &models.Order{ID: id, SalesSlotID: "slot1", Status: types.CONFIRMED, ConfirmedAt: confirmedAt, Ticket: ticket, Items: items})
+	}
+
+	// prod1 took 4 and 2 minutes; nothing else has been prepared yet.
+	order("done1", at(-12*time.Minute), "A-1", models.OrderItem{ID: "i1", ProductID: "prod1", PrepStatus: types.READY, PrepStartedAt: at(-10 * time.Minute), ReadyAt: at(-6 * time.Minute)})
+	order("done2", at(-11*time.Minute), "A-2", models.OrderItem{ID: "i2", ProductID: "prod1", PrepStatus: types.READY, PrepStartedAt: at(-5 * time.Minute), ReadyAt: at(-3 * time.Minute)})
+	order("cooking", at(-2*time.Minute), "A-3", models.OrderItem{ID: "i3", ProductID: "prod1", PrepStatus: types.PREPARING, PrepStartedAt: at(-time.Minute)})
+	order("waiting", at(-time.Minute), "A-4", models.OrderItem{ID: "i4", ProductID: "prod2", PrepStatus: types.QUEUED})
+	ticketRepo.tickets["ticket-done1"].IsDelivered = true
+
+	near := func(got, want time.Duration) bool {
+		diff := got - want
+		return diff > -time.Second && diff < time.Second
+	}
+
+	service := NewWaitTimeService(orderRepo, slotRepo, ticketRepo, DefaultWaitPolicy())
+	times, err := service.GetPrepTimes(ctx, "slot1")
+	if err != nil {
+		t.Fatalf("GetPrepTimes failed: %v", err)
+	}
+	if times.ByProduct["prod1"] != 3*time.Minute || times.Slot != 3*time.Minute {
+		t.Errorf("Expected 3 minute averages, got %+v", times)
+	}
+
+	display, err := service.GetCallDisplay(ctx, "A")
+	if err != nil {
+		t.Fatalf("GetCallDisplay failed: %v", err)
+	}
+	if len(display) != 3 || display[0].Ticket.TicketNumber != "A-2" || !display[0].Ready {
+		t.Fatalf("Expected the ready undelivered ticket first, got %+v", display)
+	}
+	// The cooking order has 2 of its 3 minutes left; prod2 has no history,
+	// so the slot's 3 minutes are assumed after it.
+	if display[1].Ready || !near(display[1].EstimatedWait, 2*time.Minute) || !near(display[2].EstimatedWait, 5*time.Minute) {
+		t.Errorf("Expected waits of 2 and 5 minutes, got %v and %v", display[1].EstimatedWait, display[2].EstimatedWait)
+	}
+
+	wait, err := service.GetTicketWait(ctx, "ticket-waiting")
+	if err != nil || !near(wait.EstimatedWait, 5*time.Minute) {
+		t.Errorf("Expected the ticket to wait 5 minutes, got %+v, %v", wait, err)
+	}
+
+	invRepo := newMockInventoryRepository()
+	prodRepo := newMockProductRepository()
+	prodRepo.Create(ctx, &models.Product{ID: "prod1", Name: "焼きそば", Price: 400})
+	slotService := NewSalesSlotService(slotRepo, invRepo, prodRepo, orderRepo, newMockIngredientRepository(), SlotSchedule{}, nil)
+	slotService.AddProductToSlot(ctx, "slot1", "prod1", 10, nil)
+
+	for _, tt := range []struct {
+		lanes int
+		want  time.Duration
+	}{
+		{lanes: 1, want: 8 * time.Minute},
+		// With two lanes the new order starts when the cooking order is done.
+		{lanes: 2, want: 5 * time.Minute},
+	} {
+		policy := DefaultWaitPolicy()
+		policy.Lanes = tt.lanes
+		orderService := NewOrderService(orderRepo, slotRepo, invRepo, prodRepo, newMockOptionGroupRepository(), newMockPromotionRepository(), newMockProductPriceRepository(), newMockIngredientRepository(), DefaultTaxPolicy(), policy, nil)
+		created, err := orderService.CreateOrder(ctx, "slot1", "", []OrderItemInput{{ProductID: "prod1", Quantity: 1}}, nil)
+		if err != nil {
+			t.Fatalf("CreateOrder failed: %v", err)
+		}
+		if created.EstimatedWait == nil || !near(*created.EstimatedWait, tt.want) {
+			t.Errorf("Expected a new order to wait %v with %d lanes, got %v", tt.want, tt.lanes, created.EstimatedWait)
+		}
+	}
+}
+
+func TestWaitTimeService_PrepTimesBySlot(t *testing.T) {
+	orderRepo := newMockOrderRepository()
+	slotRepo := newMockSalesSlotRepository()
+	ctx := context.Background()
+
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot1", Booth: "A", IsActive: true})
+	slotRepo.Create(ctx, &models.SalesSlot{ID: "slot2", Booth: "A"})
+	now := time.Now()
+	item := func(id, slotID, productID types.ID, took time.Duration) {
+		startedAt, readyAt := now.Add(-took), now
+		orderRepo.Create(ctx, &models.Order{ID: id, SalesSlotID: slotID, Status: types.CONFIRMED, Items: []models.OrderItem{
+			{ID: "item-" + id, OrderID: id, ProductID: productID, PrepStatus: types.READY, PrepStartedAt: &startedAt, ReadyAt: &readyAt},
+		}})
+	}
+	// The first slot's yakisoba are quicker than the second's; only the
+	// second slot has made ramune.
+	item("o1", "slot1", "prod1", 2*time.Minute)
+	item("o2", "slot2", "prod1", 6*time.Minute)
+	item("o3", "slot2", "prod1", 8*time.Minute)
+	item("o4", "slot2", "prod2", 4*time.Minute)
+
+	service := NewWaitTimeService(orderRepo, slotRepo, newMockOrderTicketRepository(), DefaultWaitPolicy())
+	for _, tt := range []struct {
+		slotID types.ID
+		want   map[types.ID]time.Duration
+	}{
+		{slotID: "slot1", want: map[types.ID]time.Duration{"prod1": 2 * time.Minute, "prod2": 4 * time.Minute}},
+		{slotID: "slot2", want: map[types.ID]time.Duration{"prod1": 7 * time.Minute, "prod2": 4 * time.Minute}},
+	} {
+		times, err := service.GetPrepTimes(ctx, tt.slotID)
+		if err != nil {
+			t.Fatalf("GetPrepTimes failed: %v", err)
+		}
+		for productID, want := range tt.want {
+			if got := times.ByProduct[productID]; got != want {
+				t.Errorf("%s: expected %s to take %v, got %v", tt.slotID, productID, want, got)
+			}
+		}
+	}
+}
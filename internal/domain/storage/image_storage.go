@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// ImageStorage stores uploaded images under a storage-specific key.
+type ImageStorage interface {
+	Save(ctx context.Context, name string, data []byte) (string, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
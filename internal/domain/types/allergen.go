@@ -0,0 +1,117 @@
+package types
+
+import "strings"
+
+// Allergen covers the allergens labelled on food in Japan: the eight
+// mandatory specified raw materials (特定原材料) followed by the twenty
+// recommended ones (特定原材料に準ずるもの).
+type Allergen int
+
+const (
+	_ Allergen = iota
+	SHRIMP
+	CRAB
+	WALNUT
+	WHEAT
+	BUCKWHEAT
+	EGG
+	MILK
+	PEANUT
+	ALMOND
+	ABALONE
+	SQUID
+	SALMON_ROE
+	ORANGE
+	CASHEW_NUT
+	KIWI
+	BEEF
+	MACADAMIA_NUT
+	SESAME
+	SALMON
+	MACKEREL
+	SOYBEAN
+	CHICKEN
+	BANANA
+	PORK
+	PEACH
+	YAM
+	APPLE
+	GELATIN
+)
+
+var allergenNames = map[Allergen]string{
+	SHRIMP:        "SHRIMP",
+	CRAB:          "CRAB",
+	WALNUT:        "WALNUT",
+	WHEAT:         "WHEAT",
+	BUCKWHEAT:     "BUCKWHEAT",
+	EGG:           "EGG",
+	MILK:          "MILK",
+	PEANUT:        "PEANUT",
+	ALMOND:        "ALMOND",
+	ABALONE:       "ABALONE",
+	SQUID:         "SQUID",
+	SALMON_ROE:    "SALMON_ROE",
+	ORANGE:        "ORANGE",
+	CASHEW_NUT:    "CASHEW_NUT",
+	KIWI:          "KIWI",
+	BEEF:          "BEEF",
+	MACADAMIA_NUT: "MACADAMIA_NUT",
+	SESAME:        "SESAME",
+	SALMON:        "SALMON",
+	MACKEREL:      "MACKEREL",
+	SOYBEAN:       "SOYBEAN",
+	CHICKEN:       "CHICKEN",
+	BANANA:        "BANANA",
+	PORK:          "PORK",
+	PEACH:         "PEACH",
+	YAM:           "YAM",
+	APPLE:         "APPLE",
+	GELATIN:       "GELATIN",
+}
+
+func (a Allergen) String() string {
+	return allergenNames[a]
+}
+
+func ParseAllergen(s string) (Allergen, bool) {
+	for a, name := range allergenNames {
+		if name == strings.ToUpper(s) {
+			return a, true
+		}
+	}
+	return 0, false
+}
+
+// AllergenSet is a bit set of allergens stored as a single integer column.
+type AllergenSet int64
+
+func NewAllergenSet(allergens ...Allergen) AllergenSet {
+	var set AllergenSet
+	for _, a := range allergens {
+		set |= 1 << a
+	}
+	return set
+}
+
+func (s AllergenSet) Has(a Allergen) bool {
+	return s&(1<<a) != 0
+}
+
+func (s AllergenSet) List() []Allergen {
+	var list []Allergen
+	for a := SHRIMP; a <= GELATIN; a++ {
+		if s.Has(a) {
+			list = append(list, a)
+		}
+	}
+	return list
+}
+
+func (s AllergenSet) Strings() []string {
+	list := []string{}
+	for _, a := range s.List() {
+		list = append(list, a.String())
+	}
+	return list
+}
@@ -0,0 +1,62 @@
+package types
+
+import "strings"
+
+type DietaryTag int
+
+const (
+	_ DietaryTag = iota
+	VEGETARIAN
+	VEGAN
+	HALAL
+	GLUTEN_FREE
+	ALCOHOL_FREE
+	SPICY
+)
+
+var dietaryTagNames = map[DietaryTag]string{
+	VEGETARIAN:   "VEGETARIAN",
+	VEGAN:        "VEGAN",
+	HALAL:        "HALAL",
+	GLUTEN_FREE:  "GLUTEN_FREE",
+	ALCOHOL_FREE: "ALCOHOL_FREE",
+	SPICY:        "SPICY",
+}
+
+func (t DietaryTag) String() string {
+	return dietaryTagNames[t]
+}
+
+func ParseDietaryTag(s string) (DietaryTag, bool) {
+	for t, name := range dietaryTagNames {
+		if name == strings.ToUpper(s) {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// DietaryTagSet is a bit set of dietary tags stored as a single integer column.
+type DietaryTagSet int64
+
+func NewDietaryTagSet(tags ...DietaryTag) DietaryTagSet {
+	var set DietaryTagSet
+	for _, t := range tags {
+		set |= 1 << t
+	}
+	return set
+}
+
+func (s DietaryTagSet) Has(t DietaryTag) bool {
+	return s&(1<<t) != 0
+}
+
+func (s DietaryTagSet) Strings() []string {
+	list := []string{}
+	for t := VEGETARIAN; t <= SPICY; t++ {
+		if s.Has(t) {
+			list = append(list, t.String())
+		}
+	}
+	return list
+}
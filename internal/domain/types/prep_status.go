@@ -0,0 +1,33 @@
+package types
+
+// PrepStatus is how far the kitchen is with an order item. Items are QUEUED
+// when their order is confirmed and leave the kitchen queue once READY.
+type PrepStatus int
+
+const (
+	_ PrepStatus = iota
+	QUEUED
+	PREPARING
+	READY
+)
+
+func (s PrepStatus) String() string {
+	switch s {
+	case QUEUED:
+		return "QUEUED"
+	case PREPARING:
+		return "PREPARING"
+	case READY:
+		return "READY"
+	default:
+		return "QUEUED"
+	}
+}
+
+// Next returns the status an item moves to when it is bumped.
+func (s PrepStatus) Next() PrepStatus {
+	if s == PREPARING || s == READY {
+		return READY
+	}
+	return PREPARING
+}
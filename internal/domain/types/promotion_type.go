@@ -0,0 +1,38 @@
+package types
+
+import "strings"
+
+type PromotionType int
+
+const (
+	_ PromotionType = iota
+	PERCENTAGE
+	FIXED_AMOUNT
+	BUY_X_GET_Y
+)
+
+func (t PromotionType) String() string {
+	switch t {
+	case PERCENTAGE:
+		return "PERCENTAGE"
+	case FIXED_AMOUNT:
+		return "FIXED_AMOUNT"
+	case BUY_X_GET_Y:
+		return "BUY_X_GET_Y"
+	default:
+		return "PERCENTAGE"
+	}
+}
+
+func ParsePromotionType(s string) (PromotionType, bool) {
+	switch strings.ToUpper(s) {
+	case "PERCENTAGE":
+		return PERCENTAGE, true
+	case "FIXED_AMOUNT":
+		return FIXED_AMOUNT, true
+	case "BUY_X_GET_Y":
+		return BUY_X_GET_Y, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,33 @@
+package types
+
+import "strings"
+
+type SelectionType int
+
+const (
+	_ SelectionType = iota
+	SINGLE
+	MULTIPLE
+)
+
+func (s SelectionType) String() string {
+	switch s {
+	case SINGLE:
+		return "SINGLE"
+	case MULTIPLE:
+		return "MULTIPLE"
+	default:
+		return "SINGLE"
+	}
+}
+
+func ParseSelectionType(s string) (SelectionType, bool) {
+	switch strings.ToUpper(s) {
+	case "SINGLE":
+		return SINGLE, true
+	case "MULTIPLE":
+		return MULTIPLE, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,96 @@
+package types
+
+import "strings"
+
+// SlotOverride pins a sales slot open or closed regardless of its schedule.
+// AUTO slots are opened and closed by the scheduler.
+type SlotOverride int
+
+const (
+	_ SlotOverride = iota
+	AUTO
+	PINNED_OPEN
+	PINNED_CLOSED
+)
+
+func (o SlotOverride) String() string {
+	switch o {
+	case AUTO:
+		return "AUTO"
+	case PINNED_OPEN:
+		return "PINNED_OPEN"
+	case PINNED_CLOSED:
+		return "PINNED_CLOSED"
+	default:
+		return "AUTO"
+	}
+}
+
+func ParseSlotOverride(s string) (SlotOverride, bool) {
+	switch strings.ToUpper(s) {
+	case "AUTO":
+		return AUTO, true
+	case "PINNED_OPEN":
+		return PINNED_OPEN, true
+	case "PINNED_CLOSED":
+		return PINNED_CLOSED, true
+	default:
+		return 0, false
+	}
+}
+
+// SlotTransitionCause tells what opened or closed a sales slot.
+type SlotTransitionCause int
+
+const (
+	_ SlotTransitionCause = iota
+	SCHEDULED
+	MANUAL
+	OVERRIDE
+)
+
+func (c SlotTransitionCause) String() string {
+	switch c {
+	case SCHEDULED:
+		return "SCHEDULED"
+	case MANUAL:
+		return "MANUAL"
+	case OVERRIDE:
+		return "OVERRIDE"
+	default:
+		return "SCHEDULED"
+	}
+}
+
+// SlotOverlapPolicy decides which sales slots may overlap in time.
+type SlotOverlapPolicy int
+
+const (
+	_ SlotOverlapPolicy = iota
+	// REJECT_OVERLAP allows no overlapping slots at all.
+	REJECT_OVERLAP
+	// OVERLAP_PER_BOOTH allows slots at different booths to overlap.
+	OVERLAP_PER_BOOTH
+)
+
+func (p SlotOverlapPolicy) String() string {
+	switch p {
+	case REJECT_OVERLAP:
+		return "REJECT"
+	case OVERLAP_PER_BOOTH:
+		return "PER_BOOTH"
+	default:
+		return "REJECT"
+	}
+}
+
+func ParseSlotOverlapPolicy(s string) (SlotOverlapPolicy, bool) {
+	switch strings.ToUpper(s) {
+	case "REJECT":
+		return REJECT_OVERLAP, true
+	case "PER_BOOTH":
+		return OVERLAP_PER_BOOTH, true
+	default:
+		return 0, false
+	}
+}
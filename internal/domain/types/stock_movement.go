@@ -0,0 +1,61 @@
+package types
+
+import "strings"
+
+// StockMovementType is the kind of change recorded in the stock ledger.
+// RESTOCK, WASTE, TRANSFER and CORRECTION change the stock a slot has,
+// RESERVATION and RELEASE its reserved stock and SALE its sold stock.
+type StockMovementType int
+
+const (
+	_ StockMovementType = iota
+	RESTOCK
+	WASTE
+	TRANSFER
+	CORRECTION
+	SALE
+	RESERVATION
+	RELEASE
+)
+
+func (t StockMovementType) String() string {
+	switch t {
+	case RESTOCK:
+		return "RESTOCK"
+	case WASTE:
+		return "WASTE"
+	case TRANSFER:
+		return "TRANSFER"
+	case CORRECTION:
+		return "CORRECTION"
+	case SALE:
+		return "SALE"
+	case RESERVATION:
+		return "RESERVATION"
+	case RELEASE:
+		return "RELEASE"
+	default:
+		return "RESTOCK"
+	}
+}
+
+func ParseStockMovementType(s string) (StockMovementType, bool) {
+	switch strings.ToUpper(s) {
+	case "RESTOCK":
+		return RESTOCK, true
+	case "WASTE":
+		return WASTE, true
+	case "TRANSFER":
+		return TRANSFER, true
+	case "CORRECTION":
+		return CORRECTION, true
+	case "SALE":
+		return SALE, true
+	case "RESERVATION":
+		return RESERVATION, true
+	case "RELEASE":
+		return RELEASE, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,125 @@
+package types
+
+import "strings"
+
+// TaxRate is the consumption tax rate a product is sold at. Take-out food
+// qualifies for the reduced rate; everything else uses the standard rate.
+type TaxRate int
+
+const (
+	_ TaxRate = iota
+	STANDARD_RATE
+	REDUCED_RATE
+)
+
+func (r TaxRate) String() string {
+	switch r {
+	case STANDARD_RATE:
+		return "STANDARD"
+	case REDUCED_RATE:
+		return "REDUCED"
+	default:
+		return "STANDARD"
+	}
+}
+
+// Percent returns the rate in percent.
+func (r TaxRate) Percent() int {
+	switch r {
+	case REDUCED_RATE:
+		return 8
+	default:
+		return 10
+	}
+}
+
+func ParseTaxRate(s string) (TaxRate, bool) {
+	switch strings.ToUpper(s) {
+	case "STANDARD":
+		return STANDARD_RATE, true
+	case "REDUCED":
+		return REDUCED_RATE, true
+	default:
+		return 0, false
+	}
+}
+
+// TaxMode tells whether product prices already include consumption tax.
+type TaxMode int
+
+const (
+	_ TaxMode = iota
+	TAX_INCLUSIVE
+	TAX_EXCLUSIVE
+)
+
+func (m TaxMode) String() string {
+	switch m {
+	case TAX_INCLUSIVE:
+		return "INCLUSIVE"
+	case TAX_EXCLUSIVE:
+		return "EXCLUSIVE"
+	default:
+		return "INCLUSIVE"
+	}
+}
+
+func ParseTaxMode(s string) (TaxMode, bool) {
+	switch strings.ToUpper(s) {
+	case "INCLUSIVE":
+		return TAX_INCLUSIVE, true
+	case "EXCLUSIVE":
+		return TAX_EXCLUSIVE, true
+	default:
+		return 0, false
+	}
+}
+
+// TaxRounding is how fractions of a yen are rounded when tax is calculated.
+type TaxRounding int
+
+const (
+	_ TaxRounding = iota
+	ROUND_DOWN
+	ROUND_HALF_UP
+	ROUND_UP
+)
+
+func (r TaxRounding) String() string {
+	switch r {
+	case ROUND_DOWN:
+		return "DOWN"
+	case ROUND_HALF_UP:
+		return "HALF_UP"
+	case ROUND_UP:
+		return "UP"
+	default:
+		return "DOWN"
+	}
+}
+
+// Divide returns n / d rounded according to r. n must not be negative and d
+// must be positive.
+func (r TaxRounding) Divide(n, d int) int {
+	switch r {
+	case ROUND_HALF_UP:
+		return (2*n + d) / (2 * d)
+	case ROUND_UP:
+		return (n + d - 1) / d
+	default:
+		return n / d
+	}
+}
+
+func ParseTaxRounding(s string) (TaxRounding, bool) {
+	switch strings.ToUpper(s) {
+	case "DOWN":
+		return ROUND_DOWN, true
+	case "HALF_UP":
+		return ROUND_HALF_UP, true
+	case "UP":
+		return ROUND_UP, true
+	default:
+		return 0, false
+	}
+}
@@ -38,12 +38,28 @@ func Init() error {
 	db.Exec(`CREATE EXTENSION IF NOT EXISTS "uuid-ossp";`)
 
 	err = db.AutoMigrate(
+		&models.Category{},
 		&models.Product{},
+		&models.BundleComponent{},
+		&models.ProductPrice{},
 		&models.SalesSlot{},
+		&models.SalesSlotTransition{},
 		&models.ProductInventory{},
+		&models.StockMovement{},
+		&models.Ingredient{},
+		&models.RecipeItem{},
+		&models.IngredientStock{},
 		&models.Order{},
+		&models.ProductOptionGroup{},
+		&models.ProductOption{},
 		&models.OrderItem{},
+		&models.OrderItemOption{},
+		&models.OrderItemComponent{},
+		&models.OrderItemChange{},
 		&models.OrderTicket{},
+		&models.Promotion{},
+		&models.OrderDiscount{},
+		&models.OrderTax{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+)
+
+type Handler = events.Handler
+
+// Bus passes each published event to every subscribed handler in the
+// publishing goroutine, so handlers must be quick. Events never leave the
+// process.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []*subscription
+}
+
+type subscription struct {
+	handler Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds the handler and returns a function that removes it.
+func (b *Bus) Subscribe(handler Handler) func() {
+	sub := &subscription{handler: handler}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, sub)
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, s := range b.subscriptions {
+			if s == sub {
+				// Copied rather than edited in place so that a Publish
+				// iterating over the old slice is not affected.
+				subscriptions := make([]*subscription, 0, len(b.subscriptions)-1)
+				subscriptions = append(subscriptions, b.subscriptions[:i]...)
+				b.subscriptions = append(subscriptions, b.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (b *Bus) Publish(ctx context.Context, event events.Event) {
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		sub.handler(ctx, event)
+	}
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/events"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+)
+
+const webhookTimeout = 5 * time.Second
+
+// NewStockAlertWebhook returns a handler that posts low-stock and sold-out
+// alerts to a chat bot's incoming webhook as {"text": "..."}. Posts are made
+// in the background and failures are only logged.
+func NewStockAlertWebhook(url string) Handler {
+	client := &http.Client{Timeout: webhookTimeout}
+	return func(ctx context.Context, event events.Event) {
+		text, ok := stockAlertText(event)
+		if !ok {
+			return
+		}
+		go func() {
+			if err := postWebhook(client, url, text); err != nil {
+				log.Printf("stock alert webhook: %v", err)
+			}
+		}()
+	}
+}
+
+func stockAlertText(event events.Event) (string, bool) {
+	inventory, ok := event.Payload.(*models.ProductInventory)
+	if !ok {
+		return "", false
+	}
+	name := string(inventory.ProductID)
+	if inventory.Product != nil {
+		name = inventory.Product.Name
+	}
+	slot := string(inventory.SalesSlotID)
+	if inventory.SalesSlot != nil {
+		slot = inventory.SalesSlot.StartTime.Local().Format("1/2 15:04")
+	}
+
+	switch event.Type {
+	case events.StockLow:
+		return fmt.Sprintf("在庫わずか: %s（%s の販売枠）残り%d個", name, slot, inventory.GetAvailableQuantity()), true
+	case events.StockSoldOut:
+		return fmt.Sprintf("売り切れ: %s（%s の販売枠）", name, slot), true
+	}
+	return "", false
+}
+
+func postWebhook(client *http.Client, url, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package printing
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// 80mm roll paper with Font A fits 48 half-width columns.
+const escposColumns = 48
+
+var (
+	escInit        = []byte{0x1b, 0x40}
+	escKanjiOn     = []byte{0x1c, 0x26}
+	escShiftJIS    = []byte{0x1c, 0x43, 0x01}
+	escAlignLeft   = []byte{0x1b, 0x61, 0x00}
+	escAlignCenter = []byte{0x1b, 0x61, 0x01}
+	escBoldOn      = []byte{0x1b, 0x45, 0x01}
+	escBoldOff     = []byte{0x1b, 0x45, 0x00}
+	escSizeNormal  = []byte{0x1d, 0x21, 0x00}
+	escSizeDouble  = []byte{0x1d, 0x21, 0x11}
+	escFeedAndCut  = []byte{0x1b, 0x64, 0x04, 0x1d, 0x56, 0x42, 0x00}
+)
+
+type escposWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *escposWriter) cmd(b []byte) {
+	w.buf.Write(b)
+}
+
+func (w *escposWriter) line(s string) error {
+	encoded, err := japanese.ShiftJIS.NewEncoder().String(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q: %w", s, err)
+	}
+	w.buf.WriteString(encoded)
+	w.buf.WriteByte('\n')
+	return nil
+}
+
+func renderESCPOS(title string, doc *receipt.Document) ([]byte, error) {
+	w := &escposWriter{}
+	w.cmd(escInit)
+	w.cmd(escKanjiOn)
+	w.cmd(escShiftJIS)
+
+	w.cmd(escAlignCenter)
+	if doc.Kind == receipt.KindReceipt {
+		w.cmd(escBoldOn)
+		if err := w.line(title); err != nil {
+			return nil, err
+		}
+		w.cmd(escBoldOff)
+	} else {
+		if err := w.line("厨房伝票"); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Reprint {
+		if err := w.line("【再発行】"); err != nil {
+			return nil, err
+		}
+	}
+	w.cmd(escSizeDouble)
+	if err := w.line("No. " + doc.TicketNumber); err != nil {
+		return nil, err
+	}
+	w.cmd(escSizeNormal)
+	w.cmd(escAlignLeft)
+
+	lines := []string{
+		doc.IssuedAt.Format("2006/01/02 15:04"),
+		strings.Repeat("-", escposColumns),
+	}
+	for _, item := range doc.Items {
+		if doc.Kind == receipt.KindKitchenSlip {
+			lines = append(lines, justify(item.Name, fmt.Sprintf("x%d", item.Quantity), escposColumns))
+			lines = append(lines, optionLines(doc.Kind, item, escposColumns)...)
+			continue
+		}
+		lines = append(lines, itemLabel(item))
+		lines = append(lines, optionLines(doc.Kind, item, escposColumns)...)
+		lines = append(lines, justify(fmt.Sprintf("  %s x %d", yen(item.UnitPrice), item.Quantity), yen(item.Subtotal), escposColumns))
+	}
+	lines = append(lines, strings.Repeat("-", escposColumns))
+	lines = append(lines, paymentLines(doc, escposColumns)...)
+
+	for _, l := range lines {
+		if err := w.line(l); err != nil {
+			return nil, err
+		}
+	}
+
+	w.cmd(escFeedAndCut)
+	return w.buf.Bytes(), nil
+}
+
+// optionLines lists the contents of a bundle and the options chosen for an
+// item. Receipts show the price of each paid option; kitchen slips only need
+// the names.
+func optionLines(kind receipt.Kind, item receipt.Item, columns int) []string {
+	var lines []string
+	for _, c := range item.Components {
+		lines = append(lines, justify("  - "+c.Name, fmt.Sprintf("x%d", c.Quantity), columns))
+	}
+	for _, opt := range item.Options {
+		if kind == receipt.KindKitchenSlip || opt.PriceDelta == 0 {
+			lines = append(lines, "  + "+opt.Name)
+			continue
+		}
+		lines = append(lines, justify("  + "+opt.Name, "+"+yen(opt.PriceDelta), columns))
+	}
+	return lines
+}
+
+// reducedRateMark flags items taxed at the reduced rate on receipts.
+const reducedRateMark = "※"
+
+// itemLabel is the receipt line naming an item.
+func itemLabel(item receipt.Item) string {
+	if item.ReducedRate {
+		return item.Name + " " + reducedRateMark
+	}
+	return item.Name
+}
+
+func paymentLines(doc *receipt.Document, columns int) []string {
+	if doc.Kind != receipt.KindReceipt {
+		return nil
+	}
+
+	var lines []string
+	if len(doc.Discounts) > 0 || doc.TaxExclusive {
+		lines = append(lines, justify("小計", yen(doc.Subtotal), columns))
+		for _, d := range doc.Discounts {
+			lines = append(lines, justify("  "+d.Name, yen(-d.Amount), columns))
+		}
+	}
+	if doc.TaxExclusive {
+		for _, t := range doc.Taxes {
+			lines = append(lines,
+				justify(fmt.Sprintf("  %d%%対象", t.Percent), yen(t.TaxableAmount), columns),
+				justify(fmt.Sprintf("  消費税(%d%%)", t.Percent), yen(t.TaxAmount), columns),
+			)
+		}
+	}
+	lines = append(lines, justify("合計", yen(doc.TotalAmount), columns))
+	if !doc.TaxExclusive {
+		for _, t := range doc.Taxes {
+			lines = append(lines, justify(
+				fmt.Sprintf("  (%d%%対象 %s", t.Percent, yen(t.TaxableAmount)),
+				fmt.Sprintf("内消費税 %s)", yen(t.TaxAmount)),
+				columns,
+			))
+		}
+	}
+	lines = append(lines, justify("支払方法", doc.PaymentMethod, columns))
+	if doc.TenderedAmount != nil {
+		lines = append(lines, justify("お預かり", yen(*doc.TenderedAmount), columns))
+	}
+	if doc.ChangeAmount != nil {
+		lines = append(lines, justify("お釣り", yen(*doc.ChangeAmount), columns))
+	}
+	if doc.TransactionID != nil {
+		lines = append(lines, justify("取引ID", *doc.TransactionID, columns))
+	}
+	for _, item := range doc.Items {
+		if item.ReducedRate {
+			lines = append(lines, reducedRateMark+"は軽減税率対象商品です")
+			break
+		}
+	}
+	return lines
+}
+
+// justify places left and right on one line padded to the given display width,
+// counting full-width characters as two columns.
+func justify(left, right string, columns int) string {
+	pad := columns - runewidth.StringWidth(left) - runewidth.StringWidth(right)
+	if pad < 1 {
+		pad = 1
+	}
+	return left + strings.Repeat(" ", pad) + right
+}
+
+// yen formats an amount with thousands separators, e.g. 1200 -> "1,200円".
+func yen(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String() + "円"
+}
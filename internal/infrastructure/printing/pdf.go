@@ -0,0 +1,121 @@
+package printing
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+	"github.com/mattn/go-runewidth"
+)
+
+// Receipts are laid out on an 80mm wide page using a non-embedded Japanese CID
+// font, which every PDF viewer with Japanese support can substitute.
+const (
+	pdfPageWidth  = 226.77
+	pdfMargin     = 12.0
+	pdfFontSize   = 9.0
+	pdfLineHeight = 13.0
+	pdfColumns    = 40
+)
+
+type pdfLine struct {
+	text  string
+	size  float64
+	align int
+}
+
+const (
+	pdfAlignLeft = iota
+	pdfAlignCenter
+)
+
+func renderPDF(title string, doc *receipt.Document) ([]byte, error) {
+	var lines []pdfLine
+	if doc.Kind == receipt.KindReceipt {
+		lines = append(lines, pdfLine{text: title, size: pdfFontSize * 1.2, align: pdfAlignCenter})
+	} else {
+		lines = append(lines, pdfLine{text: "厨房伝票", size: pdfFontSize, align: pdfAlignCenter})
+	}
+	if doc.Reprint {
+		lines = append(lines, pdfLine{text: "【再発行】", size: pdfFontSize, align: pdfAlignCenter})
+	}
+	lines = append(lines,
+		pdfLine{text: "No. " + doc.TicketNumber, size: pdfFontSize * 2, align: pdfAlignCenter},
+		pdfLine{text: doc.IssuedAt.Format("2006/01/02 15:04"), size: pdfFontSize},
+		pdfLine{text: strings.Repeat("-", pdfColumns), size: pdfFontSize},
+	)
+
+	for _, item := range doc.Items {
+		if doc.Kind == receipt.KindKitchenSlip {
+			lines = append(lines, pdfLine{text: justify(item.Name, fmt.Sprintf("x%d", item.Quantity), pdfColumns), size: pdfFontSize})
+		} else {
+			lines = append(lines, pdfLine{text: itemLabel(item), size: pdfFontSize})
+		}
+		for _, l := range optionLines(doc.Kind, item, pdfColumns) {
+			lines = append(lines, pdfLine{text: l, size: pdfFontSize})
+		}
+		if doc.Kind == receipt.KindReceipt {
+			lines = append(lines, pdfLine{text: justify(fmt.Sprintf("  %s x %d", yen(item.UnitPrice), item.Quantity), yen(item.Subtotal), pdfColumns), size: pdfFontSize})
+		}
+	}
+	lines = append(lines, pdfLine{text: strings.Repeat("-", pdfColumns), size: pdfFontSize})
+	for _, l := range paymentLines(doc, pdfColumns) {
+		lines = append(lines, pdfLine{text: l, size: pdfFontSize})
+	}
+
+	height := pdfMargin*2 + pdfLineHeight
+	for _, l := range lines {
+		height += l.size + pdfLineHeight - pdfFontSize
+	}
+
+	var content bytes.Buffer
+	y := height - pdfMargin
+	for _, l := range lines {
+		y -= l.size + pdfLineHeight - pdfFontSize
+		x := pdfMargin
+		if l.align == pdfAlignCenter {
+			x = (pdfPageWidth - float64(runewidth.StringWidth(l.text))*l.size/2) / 2
+		}
+		fmt.Fprintf(&content, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", l.size, x, y, pdfHexString(l.text))
+	}
+
+	return buildPDF(height, content.Bytes()), nil
+}
+
+func buildPDF(height float64, content []byte) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>", pdfPageWidth, height),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		"<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5 /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [6 0 R] >>",
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor 7 0 R /DW 1000 /W [231 325 500] >>",
+		"<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 114 >>",
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+func pdfHexString(s string) string {
+	var b strings.Builder
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	return b.String()
+}
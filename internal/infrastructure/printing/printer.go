@@ -0,0 +1,59 @@
+package printing
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+)
+
+const tcpPrinterTimeout = 5 * time.Second
+
+type tcpPrinter struct {
+	addr string
+}
+
+// NewTCPPrinter sends jobs to a network printer's raw port (usually 9100).
+func NewTCPPrinter(addr string) receipt.Printer {
+	return &tcpPrinter{addr: addr}
+}
+
+func (p *tcpPrinter) Print(ctx context.Context, data []byte) error {
+	dialer := net.Dialer{Timeout: tcpPrinterTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect printer %s: %w", p.addr, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(tcpPrinterTimeout)); err != nil {
+		return err
+	}
+	if _, err := conn.Write(data); err != nil {
+		return fmt.Errorf("failed to write to printer %s: %w", p.addr, err)
+	}
+	return nil
+}
+
+type filePrinter struct {
+	dir string
+	seq atomic.Uint64
+}
+
+// NewFilePrinter writes each job to its own file in dir.
+func NewFilePrinter(dir string) receipt.Printer {
+	return &filePrinter{dir: dir}
+}
+
+func (p *filePrinter) Print(ctx context.Context, data []byte) error {
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%04d.bin", time.Now().Format("20060102-150405"), p.seq.Add(1))
+	return os.WriteFile(filepath.Join(p.dir, name), data, 0o644)
+}
@@ -0,0 +1,87 @@
+package printing
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+)
+
+var (
+	ErrQueueFull   = errors.New("print queue is full")
+	ErrQueueClosed = errors.New("print queue is closed")
+)
+
+const (
+	queueMaxAttempts = 3
+	queueRetryDelay  = 2 * time.Second
+)
+
+// Queue hands jobs to a printer on a background worker so that slow or
+// offline printers never block request handling.
+type Queue struct {
+	printer    receipt.Printer
+	jobs       chan []byte
+	retryDelay time.Duration
+	wg         sync.WaitGroup
+
+	// mu keeps jobs from being sent on the channel once Close has closed it.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewQueue(printer receipt.Printer, size int) *Queue {
+	q := &Queue{
+		printer:    printer,
+		jobs:       make(chan []byte, size),
+		retryDelay: queueRetryDelay,
+	}
+	q.wg.Add(1)
+	go q.run()
+	return q
+}
+
+func (q *Queue) Print(ctx context.Context, data []byte) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.jobs <- data:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops accepting jobs and waits for the queued ones to be printed.
+func (q *Queue) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.jobs)
+	}
+	q.mu.Unlock()
+	q.wg.Wait()
+}
+
+func (q *Queue) run() {
+	defer q.wg.Done()
+	for job := range q.jobs {
+		for attempt := 1; attempt <= queueMaxAttempts; attempt++ {
+			err := q.printer.Print(context.Background(), job)
+			if err == nil {
+				break
+			}
+			log.Printf("print job failed (attempt %d/%d): %v", attempt, queueMaxAttempts, err)
+			if attempt < queueMaxAttempts {
+				time.Sleep(q.retryDelay)
+			}
+		}
+	}
+}
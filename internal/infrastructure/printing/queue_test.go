@@ -0,0 +1,79 @@
+package printing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type mockPrinter struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	printed  [][]byte
+	// block holds every print until it is closed.
+	block chan struct{}
+}
+
+func (p *mockPrinter) Print(ctx context.Context, data []byte) error {
+	if p.block != nil {
+		<-p.block
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.attempts++
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("printer offline")
+	}
+	p.printed = append(p.printed, data)
+	return nil
+}
+
+func TestQueue_PrintsAndRetries(t *testing.T) {
+	printer := &mockPrinter{failures: 2}
+	queue := NewQueue(printer, 4)
+	queue.retryDelay = 0
+	ctx := context.Background()
+
+	if err := queue.Print(ctx, []byte("first")); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	if err := queue.Print(ctx, []byte("second")); err != nil {
+		t.Fatalf("Print failed: %v", err)
+	}
+	queue.Close()
+
+	if len(printer.printed) != 2 || string(printer.printed[0]) != "first" || printer.attempts != 4 {
+		t.Errorf("Expected both jobs printed after two retries, got %q in %d attempts", printer.printed, printer.attempts)
+	}
+}
+
+func TestQueue_Full(t *testing.T) {
+	printer := &mockPrinter{block: make(chan struct{})}
+	queue := NewQueue(printer, 1)
+	ctx := context.Background()
+
+	// The worker takes the first job and blocks on it, so the second fills
+	// the queue.
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = queue.Print(ctx, []byte("job"))
+	}
+	if err != ErrQueueFull {
+		t.Errorf("Expected ErrQueueFull, got %v", err)
+	}
+	close(printer.block)
+	queue.Close()
+}
+
+func TestQueue_PrintAfterClose(t *testing.T) {
+	queue := NewQueue(&mockPrinter{}, 1)
+	queue.Close()
+	queue.Close()
+
+	if err := queue.Print(context.Background(), []byte("late")); err != ErrQueueClosed {
+		t.Errorf("Expected ErrQueueClosed, got %v", err)
+	}
+}
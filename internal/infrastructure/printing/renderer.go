@@ -0,0 +1,26 @@
+package printing
+
+import (
+	"fmt"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+)
+
+type renderer struct {
+	title string
+}
+
+func NewRenderer(title string) receipt.Renderer {
+	return &renderer{title: title}
+}
+
+func (r *renderer) Render(doc *receipt.Document, format receipt.Format) ([]byte, error) {
+	switch format {
+	case receipt.FormatESCPOS:
+		return renderESCPOS(r.title, doc)
+	case receipt.FormatPDF:
+		return renderPDF(r.title, doc)
+	default:
+		return nil, fmt.Errorf("unsupported receipt format: %s", format)
+	}
+}
@@ -0,0 +1,107 @@
+package printing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/receipt"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func testDocument(kind receipt.Kind) *receipt.Document {
+	tendered, change := 2000, 350
+	return &receipt.Document{
+		Kind:         kind,
+		TicketNumber: "A-12",
+		IssuedAt:     time.Date(2026, 10, 18, 12, 30, 0, 0, time.Local),
+		Items: []receipt.Item{
+			{Name: "焼きそば", Quantity: 2, UnitPrice: 450, Subtotal: 900, Options: []receipt.ItemOption{{Name: "大盛り", PriceDelta: 50}}, ReducedRate: true},
+			{Name: "ラムネ", Quantity: 3, UnitPrice: 250, Subtotal: 750},
+		},
+		Subtotal:       1650,
+		TotalAmount:    1650,
+		Taxes:          []receipt.Tax{{Percent: 8, TaxableAmount: 900, TaxAmount: 66}, {Percent: 10, TaxableAmount: 750, TaxAmount: 68}},
+		PaymentMethod:  "CASH",
+		TenderedAmount: &tendered,
+		ChangeAmount:   &change,
+	}
+}
+
+func decodeESCPOS(t *testing.T, data []byte) string {
+	t.Helper()
+	text, err := japanese.ShiftJIS.NewDecoder().Bytes(data)
+	if err != nil {
+		t.Fatalf("Failed to decode ESC/POS output: %v", err)
+	}
+	return string(text)
+}
+
+func TestRenderer_ESCPOS(t *testing.T) {
+	r := NewRenderer("模擬店")
+
+	data, err := r.Render(testDocument(receipt.KindReceipt), receipt.FormatESCPOS)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !bytes.HasPrefix(data, escInit) || !bytes.HasSuffix(data, escFeedAndCut) {
+		t.Error("Expected the output to initialize the printer and end with a cut")
+	}
+	text := decodeESCPOS(t, data)
+	for _, want := range []string{
+		"模擬店",
+		"No. A-12",
+		"2026/10/18 12:30",
+		"焼きそば " + reducedRateMark,
+		justify("  + 大盛り", "+50円", escposColumns),
+		justify("  450円 x 2", "900円", escposColumns),
+		justify("合計", "1,650円", escposColumns),
+		justify("お預かり", "2,000円", escposColumns),
+		justify("お釣り", "350円", escposColumns),
+		reducedRateMark + "は軽減税率対象商品です",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the receipt to contain %q, got\n%s", want, text)
+		}
+	}
+
+	doc := testDocument(receipt.KindKitchenSlip)
+	doc.Reprint = true
+	data, err = r.Render(doc, receipt.FormatESCPOS)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	text = decodeESCPOS(t, data)
+	if !strings.Contains(text, "厨房伝票") || !strings.Contains(text, "【再発行】") || !strings.Contains(text, "  + 大盛り\n") {
+		t.Errorf("Expected a reprinted kitchen slip with the options, got\n%s", text)
+	}
+	if strings.Contains(text, "円") || strings.Contains(text, "模擬店") {
+		t.Errorf("Expected the kitchen slip to leave out prices and the title, got\n%s", text)
+	}
+}
+
+func TestRenderer_PDF(t *testing.T) {
+	data, err := NewRenderer("模擬店").Render(testDocument(receipt.KindReceipt), receipt.FormatPDF)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Error("Expected a complete PDF document")
+	}
+	if !bytes.Contains(data, []byte("<"+pdfHexString("No. A-12")+">")) {
+		t.Error("Expected the ticket number in the PDF content")
+	}
+
+	if _, err := NewRenderer("模擬店").Render(testDocument(receipt.KindReceipt), receipt.Format("png")); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+func TestYen(t *testing.T) {
+	for amount, want := range map[int]string{0: "0円", 999: "999円", 1200: "1,200円", 1234567: "1,234,567円", -1500: "-1,500円"} {
+		if got := yen(amount); got != want {
+			t.Errorf("yen(%d) = %q, want %q", amount, got, want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ingredientRepository struct {
+	db *gorm.DB
+}
+
+func NewIngredientRepository(db *gorm.DB) repositories.IngredientRepository {
+	return &ingredientRepository{db: db}
+}
+
+func (r *ingredientRepository) Create(ctx context.Context, ingredient *models.Ingredient) error {
+	if err := r.db.WithContext(ctx).Create(ingredient).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Create",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *ingredientRepository) FindByID(ctx context.Context, id types.ID) (*models.Ingredient, error) {
+	var ingredient models.Ingredient
+	if err := r.db.WithContext(ctx).First(&ingredient, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("Ingredient", id)
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByID",
+			Err:       err,
+		}
+	}
+	return &ingredient, nil
+}
+
+func (r *ingredientRepository) FindAll(ctx context.Context) ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+	if err := r.db.WithContext(ctx).Order("name").Find(&ingredients).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindAll",
+			Err:       err,
+		}
+	}
+	return ingredients, nil
+}
+
+func (r *ingredientRepository) Update(ctx context.Context, ingredient *models.Ingredient) error {
+	if err := r.db.WithContext(ctx).Save(ingredient).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Update",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+// Delete deletes the ingredient with its recipe items and stock.
+func (r *ingredientRepository) Delete(ctx context.Context, id types.ID) error {
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.RecipeItem{}, "ingredient_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.IngredientStock{}, "ingredient_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.Ingredient{}, "id = ?", id)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Delete",
+			Err:       err,
+		}
+	}
+	if rowsAffected == 0 {
+		return repositories.NewErrNotFound("Ingredient", id)
+	}
+	return nil
+}
+
+func (r *ingredientRepository) FindRecipes(ctx context.Context, productIDs []types.ID) ([]models.RecipeItem, error) {
+	var items []models.RecipeItem
+	if len(productIDs) == 0 {
+		return items, nil
+	}
+	if err := r.db.WithContext(ctx).
+		Preload("Ingredient").
+		Where("product_id IN ?", productIDs).
+		Find(&items).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindRecipes",
+			Err:       err,
+		}
+	}
+	return items, nil
+}
+
+func (r *ingredientRepository) ReplaceRecipe(ctx context.Context, productID types.ID, items []models.RecipeItem) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.RecipeItem{}, "product_id = ?", productID).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].ProductID = productID
+			if err := tx.Omit("Ingredient").Create(&items[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "ReplaceRecipe",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *ingredientRepository) FindStock(ctx context.Context, booth string) ([]models.IngredientStock, error) {
+	var stock []models.IngredientStock
+	if err := r.db.WithContext(ctx).
+		Preload("Ingredient").
+		Where("booth = ?", booth).
+		Find(&stock).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindStock",
+			Err:       err,
+		}
+	}
+	return stock, nil
+}
+
+func (r *ingredientRepository) SetStock(ctx context.Context, booth string, ingredientID types.ID, quantity float64) error {
+	stock := &models.IngredientStock{Booth: booth, IngredientID: ingredientID, Quantity: quantity}
+	if err := r.db.WithContext(ctx).Omit("Ingredient").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "booth"}, {Name: "ingredient_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+	}).Create(stock).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "SetStock",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+// consumeIngredients takes the amounts, keyed by ingredient ID, from the
+// booth's stock. Stock may go below zero; ingredients that were never
+// counted in get a row recording the shortfall.
+func consumeIngredients(tx *gorm.DB, booth string, amounts map[types.ID]float64) error {
+	ingredientIDs := make([]types.ID, 0, len(amounts))
+	for id := range amounts {
+		ingredientIDs = append(ingredientIDs, id)
+	}
+	// Rows are locked in the same order by every caller.
+	sort.Slice(ingredientIDs, func(i, j int) bool { return ingredientIDs[i] < ingredientIDs[j] })
+
+	for _, id := range ingredientIDs {
+		result := tx.Model(&models.IngredientStock{}).
+			Where("booth = ? AND ingredient_id = ?", booth, id).
+			Update("quantity", gorm.Expr("quantity - ?", amounts[id]))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			continue
+		}
+		stock := &models.IngredientStock{Booth: booth, IngredientID: id, Quantity: -amounts[id]}
+		if err := tx.Omit("Ingredient").Create(stock).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
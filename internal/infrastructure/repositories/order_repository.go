@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type orderRepository struct {
@@ -32,7 +34,11 @@ func (r *orderRepository) FindByID(ctx context.Context, id types.ID) (*models.Or
 	if err := r.db.WithContext(ctx).
 		Preload("SalesSlot").
 		Preload("Items").
-		Preload("Items.Product").
+		Preload("Items.Product.Category").
+		Preload("Items.Options").
+		Preload("Items.Components.Product").
+		Preload("Discounts").
+		Preload("Taxes").
 		Preload("Ticket").
 		First(&order, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -52,6 +58,10 @@ func (r *orderRepository) FindAll(ctx context.Context) ([]models.Order, error) {
 		Preload("SalesSlot").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Items.Options").
+		Preload("Items.Components.Product").
+		Preload("Discounts").
+		Preload("Taxes").
 		Preload("Ticket").
 		Find(&orders).Error; err != nil {
 		return nil, &repositories.RepositoryError{
@@ -62,8 +72,15 @@ func (r *orderRepository) FindAll(ctx context.Context) ([]models.Order, error) {
 	return orders, nil
 }
 
+// Update saves the order and replaces its discounts and tax breakdown with
+// order.Discounts and order.Taxes. Items are written through their own
+// methods.
 func (r *orderRepository) Update(ctx context.Context, order *models.Order) error {
-	if err := r.db.WithContext(ctx).Save(order).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveOrder(tx, order)
+	})
+
+	if err != nil {
 		return &repositories.RepositoryError{
 			Operation: "Update",
 			Err:       err,
@@ -74,6 +91,23 @@ func (r *orderRepository) Update(ctx context.Context, order *models.Order) error
 
 func (r *orderRepository) Delete(ctx context.Context, id types.ID) error {
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("order_item_id IN (?)", tx.Model(&models.OrderItem{}).Select("id").Where("order_id = ?", id)).
+			Delete(&models.OrderItemOption{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("order_item_id IN (?)", tx.Model(&models.OrderItem{}).Select("id").Where("order_id = ?", id)).
+			Delete(&models.OrderItemComponent{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.OrderDiscount{}, "order_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.OrderTax{}, "order_id = ?", id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.OrderItemChange{}, "order_id = ?", id).Error; err != nil {
+			return err
+		}
 		if err := tx.Delete(&models.OrderItem{}, "order_id = ?", id).Error; err != nil {
 			return err
 		}
@@ -101,6 +135,10 @@ func (r *orderRepository) FindBySalesSlotID(ctx context.Context, salesSlotID typ
 		Preload("SalesSlot").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Items.Options").
+		Preload("Items.Components.Product").
+		Preload("Discounts").
+		Preload("Taxes").
 		Preload("Ticket").
 		Where("sales_slot_id = ?", salesSlotID).
 		Find(&orders).Error; err != nil {
@@ -118,6 +156,10 @@ func (r *orderRepository) FindByStatus(ctx context.Context, status types.OrderSt
 		Preload("SalesSlot").
 		Preload("Items").
 		Preload("Items.Product").
+		Preload("Items.Options").
+		Preload("Items.Components.Product").
+		Preload("Discounts").
+		Preload("Taxes").
 		Preload("Ticket").
 		Where("status = ?", status).
 		Find(&orders).Error; err != nil {
@@ -129,10 +171,47 @@ func (r *orderRepository) FindByStatus(ctx context.Context, status types.OrderSt
 	return orders, nil
 }
 
+// FindByCustomer returns the customer's orders in the slot, with the items
+// needed to count the stock they take.
+func (r *orderRepository) FindByCustomer(ctx context.Context, salesSlotID types.ID, customerID string) ([]models.Order, error) {
+	var orders []models.Order
+	if err := r.db.WithContext(ctx).
+		Preload("Items").
+		Preload("Items.Components").
+		Where("sales_slot_id = ? AND customer_id = ?", salesSlotID, customerID).
+		Find(&orders).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByCustomer",
+			Err:       err,
+		}
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) FindReservedItemsByBooth(ctx context.Context, booth string) ([]models.OrderItem, error) {
+	var items []models.OrderItem
+	if err := r.db.WithContext(ctx).
+		Preload("Components").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("JOIN sales_slots ON sales_slots.id = orders.sales_slot_id").
+		Where("orders.status = ? AND orders.deleted_at IS NULL AND sales_slots.booth = ?", types.RESERVED, booth).
+		Find(&items).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindReservedItemsByBooth",
+			Err:       err,
+		}
+	}
+	return items, nil
+}
+
 func (r *orderRepository) UpdateStatus(ctx context.Context, id types.ID, status types.OrderStatus) error {
+	updates := map[string]interface{}{"status": status}
+	if status == types.CONFIRMED {
+		updates["confirmed_at"] = time.Now()
+	}
 	result := r.db.WithContext(ctx).Model(&models.Order{}).
 		Where("id = ?", id).
-		Update("status", status)
+		Updates(updates)
 
 	if result.Error != nil {
 		return &repositories.RepositoryError{
@@ -146,40 +225,528 @@ func (r *orderRepository) UpdateStatus(ctx context.Context, id types.ID, status
 	return nil
 }
 
-func (r *orderRepository) AddItems(ctx context.Context, orderID types.ID, items []models.OrderItem) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for i := range items {
-			items[i].OrderID = orderID
-			if err := tx.Create(&items[i]).Error; err != nil {
-				return &repositories.RepositoryError{
-					Operation: "AddItems",
-					Err:       err,
-				}
+func (r *orderRepository) UpdateReservedStatus(ctx context.Context, id types.ID, status types.OrderStatus, movements []models.StockMovement, booth string, ingredients map[types.ID]float64) error {
+	updates := map[string]interface{}{"status": status}
+	if status == types.CONFIRMED {
+		updates["confirmed_at"] = time.Now()
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", id, types.RESERVED).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repositories.ErrOrderStatusChanged
+		}
+
+		for i := range movements {
+			if err := applyMovement(tx, &movements[i]); err != nil {
+				return err
 			}
 		}
-		return nil
+		return consumeIngredients(tx, booth, ingredients)
 	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdateReservedStatus",
+			Err:       err,
+		}
+	}
+	return nil
 }
 
-func (r *orderRepository) CreateWithItems(ctx context.Context, order *models.Order, items []models.OrderItem) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Create(order).Error; err != nil {
-			return &repositories.RepositoryError{
-				Operation: "CreateWithItems",
-				Err:       err,
+func (r *orderRepository) AddItems(ctx context.Context, order *models.Order, updated, added []models.OrderItem, movements []models.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		slot, err := lockSlot(tx, order.SalesSlotID)
+		if err != nil {
+			return err
+		}
+		if err := saveReservedOrderAmounts(tx, order); err != nil {
+			return err
+		}
+
+		for _, item := range updated {
+			result := tx.Model(&models.OrderItem{}).
+				Where("id = ? AND order_id = ?", item.ID, order.ID).
+				Update("quantity", item.Quantity)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return repositories.NewErrNotFound("OrderItem", item.ID)
 			}
 		}
 
+		for i := range added {
+			added[i].OrderID = order.ID
+			if err := tx.Create(&added[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range movements {
+			if err := applyMovement(tx, &movements[i]); err != nil {
+				return err
+			}
+		}
+
+		return checkSlotCapacity(tx, slot, false)
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "AddItems",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) CreateWithItems(ctx context.Context, order *models.Order, items []models.OrderItem, movements []models.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		slot, err := lockSlot(tx, order.SalesSlotID)
+		if err != nil {
+			return err
+		}
+		if err := checkPromotionUsage(tx, order.Discounts); err != nil {
+			return err
+		}
+
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+
 		for i := range items {
 			items[i].OrderID = order.ID
 			if err := tx.Create(&items[i]).Error; err != nil {
-				return &repositories.RepositoryError{
-					Operation: "CreateWithItems",
-					Err:       err,
-				}
+				return err
+			}
+		}
+
+		for i := range movements {
+			movements[i].OrderID = &order.ID
+			if err := applyMovement(tx, &movements[i]); err != nil {
+				return err
 			}
 		}
 
+		return checkSlotCapacity(tx, slot, true)
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "CreateWithItems",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) SummarizeTaxes(ctx context.Context, from, to *time.Time) ([]repositories.TaxSummary, error) {
+	query := r.db.WithContext(ctx).
+		Model(&models.OrderTax{}).
+		Select(`order_taxes.rate,
+			COUNT(DISTINCT order_taxes.order_id) AS orders,
+			SUM(CASE WHEN orders.tax_mode = ? THEN order_taxes.taxable_amount + order_taxes.tax_amount
+				ELSE order_taxes.taxable_amount END) AS sales_amount,
+			SUM(order_taxes.tax_amount) AS tax_amount`, types.TAX_EXCLUSIVE).
+		Joins("JOIN orders ON orders.id = order_taxes.order_id").
+		Where("orders.status = ? AND orders.deleted_at IS NULL", types.CONFIRMED)
+
+	if from != nil {
+		query = query.Where("orders.created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("orders.created_at < ?", *to)
+	}
+
+	var summaries []repositories.TaxSummary
+	if err := query.
+		Group("order_taxes.rate").
+		Order("order_taxes.rate").
+		Scan(&summaries).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "SummarizeTaxes",
+			Err:       err,
+		}
+	}
+	return summaries, nil
+}
+
+func (r *orderRepository) CountBySalesSlots(ctx context.Context, salesSlotIDs []types.ID) ([]repositories.SlotUsage, error) {
+	if len(salesSlotIDs) == 0 {
+		return nil, nil
+	}
+
+	usages, err := countSlotUsage(r.db.WithContext(ctx), salesSlotIDs)
+	if err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "CountBySalesSlots",
+			Err:       err,
+		}
+	}
+	return usages, nil
+}
+
+func countSlotUsage(db *gorm.DB, salesSlotIDs []types.ID) ([]repositories.SlotUsage, error) {
+	var usages []repositories.SlotUsage
+	err := db.Model(&models.Order{}).
+		Select(`orders.sales_slot_id,
+			COUNT(DISTINCT orders.id) AS orders,
+			COALESCE(SUM(order_items.quantity), 0) AS items`).
+		Joins("LEFT JOIN order_items ON order_items.order_id = orders.id").
+		Where("orders.sales_slot_id IN ? AND orders.status <> ?", salesSlotIDs, types.CANCELLED).
+		Group("orders.sales_slot_id").
+		Scan(&usages).Error
+	return usages, err
+}
+
+// lockSlot reads the sales slot and locks its row until the transaction
+// ends, so orders in the slot are counted against its capacity one
+// transaction at a time.
+func lockSlot(tx *gorm.DB, salesSlotID types.ID) (*models.SalesSlot, error) {
+	var slot models.SalesSlot
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "max_orders", "max_items").
+		First(&slot, "id = ?", salesSlotID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("SalesSlot", salesSlotID)
+		}
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// checkPromotionUsage locks the promotions of the discounts that have a
+// usage limit until the transaction ends, so their uses are counted one
+// transaction at a time, and returns repositories.ErrPromotionUsedUp when
+// one of them has been used up.
+func checkPromotionUsage(tx *gorm.DB, discounts []models.OrderDiscount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+	promotionIDs := make([]types.ID, len(discounts))
+	for i, d := range discounts {
+		promotionIDs[i] = d.PromotionID
+	}
+
+	var promotions []models.Promotion
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "usage_limit").
+		Where("id IN ? AND usage_limit IS NOT NULL", promotionIDs).
+		Order("id").
+		Find(&promotions).Error; err != nil {
+		return err
+	}
+	for _, p := range promotions {
+		var used int64
+		if err := promotionUsage(tx).
+			Where("order_discounts.promotion_id = ?", p.ID).
+			Count(&used).Error; err != nil {
+			return err
+		}
+		if int(used) >= *p.UsageLimit {
+			return repositories.ErrPromotionUsedUp
+		}
+	}
+	return nil
+}
+
+// checkSlotCapacity returns repositories.ErrSlotCapacityExceeded when the
+// items in the locked slot, and its orders when orders is set, are over its
+// capacity.
+func checkSlotCapacity(tx *gorm.DB, slot *models.SalesSlot, orders bool) error {
+	if (!orders || slot.MaxOrders == nil) && slot.MaxItems == nil {
 		return nil
+	}
+
+	usages, err := countSlotUsage(tx, []types.ID{slot.ID})
+	if err != nil {
+		return err
+	}
+	var usage repositories.SlotUsage
+	if len(usages) > 0 {
+		usage = usages[0]
+	}
+
+	if orders && slot.MaxOrders != nil && usage.Orders > *slot.MaxOrders {
+		return repositories.ErrSlotCapacityExceeded
+	}
+	if slot.MaxItems != nil && usage.Items > *slot.MaxItems {
+		return repositories.ErrSlotCapacityExceeded
+	}
+	return nil
+}
+
+func saveOrder(tx *gorm.DB, order *models.Order) error {
+	if err := tx.Omit(clause.Associations).Save(order).Error; err != nil {
+		return err
+	}
+	return replaceOrderAmounts(tx, order)
+}
+
+// saveReservedOrderAmounts writes the order's total, discounts and tax
+// breakdown if it is still RESERVED, leaving its other columns as they are.
+// The order's row stays locked until the transaction ends, so it cannot be
+// confirmed or cancelled meanwhile. repositories.ErrOrderStatusChanged is
+// returned when the order is no longer reserved.
+func saveReservedOrderAmounts(tx *gorm.DB, order *models.Order) error {
+	result := tx.Model(&models.Order{}).
+		Where("id = ? AND status = ?", order.ID, types.RESERVED).
+		Updates(map[string]interface{}{
+			"total_amount": order.TotalAmount,
+			"tax_mode":     order.TaxMode,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrOrderStatusChanged
+	}
+	return replaceOrderAmounts(tx, order)
+}
+
+// replaceOrderAmounts replaces the order's discounts and tax breakdown with
+// order.Discounts and order.Taxes.
+func replaceOrderAmounts(tx *gorm.DB, order *models.Order) error {
+	if err := tx.Delete(&models.OrderDiscount{}, "order_id = ?", order.ID).Error; err != nil {
+		return err
+	}
+	for i := range order.Discounts {
+		order.Discounts[i].ID = ""
+		order.Discounts[i].OrderID = order.ID
+		if err := tx.Create(&order.Discounts[i]).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Delete(&models.OrderTax{}, "order_id = ?", order.ID).Error; err != nil {
+		return err
+	}
+	for i := range order.Taxes {
+		order.Taxes[i].ID = ""
+		order.Taxes[i].OrderID = order.ID
+		if err := tx.Create(&order.Taxes[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) ChangeItemQuantity(ctx context.Context, order *models.Order, change *models.OrderItemChange, movements []models.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		slot, err := lockSlot(tx, order.SalesSlotID)
+		if err != nil {
+			return err
+		}
+		if err := saveReservedOrderAmounts(tx, order); err != nil {
+			return err
+		}
+
+		if change.NewQuantity == 0 {
+			if err := tx.Delete(&models.OrderItemOption{}, "order_item_id = ?", change.OrderItemID).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&models.OrderItemComponent{}, "order_item_id = ?", change.OrderItemID).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&models.OrderItem{}, "id = ?", change.OrderItemID).Error; err != nil {
+				return err
+			}
+		} else {
+			if err := tx.Model(&models.OrderItem{}).
+				Where("id = ?", change.OrderItemID).
+				Update("quantity", change.NewQuantity).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range movements {
+			if err := applyMovement(tx, &movements[i]); err != nil {
+				return err
+			}
+		}
+
+		if change.NewQuantity > change.OldQuantity {
+			if err := checkSlotCapacity(tx, slot, false); err != nil {
+				return err
+			}
+		}
+		return tx.Create(change).Error
 	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "ChangeItemQuantity",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) FindItemChanges(ctx context.Context, orderID types.ID) ([]models.OrderItemChange, error) {
+	var changes []models.OrderItemChange
+	if err := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at").
+		Find(&changes).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindItemChanges",
+			Err:       err,
+		}
+	}
+	return changes, nil
+}
+
+func (r *orderRepository) FindKitchenQueue(ctx context.Context, salesSlotIDs []types.ID) ([]models.Order, error) {
+	if len(salesSlotIDs) == 0 {
+		return nil, nil
+	}
+
+	var orders []models.Order
+	if err := r.db.WithContext(ctx).
+		Preload("Items").
+		Preload("Items.Product.Category").
+		Preload("Items.Options").
+		Preload("Items.Components.Product").
+		Preload("Ticket").
+		Where("sales_slot_id IN ? AND status = ?", salesSlotIDs, types.CONFIRMED).
+		Where("EXISTS (?)", r.db.Model(&models.OrderItem{}).
+			Select("1").
+			Where("order_items.order_id = orders.id AND order_items.prep_status <> ?", types.READY)).
+		Order("confirmed_at, created_at").
+		Find(&orders).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindKitchenQueue",
+			Err:       err,
+		}
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) FindItemByID(ctx context.Context, id types.ID) (*models.OrderItem, error) {
+	var item models.OrderItem
+	if err := r.db.WithContext(ctx).
+		Preload("Product.Category").
+		First(&item, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("OrderItem", id)
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindItemByID",
+			Err:       err,
+		}
+	}
+	return &item, nil
+}
+
+func (r *orderRepository) UpdatePrepStatus(ctx context.Context, itemIDs []types.ID, status types.PrepStatus) error {
+	if len(itemIDs) == 0 {
+		return nil
+	}
+
+	updates := map[string]interface{}{"prep_status": status}
+	switch status {
+	case types.PREPARING:
+		updates["prep_started_at"] = time.Now()
+	case types.READY:
+		updates["ready_at"] = time.Now()
+	}
+	if err := r.db.WithContext(ctx).Model(&models.OrderItem{}).
+		Where("id IN ?", itemIDs).
+		Updates(updates).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdatePrepStatus",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *orderRepository) FindUndelivered(ctx context.Context, salesSlotIDs []types.ID) ([]models.Order, error) {
+	if len(salesSlotIDs) == 0 {
+		return nil, nil
+	}
+
+	var orders []models.Order
+	if err := r.db.WithContext(ctx).
+		Preload("Items").
+		Preload("Items.Product").
+		Preload("Ticket").
+		Joins("JOIN order_tickets ON order_tickets.order_id = orders.id AND order_tickets.deleted_at IS NULL").
+		Where("orders.sales_slot_id IN ? AND orders.status = ?", salesSlotIDs, types.CONFIRMED).
+		Where("order_tickets.is_delivered = ?", false).
+		Order("orders.confirmed_at, orders.created_at").
+		Find(&orders).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindUndelivered",
+			Err:       err,
+		}
+	}
+	return orders, nil
+}
+
+func (r *orderRepository) FindPrepTimes(ctx context.Context, salesSlotID types.ID, window int) (*repositories.PrepTimes, error) {
+	// Items in the slot and in other slots are ranked separately, so a
+	// product the slot has prepared only uses the slot's items.
+	var products []struct {
+		ProductID types.ID
+		InSlot    bool
+		Seconds   float64
+	}
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT product_id, in_slot, AVG(EXTRACT(EPOCH FROM ready_at - prep_started_at)) AS seconds
+		FROM (
+			SELECT order_items.product_id, order_items.ready_at, order_items.prep_started_at,
+				orders.sales_slot_id = ? AS in_slot,
+				ROW_NUMBER() OVER (
+					PARTITION BY order_items.product_id, orders.sales_slot_id = ?
+					ORDER BY order_items.ready_at DESC
+				) AS n
+			FROM order_items
+			JOIN orders ON orders.id = order_items.order_id
+			WHERE order_items.ready_at IS NOT NULL AND order_items.prep_started_at IS NOT NULL
+		) AS recent
+		WHERE n <= ?
+		GROUP BY product_id, in_slot`, salesSlotID, salesSlotID, window).
+		Scan(&products).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindPrepTimes",
+			Err:       err,
+		}
+	}
+
+	var slotSeconds float64
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT COALESCE(AVG(EXTRACT(EPOCH FROM ready_at - prep_started_at)), 0)
+		FROM (
+			SELECT order_items.ready_at, order_items.prep_started_at
+			FROM order_items
+			JOIN orders ON orders.id = order_items.order_id
+			WHERE orders.sales_slot_id = ?
+				AND order_items.ready_at IS NOT NULL AND order_items.prep_started_at IS NOT NULL
+			ORDER BY order_items.ready_at DESC
+			LIMIT ?
+		) AS recent`, salesSlotID, window).
+		Scan(&slotSeconds).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindPrepTimes",
+			Err:       err,
+		}
+	}
+
+	times := &repositories.PrepTimes{
+		ByProduct: make(map[types.ID]time.Duration, len(products)),
+		Slot:      time.Duration(slotSeconds * float64(time.Second)),
+	}
+	for _, p := range products {
+		if _, exists := times.ByProduct[p.ProductID]; exists && !p.InSlot {
+			continue
+		}
+		times.ByProduct[p.ProductID] = time.Duration(p.Seconds * float64(time.Second))
+	}
+	return times, nil
 }
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
@@ -32,6 +33,9 @@ func (r *orderTicketRepository) FindByID(ctx context.Context, id types.ID) (*mod
 	if err := r.db.WithContext(ctx).
 		Preload("Order").
 		Preload("Order.Items").
+		Preload("Order.Items.Options").
+		Preload("Order.Items.Components.Product").
+		Preload("Order.Discounts").
 		Preload("Order.SalesSlot").
 		First(&ticket, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -50,6 +54,9 @@ func (r *orderTicketRepository) FindAll(ctx context.Context) ([]models.OrderTick
 	if err := r.db.WithContext(ctx).
 		Preload("Order").
 		Preload("Order.Items").
+		Preload("Order.Items.Options").
+		Preload("Order.Items.Components.Product").
+		Preload("Order.Discounts").
 		Preload("Order.SalesSlot").
 		Find(&tickets).Error; err != nil {
 		return nil, &repositories.RepositoryError{
@@ -89,6 +96,9 @@ func (r *orderTicketRepository) FindByTicketNumber(ctx context.Context, ticketNu
 	if err := r.db.WithContext(ctx).
 		Preload("Order").
 		Preload("Order.Items").
+		Preload("Order.Items.Options").
+		Preload("Order.Items.Components.Product").
+		Preload("Order.Discounts").
 		Preload("Order.SalesSlot").
 		Where("ticket_number = ?", ticketNumber).
 		First(&ticket).Error; err != nil {
@@ -111,6 +121,9 @@ func (r *orderTicketRepository) FindByOrderID(ctx context.Context, orderID types
 	if err := r.db.WithContext(ctx).
 		Preload("Order").
 		Preload("Order.Items").
+		Preload("Order.Items.Options").
+		Preload("Order.Items.Components.Product").
+		Preload("Order.Discounts").
 		Preload("Order.SalesSlot").
 		Where("order_id = ?", orderID).
 		First(&ticket).Error; err != nil {
@@ -125,9 +138,15 @@ func (r *orderTicketRepository) FindByOrderID(ctx context.Context, orderID types
 	return &ticket, nil
 }
 
-func (r *orderTicketRepository) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string) error {
+func (r *orderTicketRepository) UpdatePaymentStatus(ctx context.Context, id types.ID, isPaid bool, transactionID *string, tenderedAmount, changeAmount *int) error {
 	updates := map[string]interface{}{
-		"is_paid": isPaid,
+		"is_paid":         isPaid,
+		"tendered_amount": tenderedAmount,
+		"change_amount":   changeAmount,
+		"paid_at":         nil,
+	}
+	if isPaid {
+		updates["paid_at"] = time.Now()
 	}
 	if transactionID != nil {
 		updates["transaction_id"] = transactionID
@@ -150,9 +169,16 @@ func (r *orderTicketRepository) UpdatePaymentStatus(ctx context.Context, id type
 }
 
 func (r *orderTicketRepository) UpdateDeliveryStatus(ctx context.Context, id types.ID, isDelivered bool) error {
+	updates := map[string]interface{}{
+		"is_delivered": isDelivered,
+		"delivered_at": nil,
+	}
+	if isDelivered {
+		updates["delivered_at"] = time.Now()
+	}
 	result := r.db.WithContext(ctx).Model(&models.OrderTicket{}).
 		Where("id = ?", id).
-		Update("is_delivered", isDelivered)
+		Updates(updates)
 
 	if result.Error != nil {
 		return &repositories.RepositoryError{
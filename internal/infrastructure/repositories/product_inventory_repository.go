@@ -7,6 +7,7 @@ import (
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
 	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type productInventoryRepository struct {
@@ -130,17 +131,180 @@ func (r *productInventoryRepository) FindBySalesSlotAndProduct(ctx context.Conte
 	return &inventory, nil
 }
 
-func (r *productInventoryRepository) UpdateQuantities(ctx context.Context, id types.ID, reserved, sold int) error {
+func (r *productInventoryRepository) ApplyMovements(ctx context.Context, movements []models.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range movements {
+			if err := applyMovement(tx, &movements[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "ApplyMovements",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+// applyMovement adds the movement's deltas to the stock of its slot and
+// product and records it. Stock is only taken away, reserved or sold while
+// the initial quantity still covers everything reserved and sold;
+// repositories.ErrStockShortage is returned otherwise.
+func applyMovement(tx *gorm.DB, movement *models.StockMovement) error {
+	var inventory models.ProductInventory
+	if err := tx.Select("id").
+		Where("sales_slot_id = ? AND product_id = ?", movement.SalesSlotID, movement.ProductID).
+		First(&inventory).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return repositories.NewErrNotFound("ProductInventory", movement.ProductID)
+		}
+		return err
+	}
+	movement.InventoryID = inventory.ID
+
+	initial, reserved, sold := movement.Deltas()
+	query := tx.Model(&models.ProductInventory{}).Where("id = ?", inventory.ID)
+	if initial < 0 || reserved > 0 || sold > 0 {
+		query = query.Where("initial_quantity + ? >= reserved_quantity + ? + sold_quantity + ?", initial, reserved, sold)
+	}
+	result := query.Updates(map[string]interface{}{
+		"initial_quantity":  gorm.Expr("initial_quantity + ?", initial),
+		"reserved_quantity": gorm.Expr("reserved_quantity + ?", reserved),
+		"sold_quantity":     gorm.Expr("sold_quantity + ?", sold),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrStockShortage
+	}
+	return tx.Create(movement).Error
+}
+
+func (r *productInventoryRepository) FindMovements(ctx context.Context, inventoryID types.ID) ([]models.StockMovement, error) {
+	var movements []models.StockMovement
+	if err := r.db.WithContext(ctx).
+		Where("inventory_id = ?", inventoryID).
+		Order("created_at").
+		Find(&movements).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindMovements",
+			Err:       err,
+		}
+	}
+	return movements, nil
+}
+
+func (r *productInventoryRepository) Reconcile(ctx context.Context, inventoryID types.ID, reconcile func(inventory *models.ProductInventory, movements []models.StockMovement) []models.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Stock movements update the inventory's row before they are
+		// recorded, so the lock keeps the ledger from changing meanwhile.
+		var inventory models.ProductInventory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&inventory, "id = ?", inventoryID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return repositories.NewErrNotFound("ProductInventory", inventoryID)
+			}
+			return err
+		}
+		var movements []models.StockMovement
+		if err := tx.Where("inventory_id = ?", inventoryID).
+			Order("created_at").
+			Find(&movements).Error; err != nil {
+			return err
+		}
+
+		opening := reconcile(&inventory, movements)
+		if err := tx.Model(&models.ProductInventory{}).
+			Where("id = ?", inventoryID).
+			Updates(map[string]interface{}{
+				"initial_quantity":  inventory.InitialQuantity,
+				"reserved_quantity": inventory.ReservedQuantity,
+				"sold_quantity":     inventory.SoldQuantity,
+			}).Error; err != nil {
+			return err
+		}
+		if len(opening) == 0 {
+			return nil
+		}
+		return tx.Create(&opening).Error
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Reconcile",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *productInventoryRepository) UpdateLimits(ctx context.Context, id types.ID, maxPerOrder, maxPerCustomer *int) error {
 	result := r.db.WithContext(ctx).Model(&models.ProductInventory{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
-			"reserved_quantity": reserved,
-			"sold_quantity":     sold,
+			"max_per_order":    maxPerOrder,
+			"max_per_customer": maxPerCustomer,
 		})
 
 	if result.Error != nil {
 		return &repositories.RepositoryError{
-			Operation: "UpdateQuantities",
+			Operation: "UpdateLimits",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("ProductInventory", id)
+	}
+	return nil
+}
+
+func (r *productInventoryRepository) UpdateLowStockThreshold(ctx context.Context, id types.ID, threshold *int) error {
+	result := r.db.WithContext(ctx).Model(&models.ProductInventory{}).
+		Where("id = ?", id).
+		Update("low_stock_threshold", threshold)
+
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdateLowStockThreshold",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("ProductInventory", id)
+	}
+	return nil
+}
+
+func (r *productInventoryRepository) UpdateAvailability(ctx context.Context, id types.ID, unavailable bool) error {
+	result := r.db.WithContext(ctx).Model(&models.ProductInventory{}).
+		Where("id = ?", id).
+		Update("unavailable", unavailable)
+
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdateAvailability",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("ProductInventory", id)
+	}
+	return nil
+}
+
+func (r *productInventoryRepository) UpdatePrice(ctx context.Context, id types.ID, price *int) error {
+	result := r.db.WithContext(ctx).Model(&models.ProductInventory{}).
+		Where("id = ?", id).
+		Update("price", price)
+
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdatePrice",
 			Err:       result.Error,
 		}
 	}
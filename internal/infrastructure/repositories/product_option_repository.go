@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+type productOptionGroupRepository struct {
+	db *gorm.DB
+}
+
+func NewProductOptionGroupRepository(db *gorm.DB) repositories.ProductOptionGroupRepository {
+	return &productOptionGroupRepository{db: db}
+}
+
+func (r *productOptionGroupRepository) Create(ctx context.Context, group *models.ProductOptionGroup) error {
+	if err := r.db.WithContext(ctx).Create(group).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Create",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *productOptionGroupRepository) FindByID(ctx context.Context, id types.ID) (*models.ProductOptionGroup, error) {
+	var group models.ProductOptionGroup
+	if err := r.db.WithContext(ctx).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order, name")
+		}).
+		First(&group, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("ProductOptionGroup", id)
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByID",
+			Err:       err,
+		}
+	}
+	return &group, nil
+}
+
+func (r *productOptionGroupRepository) FindAll(ctx context.Context) ([]models.ProductOptionGroup, error) {
+	var groups []models.ProductOptionGroup
+	if err := r.db.WithContext(ctx).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order, name")
+		}).
+		Order("display_order, name").
+		Find(&groups).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindAll",
+			Err:       err,
+		}
+	}
+	return groups, nil
+}
+
+func (r *productOptionGroupRepository) FindByProductID(ctx context.Context, productID types.ID) ([]models.ProductOptionGroup, error) {
+	var groups []models.ProductOptionGroup
+	if err := r.db.WithContext(ctx).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("display_order, name")
+		}).
+		Where("product_id = ?", productID).
+		Order("display_order, name").
+		Find(&groups).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByProductID",
+			Err:       err,
+		}
+	}
+	return groups, nil
+}
+
+// Update saves the group and replaces its options with group.Options.
+func (r *productOptionGroupRepository) Update(ctx context.Context, group *models.ProductOptionGroup) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Options").Save(group).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.ProductOption{}, "group_id = ?", group.ID).Error; err != nil {
+			return err
+		}
+		for i := range group.Options {
+			group.Options[i].GroupID = group.ID
+			if err := tx.Create(&group.Options[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Update",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *productOptionGroupRepository) Delete(ctx context.Context, id types.ID) error {
+	var rowsAffected int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.ProductOption{}, "group_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.ProductOptionGroup{}, "id = ?", id)
+		rowsAffected = result.RowsAffected
+		return result.Error
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Delete",
+			Err:       err,
+		}
+	}
+	if rowsAffected == 0 {
+		return repositories.NewErrNotFound("ProductOptionGroup", id)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+type productPriceRepository struct {
+	db *gorm.DB
+}
+
+func NewProductPriceRepository(db *gorm.DB) repositories.ProductPriceRepository {
+	return &productPriceRepository{db: db}
+}
+
+func (r *productPriceRepository) Create(ctx context.Context, price *models.ProductPrice) error {
+	if err := r.db.WithContext(ctx).Create(price).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Create",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *productPriceRepository) FindByID(ctx context.Context, id types.ID) (*models.ProductPrice, error) {
+	var price models.ProductPrice
+	if err := r.db.WithContext(ctx).First(&price, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("ProductPrice", id)
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByID",
+			Err:       err,
+		}
+	}
+	return &price, nil
+}
+
+func (r *productPriceRepository) FindByProductID(ctx context.Context, productID types.ID) ([]models.ProductPrice, error) {
+	var prices []models.ProductPrice
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("effective_from, created_at").
+		Find(&prices).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByProductID",
+			Err:       err,
+		}
+	}
+	return prices, nil
+}
+
+func (r *productPriceRepository) FindEffective(ctx context.Context, productID types.ID, at time.Time) (*models.ProductPrice, error) {
+	var price models.ProductPrice
+	if err := r.db.WithContext(ctx).
+		Where("product_id = ? AND effective_from <= ?", productID, at).
+		Order("effective_from DESC, created_at DESC").
+		First(&price).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("ProductPrice", productID)
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindEffective",
+			Err:       err,
+		}
+	}
+	return &price, nil
+}
+
+func (r *productPriceRepository) FindEffectiveAll(ctx context.Context, at time.Time) ([]models.ProductPrice, error) {
+	var prices []models.ProductPrice
+	if err := r.db.WithContext(ctx).
+		Raw(`SELECT DISTINCT ON (product_id) * FROM product_prices
+			WHERE effective_from <= ?
+			ORDER BY product_id, effective_from DESC, created_at DESC`, at).
+		Scan(&prices).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindEffectiveAll",
+			Err:       err,
+		}
+	}
+	return prices, nil
+}
+
+func (r *productPriceRepository) Delete(ctx context.Context, id types.ID) error {
+	result := r.db.WithContext(ctx).Delete(&models.ProductPrice{}, "id = ?", id)
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "Delete",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("ProductPrice", id)
+	}
+	return nil
+}
@@ -18,7 +18,7 @@ func NewProductRepository(db *gorm.DB) repositories.ProductRepository {
 }
 
 func (r *productRepository) Create(ctx context.Context, product *models.Product) error {
-	if err := r.db.WithContext(ctx).Create(product).Error; err != nil {
+	if err := r.db.WithContext(ctx).Omit("Category", "Components.Product").Create(product).Error; err != nil {
 		return &repositories.RepositoryError{
 			Operation: "Create",
 			Err:       err,
@@ -29,7 +29,10 @@ func (r *productRepository) Create(ctx context.Context, product *models.Product)
 
 func (r *productRepository) FindByID(ctx context.Context, id types.ID) (*models.Product, error) {
 	var product models.Product
-	if err := r.db.WithContext(ctx).First(&product, "id = ?", id).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Components.Product").
+		First(&product, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, repositories.NewErrNotFound("Product", id)
 		}
@@ -43,7 +46,10 @@ func (r *productRepository) FindByID(ctx context.Context, id types.ID) (*models.
 
 func (r *productRepository) FindAll(ctx context.Context) ([]models.Product, error) {
 	var products []models.Product
-	if err := r.db.WithContext(ctx).Find(&products).Error; err != nil {
+	if err := r.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Components.Product").
+		Find(&products).Error; err != nil {
 		return nil, &repositories.RepositoryError{
 			Operation: "FindAll",
 			Err:       err,
@@ -52,8 +58,25 @@ func (r *productRepository) FindAll(ctx context.Context) ([]models.Product, erro
 	return products, nil
 }
 
+// Update saves the product and replaces its bundle components with product.Components.
 func (r *productRepository) Update(ctx context.Context, product *models.Product) error {
-	if err := r.db.WithContext(ctx).Save(product).Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Category", "Components").Save(product).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.BundleComponent{}, "bundle_id = ?", product.ID).Error; err != nil {
+			return err
+		}
+		for i := range product.Components {
+			product.Components[i].BundleID = product.ID
+			if err := tx.Omit("Product").Create(&product.Components[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
 		return &repositories.RepositoryError{
 			Operation: "Update",
 			Err:       err,
@@ -92,3 +115,28 @@ func (r *productRepository) FindByName(ctx context.Context, name string) (*model
 	}
 	return &product, nil
 }
+
+func (r *productRepository) FindByFilter(ctx context.Context, filter repositories.ProductFilter) ([]models.Product, error) {
+	query := r.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Components.Product").
+		Joins("LEFT JOIN categories ON categories.id = products.category_id")
+
+	if filter.CategoryID != nil {
+		query = query.Where("products.category_id = ?", *filter.CategoryID)
+	}
+	if filter.ExcludeAllergens != 0 {
+		query = query.Where("products.allergens & ? = 0", filter.ExcludeAllergens)
+	}
+
+	var products []models.Product
+	if err := query.
+		Order("categories.display_order NULLS LAST, products.name").
+		Find(&products).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByFilter",
+			Err:       err,
+		}
+	}
+	return products, nil
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/models"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/repositories"
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/types"
+	"gorm.io/gorm"
+)
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) repositories.PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	if err := r.db.WithContext(ctx).Create(promotion).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Create",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *promotionRepository) FindByID(ctx context.Context, id types.ID) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := r.db.WithContext(ctx).First(&promotion, "id = ?", id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("Promotion", id)
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByID",
+			Err:       err,
+		}
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) FindAll(ctx context.Context) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if err := r.db.WithContext(ctx).Order("created_at").Find(&promotions).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindAll",
+			Err:       err,
+		}
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *models.Promotion) error {
+	if err := r.db.WithContext(ctx).Save(promotion).Error; err != nil {
+		return &repositories.RepositoryError{
+			Operation: "Update",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id types.ID) error {
+	result := r.db.WithContext(ctx).Delete(&models.Promotion{}, "id = ?", id)
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "Delete",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("Promotion", id)
+	}
+	return nil
+}
+
+func (r *promotionRepository) FindActive(ctx context.Context) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if err := r.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		Order("created_at").
+		Find(&promotions).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindActive",
+			Err:       err,
+		}
+	}
+	return promotions, nil
+}
+
+func (r *promotionRepository) FindByCode(ctx context.Context, code string) (*models.Promotion, error) {
+	var promotion models.Promotion
+	if err := r.db.WithContext(ctx).First(&promotion, "code = ?", code).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("Promotion", types.ID(code))
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindByCode",
+			Err:       err,
+		}
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) usageQuery(ctx context.Context) *gorm.DB {
+	return promotionUsage(r.db.WithContext(ctx))
+}
+
+// promotionUsage queries the discounts of the orders that have not been
+// cancelled, each of which counts as a use of its promotion.
+func promotionUsage(db *gorm.DB) *gorm.DB {
+	return db.
+		Table("order_discounts").
+		Joins("JOIN orders ON orders.id = order_discounts.order_id").
+		Where("orders.deleted_at IS NULL AND orders.status <> ?", types.CANCELLED)
+}
+
+func (r *promotionRepository) CountUsage(ctx context.Context, promotionID types.ID) (int, error) {
+	var count int64
+	if err := r.usageQuery(ctx).
+		Where("order_discounts.promotion_id = ?", promotionID).
+		Count(&count).Error; err != nil {
+		return 0, &repositories.RepositoryError{
+			Operation: "CountUsage",
+			Err:       err,
+		}
+	}
+	return int(count), nil
+}
+
+func (r *promotionRepository) SummarizeUsage(ctx context.Context) ([]repositories.PromotionUsage, error) {
+	var usage []repositories.PromotionUsage
+	if err := r.usageQuery(ctx).
+		Select("order_discounts.promotion_id, promotions.name, COUNT(*) AS uses, SUM(order_discounts.amount) AS discount_amount").
+		Joins("JOIN promotions ON promotions.id = order_discounts.promotion_id").
+		Group("order_discounts.promotion_id, promotions.name").
+		Order("discount_amount DESC").
+		Scan(&usage).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "SummarizeUsage",
+			Err:       err,
+		}
+	}
+	return usage, nil
+}
@@ -118,6 +118,141 @@ func (r *salesSlotRepository) ActivateSlot(ctx context.Context, id types.ID) err
 	return nil
 }
 
+func (r *salesSlotRepository) FindOverlapping(ctx context.Context, start, end time.Time) ([]models.SalesSlot, error) {
+	var slots []models.SalesSlot
+	if err := r.db.WithContext(ctx).
+		Where("start_time < ? AND end_time > ?", end, start).
+		Order("start_time").
+		Find(&slots).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindOverlapping",
+			Err:       err,
+		}
+	}
+	return slots, nil
+}
+
+func (r *salesSlotRepository) FindNext(ctx context.Context, booth string, after time.Time) (*models.SalesSlot, error) {
+	var slot models.SalesSlot
+	if err := r.db.WithContext(ctx).
+		Where("booth = ? AND start_time >= ?", booth, after).
+		Order("start_time").
+		First(&slot).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, repositories.NewErrNotFound("SalesSlot", types.ID(booth))
+		}
+		return nil, &repositories.RepositoryError{
+			Operation: "FindNext",
+			Err:       err,
+		}
+	}
+	return &slot, nil
+}
+
+func (r *salesSlotRepository) UpdateTimes(ctx context.Context, id types.ID, booth string, start, end time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.SalesSlot{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"booth":      booth,
+			"start_time": start,
+			"end_time":   end,
+		})
+
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdateTimes",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	return nil
+}
+
+func (r *salesSlotRepository) DeleteWithOrders(ctx context.Context, id types.ID, orderIDs []types.ID, movements []models.StockMovement) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the slot keeps new orders out of it until it is deleted.
+		if _, err := lockSlot(tx, id); err != nil {
+			return err
+		}
+
+		if len(orderIDs) > 0 {
+			result := tx.Model(&models.Order{}).
+				Where("id IN ? AND sales_slot_id = ? AND status = ?", orderIDs, id, types.RESERVED).
+				Where("NOT EXISTS (?)", tx.Model(&models.OrderTicket{}).
+					Select("1").
+					Where("order_tickets.order_id = orders.id AND order_tickets.is_paid")).
+				Update("status", types.CANCELLED)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected != int64(len(orderIDs)) {
+				return repositories.ErrOrderStatusChanged
+			}
+			if err := tx.Delete(&models.OrderTicket{}, "order_id IN ?", orderIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		var remaining int64
+		if err := tx.Model(&models.Order{}).
+			Where("sales_slot_id = ? AND status <> ?", id, types.CANCELLED).
+			Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return repositories.ErrOrderStatusChanged
+		}
+
+		for i := range movements {
+			if err := applyMovement(tx, &movements[i]); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Delete(&models.ProductInventory{}, "sales_slot_id = ?", id).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&models.SalesSlot{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repositories.NewErrNotFound("SalesSlot", id)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return &repositories.RepositoryError{
+			Operation: "DeleteWithOrders",
+			Err:       err,
+		}
+	}
+	return nil
+}
+
+func (r *salesSlotRepository) UpdateCapacity(ctx context.Context, id types.ID, maxOrders, maxItems *int) error {
+	result := r.db.WithContext(ctx).Model(&models.SalesSlot{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"max_orders": maxOrders,
+			"max_items":  maxItems,
+		})
+
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdateCapacity",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	return nil
+}
+
 func (r *salesSlotRepository) DeactivateSlot(ctx context.Context, id types.ID) error {
 	result := r.db.WithContext(ctx).Model(&models.SalesSlot{}).
 		Where("id = ?", id).
@@ -134,3 +269,109 @@ func (r *salesSlotRepository) DeactivateSlot(ctx context.Context, id types.ID) e
 	}
 	return nil
 }
+
+func (r *salesSlotRepository) UpdateOverride(ctx context.Context, id types.ID, override types.SlotOverride) error {
+	result := r.db.WithContext(ctx).Model(&models.SalesSlot{}).
+		Where("id = ?", id).
+		Update("override", override)
+
+	if result.Error != nil {
+		return &repositories.RepositoryError{
+			Operation: "UpdateOverride",
+			Err:       result.Error,
+		}
+	}
+	if result.RowsAffected == 0 {
+		return repositories.NewErrNotFound("SalesSlot", id)
+	}
+	return nil
+}
+
+func (r *salesSlotRepository) FindOutOfSchedule(ctx context.Context, openBy, closedAfter time.Time) ([]models.SalesSlot, error) {
+	var slots []models.SalesSlot
+	if err := r.db.WithContext(ctx).
+		Where("override NOT IN ?", []types.SlotOverride{types.PINNED_OPEN, types.PINNED_CLOSED}).
+		Where(r.db.
+			Where("is_active = ? AND start_time <= ? AND end_time > ?", false, openBy, closedAfter).
+			Or("is_active = ? AND (start_time > ? OR end_time <= ?)", true, openBy, closedAfter)).
+		Find(&slots).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindOutOfSchedule",
+			Err:       err,
+		}
+	}
+	return slots, nil
+}
+
+func (r *salesSlotRepository) Transition(ctx context.Context, transition *models.SalesSlotTransition) (bool, error) {
+	changed := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if transition.IsActive {
+			if err := checkBoothFree(tx, transition.SalesSlotID); err != nil {
+				return err
+			}
+		}
+
+		// The conditional update lets only one of several server instances
+		// make the same transition.
+		result := tx.Model(&models.SalesSlot{}).
+			Where("id = ? AND is_active = ?", transition.SalesSlotID, !transition.IsActive).
+			Update("is_active", transition.IsActive)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		changed = true
+		return tx.Create(transition).Error
+	})
+	if err != nil {
+		return false, &repositories.RepositoryError{
+			Operation: "Transition",
+			Err:       err,
+		}
+	}
+	return changed, nil
+}
+
+// checkBoothFree returns repositories.ErrBoothSlotActive when another slot
+// in the slot's booth is active. It holds a lock on the booth until the
+// transaction ends, so slots in the same booth are opened one at a time.
+func checkBoothFree(tx *gorm.DB, salesSlotID types.ID) error {
+	var slot models.SalesSlot
+	if err := tx.Select("id", "booth").First(&slot, "id = ?", salesSlotID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "sales_slot_booth:"+slot.Booth).Error; err != nil {
+		return err
+	}
+
+	var active int64
+	if err := tx.Model(&models.SalesSlot{}).
+		Where("booth = ? AND is_active = ? AND id <> ?", slot.Booth, true, slot.ID).
+		Count(&active).Error; err != nil {
+		return err
+	}
+	if active > 0 {
+		return repositories.ErrBoothSlotActive
+	}
+	return nil
+}
+
+func (r *salesSlotRepository) FindTransitions(ctx context.Context, salesSlotID types.ID) ([]models.SalesSlotTransition, error) {
+	var transitions []models.SalesSlotTransition
+	if err := r.db.WithContext(ctx).
+		Where("sales_slot_id = ?", salesSlotID).
+		Order("created_at").
+		Find(&transitions).Error; err != nil {
+		return nil, &repositories.RepositoryError{
+			Operation: "FindTransitions",
+			Err:       err,
+		}
+	}
+	return transitions, nil
+}
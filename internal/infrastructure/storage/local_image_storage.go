@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/SeikoStudentCouncil/timeseats-backend/internal/domain/storage"
+)
+
+type localImageStorage struct {
+	dir string
+}
+
+// NewLocalImageStorage stores images as files in dir. Keys are plain file
+// names so that they can never point outside of dir.
+func NewLocalImageStorage(dir string) storage.ImageStorage {
+	return &localImageStorage{dir: dir}
+}
+
+func (s *localImageStorage) Save(ctx context.Context, name string, data []byte) (string, error) {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", err
+	}
+
+	key := filepath.Base(name)
+	if err := os.WriteFile(filepath.Join(s.dir, key), data, 0o644); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (s *localImageStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, filepath.Base(key)))
+}
+
+func (s *localImageStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(filepath.Join(s.dir, filepath.Base(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}